@@ -4,10 +4,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 	matrixrain "github.com/symbolmove/symbol_move/pkg/effects/matrix-rain"
+	"github.com/symbolmove/symbol_move/pkg/record"
 )
 
 func main() {
@@ -17,6 +18,7 @@ func main() {
 		density  string
 		charset  string
 		fps      int
+		recordTo string
 		help     bool
 	)
 
@@ -24,6 +26,7 @@ func main() {
 	flag.StringVar(&density, "density", "medium", "字符雨密度: sparse, medium, dense")
 	flag.StringVar(&charset, "charset", "mixed", "字符集: digits, letters, katakana, mixed")
 	flag.IntVar(&fps, "fps", 30, "帧率 (默认 30)")
+	flag.StringVar(&recordTo, "record", "", "把画面录制为 asciicast v2 文件，如 out.cast")
 	flag.BoolVar(&help, "help", false, "显示帮助信息")
 
 	flag.Parse()
@@ -93,10 +96,25 @@ func main() {
 		os.Exit(1)
 	}
 
+	// 录制时字符雨绘制到录制屏幕
+	var rainScreen tcell.Screen = screen
+	var recorder *record.Recorder
+	if recordTo != "" {
+		width, height := screen.Size()
+		recorder, err = record.Create(recordTo, width, height, "matrix-rain")
+		if err != nil {
+			screen.Fini()
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		rainScreen = recorder.Wrap(screen)
+	}
+
 	// 创建字符雨效果
-	rain := matrixrain.New(screen, config)
+	rain := matrixrain.New(rainScreen, config)
 
-	// 主循环
+	// 主循环（时钟负责帧率控制，调整大小在两帧之间进行）
+	clock := effects.NewClock()
 	quit := make(chan struct{})
 	go func() {
 		for {
@@ -110,24 +128,25 @@ func main() {
 				}
 			case *tcell.EventResize:
 				// 处理终端大小调整
-				rain.Resize()
+				w, h := screen.Size()
+				clock.Do(func() { rain.Resize(w, h) })
 				screen.Sync()
 			}
 		}
 	}()
 
-	// 帧率控制
-	ticker := time.NewTicker(time.Second / time.Duration(fps))
-	defer ticker.Stop()
-
 	// 渲染循环
-	for {
-		select {
-		case <-quit:
-			return
-		case <-ticker.C:
-			rain.Update()
-			rain.Render()
+	clock.Run(fps, quit, func(deltaTime float64) {
+		rain.Update(deltaTime)
+		rain.Render()
+	})
+
+	// 结束录制（先恢复终端再报告错误）
+	if recorder != nil {
+		screen.Fini()
+		if err := recorder.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "录制失败: %v\n", err)
+			os.Exit(1)
 		}
 	}
 }
@@ -147,6 +166,8 @@ func printHelp() {
 	fmt.Println("        字符集: digits, letters, katakana, mixed (默认 mixed)")
 	fmt.Println("  -fps int")
 	fmt.Println("        帧率 (默认 30)")
+	fmt.Println("  -record string")
+	fmt.Println("        把画面录制为 asciicast v2 文件，如 out.cast")
 	fmt.Println("  -help")
 	fmt.Println("        显示此帮助信息")
 	fmt.Println()
@@ -157,4 +178,5 @@ func printHelp() {
 	fmt.Println("  matrix-rain")
 	fmt.Println("  matrix-rain -speed fast -density dense")
 	fmt.Println("  matrix-rain -charset katakana -speed slow")
+	fmt.Println("  matrix-rain -record demo.cast")
 }
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/theme"
+	"github.com/symbolmove/symbol_move/pkg/transition"
+)
+
+// command 子命令
+type command struct {
+	name    string                    // 命令名
+	args    string                    // 参数说明
+	summary string                    // 简要说明
+	run     func(args []string) error // 执行函数
+}
+
+// commands 所有子命令（按帮助信息中的显示顺序）
+var commands = []command{
+	{"list", "", "列出所有特效", runList},
+	{"run", "<effect-id>", "直接运行指定特效（ESC 退出）", runRun},
+	{"info", "<effect-id>", "显示特效的详细信息", runInfo},
+	{"random", "", "随机运行一个特效（ESC 退出）", runRandom},
+	{"split", "<effect-id> <effect-id>...", "分屏同时运行多个特效（ESC 退出）", runSplit},
+	{"playlist", "[名称 | 特效...]", "按播放列表轮流播放特效（屏保模式）", runPlaylist},
+	{"export", "<effect-id> --gif out.gif", "把特效导出为动画 GIF", runExport},
+}
+
+// runCommand 执行子命令，返回进程退出码
+func runCommand(name string, args []string) int {
+	if name == "help" {
+		printUsage()
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		if err := cmd.run(args); err != nil {
+			if err == flag.ErrHelp {
+				return 0
+			}
+			fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "未知命令: %s\n\n", name)
+	printUsage()
+	return 2
+}
+
+// printUsage 打印总体帮助信息
+func printUsage() {
+	out := flag.CommandLine.Output()
+
+	fmt.Fprintln(out, "符动世界 (SymbolMove) - 字符符号在动，创造世界")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "用法:")
+	fmt.Fprintln(out, "  symbol-move [选项]              启动交互式特效选择器")
+	fmt.Fprintln(out, "  symbol-move <命令> [参数] [选项]")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "命令:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintf(out, "  %-8s %s\n", "help", "显示此帮助信息")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "选项:")
+	flag.PrintDefaults()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "使用 symbol-move <命令> -h 查看命令的选项")
+}
+
+// newFlagSet 创建子命令的参数集
+func newFlagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		usage := "symbol-move " + name
+		if args != "" {
+			usage += " " + args
+		}
+		fmt.Fprintf(fs.Output(), "用法: %s [选项]\n\n选项:\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs 解析参数，选项可以出现在位置参数之前或之后，返回位置参数
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// paramFlag 可重复的 --set name=value 选项，收集特效参数
+type paramFlag map[string]any
+
+// String 实现 flag.Value 接口
+func (p paramFlag) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, value := range p {
+		pairs = append(pairs, name+"="+effects.FormatParamValue(value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Set 实现 flag.Value 接口
+func (p paramFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("参数格式应为 name=value: %s", s)
+	}
+	p[strings.TrimSpace(name)] = value
+	return nil
+}
+
+// registerParamFlag 注册 --set 选项（使用 symbol-move info <effect-id> 查看可用参数）
+func registerParamFlag(fs *flag.FlagSet) {
+	fs.Var(effectParams, "set", "设置特效参数 name=value，可重复使用（参数见 info 命令）")
+}
+
+// registerRunFlags 注册运行特效相关的选项（选择器、run 与 random 共用）
+func registerRunFlags(fs *flag.FlagSet) {
+	fs.Int64Var(&seed, "seed", 0, "随机种子，相同种子重放相同动画 (默认 0 表示随机)")
+	fs.Float64Var(&speed, "speed", 1.0, "速度倍率")
+	fs.BoolVar(&fixedStep, "fixed", false, "使用固定步长时钟，每帧推进 1/FPS 秒")
+	fs.StringVar(&recordTo, "record", "", "把特效画面录制为 asciicast v2 文件，如 out.cast")
+	fs.StringVar(&configPath, "config", "", "配置文件路径，运行中修改会立即生效 (默认 ~/.symbolmove/config.json)")
+	registerPaletteFlag(fs)
+	fs.StringVar(&themeName, "theme", "", "主题（界面配色与特效默认调色板）: "+strings.Join(theme.Names(), ", ")+"，以及 ~/.symbolmove/themes 中的主题 (默认使用配置或 dark)")
+}
+
+// registerPaletteFlag 注册 --palette 选项
+func registerPaletteFlag(fs *flag.FlagSet) {
+	fs.StringVar(&paletteName, "palette", "", "调色板: "+strings.Join(palette.Names(), ", ")+"，或逗号分隔的颜色如 #001020,#08f,white (默认使用配置或特效自己的配色)")
+}
+
+// registerTransitionFlags 注册过渡效果相关的选项（选择器与 playlist 共用）
+func registerTransitionFlags(fs *flag.FlagSet) {
+	fs.StringVar(&transitionName, "transition", "", "切换特效时的过渡效果: "+strings.Join(transition.Names(), ", ")+" (默认 crossfade)")
+	fs.DurationVar(&transitionTime, "transition-time", 0, "过渡时长，如 800ms")
+}
+
+// scanConfigFlag 在解析参数之前找出 --config 指定的配置文件路径
+func scanConfigFlag(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// runList 执行 list 子命令
+func runList(args []string) error {
+	fs := newFlagSet("list", "")
+	idsOnly := fs.Bool("ids", false, "只输出特效 ID（每行一个，便于脚本使用）")
+	tag := fs.String("tag", "", "只列出带有指定标签的特效")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("多余的参数: %v", positional)
+	}
+
+	list := filterByTag(effects.List(), *tag)
+	if *idsOnly {
+		for _, metadata := range list {
+			fmt.Println(metadata.ID)
+		}
+		return nil
+	}
+
+	mgr := i18n.GetManager()
+
+	// 计算 ID 与名称列的显示宽度（中文字符占两列）
+	idWidth, nameWidth := len("ID"), uniseg.StringWidth("名称")
+	for _, metadata := range list {
+		name := mgr.GetEffectName(metadata.Name, metadata.NameEN)
+		idWidth = max(idWidth, len(metadata.ID))
+		nameWidth = max(nameWidth, uniseg.StringWidth(name))
+	}
+
+	fmt.Printf("%s  %s  %s\n", pad("ID", idWidth), pad("名称", nameWidth), "描述")
+	for _, metadata := range list {
+		name := mgr.GetEffectName(metadata.Name, metadata.NameEN)
+		desc := mgr.GetEffectDescription(metadata.Description, metadata.DescriptionEN)
+		fmt.Printf("%s  %s  %s\n", pad(metadata.ID, idWidth), pad(name, nameWidth), desc)
+	}
+
+	fmt.Printf("\n共 %d 个特效，使用 symbol-move run <effect-id> 运行\n", len(list))
+	return nil
+}
+
+// runRun 执行 run 子命令
+func runRun(args []string) error {
+	fs := newFlagSet("run", "<effect-id>")
+	registerRunFlags(fs)
+	registerParamFlag(fs)
+	fs.DurationVar(&runDuration, "duration", 0, "运行时长，到时自动退出，如 30s (默认 0 表示直到按 ESC)")
+	pcm := fs.String("pcm", "", "audio-visualizer 的 PCM 音频输入，- 表示标准输入，也可以是命名管道或 WAV 文件（等同于 --set pcm=...）")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要指定一个特效 ID")
+	}
+	if *pcm != "" {
+		effectParams["pcm"] = *pcm
+	}
+
+	// 在进入全屏之前校验特效和参数
+	id := positional[0]
+	factory, err := effects.Get(id)
+	if err != nil {
+		return err
+	}
+	if err := effects.ApplyParams(factory(), effectParams); err != nil {
+		return err
+	}
+
+	return runStandalone(id)
+}
+
+// runInfo 执行 info 子命令
+func runInfo(args []string) error {
+	fs := newFlagSet("info", "<effect-id>")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要指定一个特效 ID")
+	}
+
+	factory, err := effects.Get(positional[0])
+	if err != nil {
+		return err
+	}
+
+	effect := factory()
+	metadata := effect.Metadata()
+	mgr := i18n.GetManager()
+
+	fmt.Printf("%s (%s)\n", mgr.GetEffectName(metadata.Name, metadata.NameEN), metadata.ID)
+	fmt.Println(mgr.GetEffectDescription(metadata.Description, metadata.DescriptionEN))
+	fmt.Println()
+
+	printField := func(label, value string) {
+		if value != "" {
+			fmt.Printf("  %s: %s\n", label, value)
+		}
+	}
+	printField("中文名称", metadata.Name)
+	printField("英文名称", metadata.NameEN)
+	printField("作者", metadata.Author)
+	printField("版本", metadata.Version)
+	printField("标签", strings.Join(metadata.Tags, ", "))
+	_, seedable := effect.(effects.Seeder)
+	printField("随机种子", supportText(seedable))
+
+	if ps := effects.ParamsOf(effect); ps != nil {
+		fmt.Println()
+		fmt.Println("参数 (--set name=value):")
+		printParams(ps)
+	}
+
+	if long := strings.TrimSpace(metadata.LongDescription); long != "" {
+		fmt.Println()
+		fmt.Println(long)
+	}
+
+	fmt.Printf("\n运行: symbol-move run %s\n", metadata.ID)
+	return nil
+}
+
+// printParams 打印参数表：名称、类型与取值范围、默认值、说明
+func printParams(ps *effects.ParamSet) {
+	mgr := i18n.GetManager()
+	params := ps.Params()
+
+	rows := make([][3]string, len(params))
+	widths := [3]int{}
+	for i, p := range params {
+		value, _ := ps.Get(p.Name)
+		rows[i] = [3]string{p.Name, paramTypeText(p), effects.FormatParamValue(value)}
+		for j, cell := range rows[i] {
+			widths[j] = max(widths[j], uniseg.StringWidth(cell))
+		}
+	}
+
+	for i, p := range params {
+		label := mgr.GetEffectName(p.Label, p.LabelEN)
+		fmt.Printf("  %s  %s  默认 %s  %s\n", pad(rows[i][0], widths[0]), pad(rows[i][1], widths[1]), pad(rows[i][2], widths[2]), label)
+	}
+}
+
+// paramTypeText 返回参数类型及取值范围的说明
+func paramTypeText(p effects.Param) string {
+	switch {
+	case p.Type == effects.ParamEnum:
+		return strings.Join(p.Options, "|")
+	case (p.Type == effects.ParamInt || p.Type == effects.ParamFloat) && p.Ranged():
+		return fmt.Sprintf("%s [%s, %s]", p.Type, effects.FormatParamValue(p.Min), effects.FormatParamValue(p.Max))
+	}
+	return string(p.Type)
+}
+
+// runRandom 执行 random 子命令
+func runRandom(args []string) error {
+	fs := newFlagSet("random", "")
+	registerRunFlags(fs)
+	fs.DurationVar(&runDuration, "duration", 0, "运行时长，到时自动退出，如 30s (默认 0 表示直到按 ESC)")
+	tag := fs.String("tag", "", "只从带有指定标签的特效中选择")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("多余的参数: %v", positional)
+	}
+
+	list := filterByTag(effects.List(), *tag)
+	if len(list) == 0 {
+		return fmt.Errorf("没有带有标签 %q 的特效", *tag)
+	}
+
+	// 相同的种子选中相同的特效
+	rng := effects.NewRand(seed)
+	return runStandalone(list[rng.Intn(len(list))].ID)
+}
+
+// runStandalone 在终端中单独运行特效，特效退出后程序随之退出
+func runStandalone(id string) error {
+	screen, err := openScreen()
+	if err != nil {
+		return err
+	}
+
+	err = runEffect(screen, id, nil)
+	closeScreen(screen)
+
+	// 配置文件的问题只提示，不影响退出状态
+	var warning *configWarning
+	if errors.As(err, &warning) {
+		fmt.Fprintf(os.Stderr, "警告: %v\n", warning)
+		return nil
+	}
+	return err
+}
+
+// filterByTag 筛选带有指定标签的特效（忽略大小写），tag 为空时返回全部
+func filterByTag(list []effects.Metadata, tag string) []effects.Metadata {
+	if tag == "" {
+		return list
+	}
+
+	filtered := make([]effects.Metadata, 0, len(list))
+	for _, metadata := range list {
+		for _, t := range metadata.Tags {
+			if strings.EqualFold(t, tag) {
+				filtered = append(filtered, metadata)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// supportText 返回是否支持某项能力的说明
+func supportText(supported bool) string {
+	if supported {
+		return "支持"
+	}
+	return "不支持"
+}
+
+// pad 按显示宽度在右侧补齐空格
+func pad(s string, width int) string {
+	if n := uniseg.StringWidth(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+)
+
+// playing 正在运行的特效及其时钟
+type playing struct {
+	effect effects.Effect
+	clock  *effects.Clock
+}
+
+// pumpEvents 启动键盘与鼠标监听协程，把事件转发给 current 返回的特效（返回 nil 表示暂无特效）
+// ESC 调用 stop；onKey 不为 nil 时先处理按键，返回 true 表示已处理；特效不使用的按键用于时钟控制。
+// 返回的函数结束监听并等待协程退出，之后的事件留给选择器或错误界面；屏幕关闭后 PollEvent 返回 nil
+func pumpEvents(screen tcell.Screen, current func() *playing, stop func(), onKey func(ev *tcell.EventKey) bool) func() {
+	done := make(chan struct{})
+	polling := make(chan struct{})
+	go func() {
+		defer close(polling)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			switch ev := screen.PollEvent().(type) {
+			case nil, *tcell.EventInterrupt:
+				return
+			case *tcell.EventKey:
+				if ev.Key() == tcell.KeyEscape {
+					stop()
+					return
+				}
+				if onKey != nil && onKey(ev) {
+					continue
+				}
+				if cur := current(); cur != nil && !effects.SendKey(cur.effect, cur.clock, ev) {
+					handleClockKey(cur.clock, ev)
+				}
+			case *tcell.EventMouse:
+				if cur := current(); cur != nil {
+					effects.SendMouse(cur.effect, cur.clock, ev)
+				}
+			case *tcell.EventResize:
+				screen.Sync()
+				if cur := current(); cur != nil {
+					width, height := ev.Size()
+					effects.NotifyResize(cur.effect, cur.clock, width, height)
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			// 协程阻塞在 PollEvent 时由 EventInterrupt 唤醒
+			select {
+			case <-polling:
+			default:
+				screen.PostEvent(tcell.NewEventInterrupt(nil))
+				<-polling
+			}
+		})
+	}
+}
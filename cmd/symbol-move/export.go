@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/export"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+)
+
+// runExport 执行 export 子命令
+// 用法: symbol-move export <effect-id> --gif out.gif [选项]
+func runExport(args []string) error {
+	opts := export.DefaultOptions()
+
+	fs := newFlagSet("export", "<effect-id> --gif out.gif")
+	gifPath := fs.String("gif", "", "输出的 GIF 文件路径")
+	fs.IntVar(&opts.Width, "width", opts.Width, "字符网格列数")
+	fs.IntVar(&opts.Height, "height", opts.Height, "字符网格行数")
+	fs.DurationVar(&opts.Duration, "duration", opts.Duration, "导出时长，如 5s、1m")
+	fs.IntVar(&opts.FPS, "fps", opts.FPS, fmt.Sprintf("GIF 帧率 (%d-%d)", export.MinFPS, export.MaxFPS))
+	fs.Int64Var(&opts.Seed, "seed", 0, "随机种子，相同种子导出相同动画 (默认 0 表示随机)")
+	registerParamFlag(fs)
+	registerPaletteFlag(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return fmt.Errorf("需要指定一个特效 ID")
+	}
+	id := positional[0]
+	if *gifPath == "" {
+		return fmt.Errorf("缺少输出路径，请使用 --gif 指定")
+	}
+
+	factory, err := effects.Get(id)
+	if err != nil {
+		return err
+	}
+
+	effect := factory()
+	if err := effects.ApplyParams(effect, effectParams); err != nil {
+		return err
+	}
+	if paletteName != "" {
+		if opts.Palette, err = palette.Parse(paletteName); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Create(*gifPath)
+	if err != nil {
+		return fmt.Errorf("创建 GIF 文件失败: %w", err)
+	}
+
+	if err := export.WriteGIF(file, effect, opts); err != nil {
+		file.Close()
+		os.Remove(*gifPath)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("写入 GIF 文件失败: %w", err)
+	}
+
+	fmt.Printf("已导出 %s -> %s\n", id, *gifPath)
+	return nil
+}
@@ -1,10 +1,19 @@
 package main
 
 import (
+	"cmp"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/config"
 	"github.com/symbolmove/symbol_move/pkg/effects"
 	_ "github.com/symbolmove/symbol_move/pkg/effects/audio-visualizer"  // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/big-clock"         // 自动注册
@@ -22,6 +31,7 @@ import (
 	_ "github.com/symbolmove/symbol_move/pkg/effects/plasma"            // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/qrcode-gen"        // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/rainbow-wave"      // 自动注册
+	_ "github.com/symbolmove/symbol_move/pkg/effects/scenes"            // 自动注册（组合场景）
 	_ "github.com/symbolmove/symbol_move/pkg/effects/snake-ai"          // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/snowfall"          // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/starry-sky"        // 自动注册
@@ -29,48 +39,163 @@ import (
 	_ "github.com/symbolmove/symbol_move/pkg/effects/typewriter-code"   // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/water-ripple"      // 自动注册
 	_ "github.com/symbolmove/symbol_move/pkg/effects/wave-text"         // 自动注册
+	"github.com/symbolmove/symbol_move/pkg/frame"
 	"github.com/symbolmove/symbol_move/pkg/i18n"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/playlist"
+	"github.com/symbolmove/symbol_move/pkg/record"
+	"github.com/symbolmove/symbol_move/pkg/theme"
+	"github.com/symbolmove/symbol_move/pkg/transition"
 	"github.com/symbolmove/symbol_move/pkg/ui/selector"
 )
 
+// 命令行参数
+var (
+	seed      int64   // 随机种子（0 表示每次运行使用不同的随机序列）
+	speed     float64 // 初始速度倍率
+	fixedStep bool    // 是否使用固定步长时钟
+	recordTo  string  // asciicast 录制文件路径（空表示不录制）
+
+	configPath string // 配置文件路径（空表示 ~/.symbolmove/config.json）
+
+	paletteName string // 特效使用的调色板（空表示使用配置）
+	themeName   string // 界面与特效默认配色的主题（空表示使用配置）
+
+	transitionName string        // 切换特效时的过渡效果（空表示使用配置）
+	transitionTime time.Duration // 过渡时长（0 表示使用配置）
+
+	runDuration  time.Duration     // run/random 子命令的运行时长（0 表示直到用户退出）
+	effectParams = make(paramFlag) // run/export 子命令通过 --set 指定的特效参数
+)
+
+// switchTransitionTime 从选择器启动特效及返回选择器时的默认过渡时长
+const switchTransitionTime = 600 * time.Millisecond
+
+// recorder 特效录制器（未启用录制时为 nil）
+var recorder *record.Recorder
+
+// appConfig 应用配置（界面语言、设置面板保存的参数、最近运行的特效等）
+var appConfig *config.Store
+
 func main() {
-	// 加载用户语言配置
-	mgr := i18n.GetManager()
-	mgr.LoadConfig() // 忽略错误，使用默认值
+	// 加载应用配置，配置文件有错误时使用默认配置并提示用户
+	// 配置文件在解析其他参数之前加载，以便尽早设置界面语言
+	var configErr error
+	appConfig, configErr = config.Open(scanConfigFlag(os.Args[1:]))
+	i18n.GetManager().SetLanguage(appConfig.Config().Language)
+
+	// 用户主题在注册选项之前加载，--theme 的说明中会列出它们
+	configErr = errors.Join(configErr, loadThemes())
+
+	// 命令行参数（总体帮助信息中也会列出）
+	registerRunFlags(flag.CommandLine)
+	registerTransitionFlags(flag.CommandLine)
+	flag.Usage = printUsage
+
+	// 子命令
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		if configErr != nil {
+			fmt.Fprintf(os.Stderr, "警告: %v\n", configErr)
+		}
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
 
-	// 初始化终端
-	screen, err := tcell.NewScreen()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "初始化终端失败: %v\n", err)
-		os.Exit(1)
+	flag.Parse()
+	if _, _, err := switchTransition(); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(2)
+	}
+	if _, err := currentTheme(); err != nil {
+		fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+		os.Exit(2)
 	}
 
-	if err := screen.Init(); err != nil {
-		fmt.Fprintf(os.Stderr, "初始化屏幕失败: %v\n", err)
+	// 没有子命令时启动交互式选择器
+	screen, err := openScreen()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
 	}
 
-	defer screen.Fini()
+	if configErr != nil {
+		showError(screen, configErr.Error())
+	}
 
 	// 运行主循环
-	if err := runMainLoop(screen); err != nil {
-		screen.Fini()
+	err = runMainLoop(screen)
+	closeScreen(screen)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "运行错误: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// openScreen 初始化终端，启用录制时同时创建录制器
+func openScreen() (tcell.Screen, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, fmt.Errorf("初始化终端失败: %w", err)
+	}
+
+	if err := screen.Init(); err != nil {
+		return nil, fmt.Errorf("初始化屏幕失败: %w", err)
+	}
+	// 只需要按键与拖动事件，鼠标移动不产生事件
+	screen.EnableMouse(tcell.MouseButtonEvents | tcell.MouseDragEvents)
+
+	// 只录制特效画面，选择器界面停留的时间不计入录制
+	if recordTo != "" {
+		width, height := screen.Size()
+		recorder, err = record.Create(recordTo, width, height, "symbol-move")
+		if err != nil {
+			screen.Fini()
+			return nil, err
+		}
+		recorder.Pause()
+	}
+
+	return screen, nil
+}
+
+// closeScreen 恢复终端，然后结束录制并报告录制过程中的错误
+func closeScreen(screen tcell.Screen) {
+	screen.Fini()
+
+	if recorder == nil {
+		return
+	}
+
+	if err := recorder.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "录制失败: %v\n", err)
+	}
+}
+
 // runMainLoop 主循环 - 选择器和特效之间的状态机
 func runMainLoop(screen tcell.Screen) error {
-	sel := selector.New(screen)
+	t, duration, err := switchTransition()
+	if err != nil {
+		return err
+	}
+	uiTheme, err := currentTheme()
+	if err != nil {
+		return err
+	}
+
+	// 选择器绘制到过渡屏幕，从特效返回时可以过渡回选择器
+	ts := transition.NewScreen(screen)
+	sel := selector.New(ts)
+	sel.SetConfig(appConfig)
+	sel.SetTheme(uiTheme)
+	sel.SetPalettes(effectPalette)
 
 	for {
 		// 显示选择器界面
 		sel.Refresh()
 		sel.Render()
 
-		// 等待用户选择
+		// 等待用户选择，之后停止预览（启动特效或退出）
 		_, quit := waitForSelection(screen, sel)
+		sel.StopPreview()
 
 		if quit {
 			// 用户选择退出
@@ -83,15 +208,22 @@ func runMainLoop(screen tcell.Screen) error {
 			continue
 		}
 
-		// 运行特效
-		if err := runEffect(screen, metadata.ID); err != nil {
-			// 显示错误（简单处理）
-			showError(screen, fmt.Sprintf("特效运行错误: %v", err))
+		// 运行特效，由选择器画面过渡到特效
+		intro := &handoff{transition: t, from: frame.Capture(screen), duration: duration}
+		if err := runEffect(screen, metadata.ID, intro); err != nil {
+			// 显示错误（简单处理），配置文件的问题原样显示
+			message := fmt.Sprintf("特效运行错误: %v", err)
+			var warning *configWarning
+			if errors.As(err, &warning) {
+				message = warning.Error()
+			}
+			showError(screen, message)
 			continue
 		}
 
-		// 特效结束后，循环回到选择器
-		sel.Render()
+		// 特效结束后，由特效的最后一帧过渡回选择器
+		sel.Refresh()
+		ts.Animate(t, frame.Capture(screen), duration, sel.Render)
 	}
 }
 
@@ -121,92 +253,256 @@ func waitForSelection(screen tcell.Screen, sel *selector.Selector) (int, bool) {
 			// 重新渲染（选择可能改变）
 			sel.Render()
 
+		case *tcell.EventMouse:
+			if result := sel.HandleMouse(ev); result >= 0 {
+				return result, false // 用户点击运行特效
+			}
+			sel.Render()
+
 		case *tcell.EventResize:
 			sel.Render()
 		}
 	}
 }
 
-// runEffect 运行指定的特效
-func runEffect(screen tcell.Screen, effectID string) error {
-	// 获取特效工厂
-	factory, err := effects.Get(effectID)
+// handoff 切换画面时的过渡：由上一个画面的最后一帧 from 过渡到新画面
+type handoff struct {
+	transition transition.Transition
+	from       *frame.Frame
+	duration   time.Duration
+}
+
+// switchTransition 返回从选择器启动特效及返回选择器时使用的过渡效果与时长
+// 命令行选项优先，其次是配置文件
+func switchTransition() (transition.Transition, time.Duration, error) {
+	cfg := appConfig.Config()
+	t, err := transition.Get(cmp.Or(transitionName, cfg.Transition, playlist.DefaultTransition))
+	if err != nil {
+		return nil, 0, err
+	}
+	return t, cmp.Or(transitionTime, time.Duration(cfg.TransitionTime), switchTransitionTime), nil
+}
+
+// runEffect 运行指定的特效，intro 不为 nil 时由上一个画面过渡到特效
+func runEffect(screen tcell.Screen, effectID string, intro *handoff) error {
+	effect, defaults, err := newEffect(effectID)
+	if err != nil {
+		return err
+	}
+	p, err := effectPalette(effectID)
 	if err != nil {
 		return err
 	}
 
-	// 创建特效实例
-	effect := factory()
+	// 记录最近运行的特效与运行次数（保存失败不影响运行）
+	appConfig.Update(func(cfg *config.Config) { cfg.RecordRun(effectID) })
+
+	// 创建驱动特效的时钟
+	clock := newClock()
+	clocked := effects.ApplyClock(effect, clock)
+
+	// 录制时特效绘制到录制屏幕
+	var effectScreen tcell.Screen = screen
+	if recorder != nil {
+		recScreen := recorder.Wrap(screen)
+		defer recScreen.Close()
+		effectScreen = recScreen
+	}
+
+	// 过渡进度依赖注入的时钟
+	ts := transition.NewScreen(effectScreen)
+	if intro != nil && clocked {
+		ts.Start(intro.transition, intro.from, intro.duration, clock)
+	}
 
 	// 初始化特效
-	if err := effect.Init(screen); err != nil {
+	if err := effect.Init(effects.PaletteScreen(effect, ts, p, palette.Detect(screen))); err != nil {
 		return fmt.Errorf("初始化失败: %w", err)
 	}
 
 	// 清理资源
 	defer effect.Cleanup()
 
-	// 创建退出通道
+	// 配置文件被修改后把新的参数推送给运行中的特效
+	stopWatch := watchConfig(effect, effectID, defaults, clock)
+
+	// 创建退出通道（ESC 与运行时长到期都会关闭它）
 	quit := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(quit) })
+	}
 
-	// 启动键盘监听协程
-	go func() {
-		for {
-			ev := screen.PollEvent()
-			switch ev := ev.(type) {
-			case *tcell.EventKey:
-				// ESC 键返回主界面
-				if ev.Key() == tcell.KeyEscape {
-					close(quit)
-					return
-				}
-			case *tcell.EventResize:
-				screen.Sync()
-			}
+	if runDuration > 0 {
+		timer := time.AfterFunc(runDuration, stop)
+		defer timer.Stop()
+	}
+
+	// 启动键盘与鼠标监听协程，ESC 键返回主界面
+	cur := &playing{effect: effect, clock: clock}
+	stopEvents := pumpEvents(screen, func() *playing { return cur }, stop, nil)
+
+	// 运行特效，结束后（ESC、运行时长到期或出错）等待监听协程退出
+	// 特效正常结束时返回重新加载配置文件时出现的问题（*configWarning）
+	err = effect.Run(quit)
+	stop()
+	stopEvents()
+	if warning := stopWatch(); err == nil {
+		err = warning
+	}
+	return err
+}
+
+// newEffect 创建特效实例并应用随机种子与参数
+// 先应用配置中保存的参数，命令行 --set 指定的参数优先；同时返回特效的默认参数值
+func newEffect(effectID string) (effects.Effect, map[string]any, error) {
+	factory, err := effects.Get(effectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	effect := factory()
+	effects.ApplySeed(effect, seed)
+	defaults := paramValues(effect)
+
+	applyConfigParams(effect, effectID)
+	if err := effects.ApplyParams(effect, effectParams); err != nil {
+		return nil, nil, err
+	}
+	return effect, defaults, nil
+}
+
+// effectPalette 返回特效使用的调色板：命令行 --palette 优先，其次是配置文件，最后是主题；都没有设置时返回 nil
+func effectPalette(effectID string) (*palette.Palette, error) {
+	spec := cmp.Or(paletteName, appConfig.Config().EffectPalette(effectID))
+	if spec == "" {
+		t, err := currentTheme()
+		if err != nil {
+			return nil, err
 		}
-	}()
+		spec = t.EffectPalette(effectID)
+	}
+	if spec == "" {
+		return nil, nil
+	}
+	return palette.Parse(spec)
+}
 
-	// 运行特效
-	return effect.Run(quit)
+// currentTheme 返回使用的主题：命令行 --theme 优先，其次是配置文件，都没有设置时为 dark
+func currentTheme() (*theme.Theme, error) {
+	if themeName != "" {
+		return theme.Get(themeName)
+	}
+	if name := appConfig.Config().Theme; name != "" {
+		t, err := theme.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("配置文件中的 theme: %w", err)
+		}
+		return t, nil
+	}
+	return theme.Dark, nil
+}
+
+// loadThemes 加载配置文件所在目录下 themes 中的用户主题（默认 ~/.symbolmove/themes）
+func loadThemes() error {
+	if appConfig.Path() == "" {
+		return nil
+	}
+	return theme.LoadDir(filepath.Join(filepath.Dir(appConfig.Path()), "themes"))
+}
+
+// newClock 根据命令行参数创建时钟
+func newClock() *effects.Clock {
+	mode := effects.ClockRealtime
+	if fixedStep {
+		mode = effects.ClockFixed
+	}
+
+	clock := effects.NewClockWithMode(mode)
+	clock.SetSpeed(speed)
+	return clock
+}
+
+// handleClockKey 处理时钟控制按键
+// 空格: 暂停/继续 | .: 单步 | +/=: 加速 | -: 减速 | 0: 恢复原速
+func handleClockKey(clock *effects.Clock, ev *tcell.EventKey) bool {
+	if ev.Key() != tcell.KeyRune {
+		return false
+	}
+
+	switch ev.Rune() {
+	case ' ':
+		clock.TogglePause()
+	case '.':
+		clock.Step()
+	case '+', '=':
+		clock.SetSpeed(clock.Speed() * 2)
+	case '-':
+		clock.SetSpeed(clock.Speed() / 2)
+	case '0':
+		clock.SetSpeed(1.0)
+	default:
+		return false
+	}
+
+	return true
 }
 
-// showError 显示错误信息
+// showError 显示错误信息（过长的信息自动换行）
 func showError(screen tcell.Screen, message string) {
 	screen.Clear()
 
 	width, height := screen.Size()
-	y := height / 2
+	lines := wrapText(message, max(10, width-8))
+	y := height/2 - len(lines)/2
 
 	// 错误标题
-	title := "错误"
-	x := (width - len(title)) / 2
-	style := tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)
-	for i, ch := range title {
-		screen.SetContent(x+i, y-1, ch, nil, style)
-	}
+	drawCentered(screen, y-2, "错误", tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true))
 
 	// 错误信息
-	x = (width - len(message)) / 2
-	if x < 0 {
-		x = 0
-	}
-	style = tcell.StyleDefault.Foreground(tcell.ColorWhite)
-	for i, ch := range message {
-		if x+i < width {
-			screen.SetContent(x+i, y+1, ch, nil, style)
-		}
+	style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+	for i, line := range lines {
+		drawCentered(screen, y+i, line, style)
 	}
 
 	// 提示
-	hint := "按任意键继续..."
-	x = (width - len(hint)) / 2
-	style = tcell.StyleDefault.Foreground(tcell.ColorGray)
-	for i, ch := range hint {
-		screen.SetContent(x+i, y+3, ch, nil, style)
-	}
+	drawCentered(screen, y+len(lines)+1, "按任意键继续...", tcell.StyleDefault.Foreground(tcell.ColorGray))
 
 	screen.Show()
 
 	// 等待按键
-	screen.PollEvent()
+	for {
+		if _, ok := screen.PollEvent().(*tcell.EventKey); ok {
+			return
+		}
+	}
+}
+
+// drawCentered 按显示宽度居中绘制一行文本
+func drawCentered(screen tcell.Screen, y int, text string, style tcell.Style) {
+	width, _ := screen.Size()
+	x := max(0, (width-uniseg.StringWidth(text))/2)
+	for _, ch := range text {
+		screen.SetContent(x, y, ch, nil, style)
+		x += uniseg.StringWidth(string(ch))
+	}
+}
+
+// wrapText 按显示宽度把文本拆分为多行
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line, lineWidth := "", 0
+		for _, ch := range paragraph {
+			w := uniseg.StringWidth(string(ch))
+			if lineWidth+w > width {
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+			}
+			line += string(ch)
+			lineWidth += w
+		}
+		lines = append(lines, line)
+	}
+	return lines
 }
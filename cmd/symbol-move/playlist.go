@@ -0,0 +1,242 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/playlist"
+	"github.com/symbolmove/symbol_move/pkg/transition"
+)
+
+// defaultPlaylist 不带参数运行 playlist 子命令时使用的配置中的播放列表名称
+const defaultPlaylist = "default"
+
+// runPlaylist 执行 playlist 子命令
+func runPlaylist(args []string) error {
+	fs := newFlagSet("playlist", "[播放列表名称 | 特效ID或标签...]")
+	registerRunFlags(fs)
+	fs.DurationVar(&runDuration, "duration", 0, "总运行时长，到时自动退出，如 2h (默认 0 表示直到按 ESC)")
+	each := fs.Duration("each", playlist.DefaultDuration, "每个特效的播放时长")
+	shuffle := fs.Bool("shuffle", false, "每一轮随机打乱播放顺序")
+	registerTransitionFlags(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	// 命令行明确指定的选项覆盖配置中的设置，播放列表未设置过渡效果时使用全局设置
+	pl := selectPlaylist(positional)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "each":
+			pl.Duration = playlist.Duration(*each)
+		case "shuffle":
+			pl.Shuffle = *shuffle
+		case "transition":
+			pl.Transition = transitionName
+		case "transition-time":
+			pl.TransitionTime = playlist.Duration(transitionTime)
+		}
+	})
+	cfg := appConfig.Config()
+	pl.Transition = cmp.Or(pl.Transition, cfg.Transition)
+	pl.TransitionTime = cmp.Or(pl.TransitionTime, cfg.TransitionTime)
+	if err := pl.Validate(); err != nil {
+		return err
+	}
+
+	ids, err := pl.Resolve(effects.List())
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("播放列表中没有特效")
+	}
+
+	screen, err := openScreen()
+	if err != nil {
+		return err
+	}
+
+	warnings, err := playPlaylist(screen, pl, ids)
+	closeScreen(screen)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "警告: %v\n", warning)
+	}
+	return err
+}
+
+// selectPlaylist 根据位置参数选择播放列表
+// 唯一的参数是配置中的播放列表名称时使用该列表，否则参数即为特效 ID 或标签；
+// 没有参数时使用配置中名为 default 的播放列表，未定义时播放全部特效
+func selectPlaylist(positional []string) *playlist.Playlist {
+	name := defaultPlaylist
+	if len(positional) == 1 {
+		name = positional[0]
+	}
+
+	if len(positional) <= 1 {
+		if pl, ok := appConfig.Config().Playlists[name]; ok {
+			return pl
+		}
+	}
+	return &playlist.Playlist{Effects: positional}
+}
+
+// player 播放列表播放器
+type player struct {
+	screen     *transition.Screen
+	transition transition.Transition
+	playlist   *playlist.Playlist
+	depth      palette.Depth           // 终端的颜色深度
+	exit       chan struct{}           // 关闭时结束播放（ESC 或总运行时长到期）
+	skip       chan struct{}           // 切换到下一个特效
+	current    atomic.Pointer[playing] // 当前特效，供按键控制与转发鼠标事件
+	warnings   []error                 // 播放期间重新加载配置文件时出现的问题
+}
+
+// playPlaylist 按播放列表轮流运行特效，循环往复，直到按 ESC 或达到 --duration
+// 播放期间 n 键立即切换到下一个特效，时钟控制按键作用于当前特效。
+// 无法初始化或运行出错的特效被跳过，之后的轮次也不再运行；所有特效都出错时结束播放并返回错误。
+// 返回的 warnings 为被跳过的特效与重新加载配置文件时出现的问题，供恢复终端后提示
+func playPlaylist(screen tcell.Screen, pl *playlist.Playlist, ids []string) (warnings []error, err error) {
+	t, err := transition.Get(pl.TransitionName())
+	if err != nil {
+		return nil, err
+	}
+
+	// 录制时整个播放过程录制为一段
+	var base tcell.Screen = screen
+	if recorder != nil {
+		recScreen := recorder.Wrap(screen)
+		defer recScreen.Close()
+		base = recScreen
+	}
+
+	p := &player{
+		screen:     transition.NewScreen(base),
+		transition: t,
+		playlist:   pl,
+		depth:      palette.Detect(screen),
+		exit:       make(chan struct{}),
+		skip:       make(chan struct{}, 1),
+	}
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(p.exit) })
+	}
+	if runDuration > 0 {
+		timer := time.AfterFunc(runDuration, stop)
+		defer timer.Stop()
+	}
+
+	// 启动键盘与鼠标监听协程，事件转发给当前特效，n 键切换到下一个特效
+	stopEvents := pumpEvents(screen, p.current.Load, stop, func(ev *tcell.EventKey) bool {
+		if ev.Key() != tcell.KeyRune || ev.Rune() != 'n' {
+			return false
+		}
+		select {
+		case p.skip <- struct{}{}:
+		default:
+		}
+		return true
+	})
+	defer stopEvents()
+
+	order := playlist.NewOrder(ids, pl.Shuffle, effects.NewRand(seed))
+	failed := make(map[string]bool)
+	var last *frame.Frame
+	for {
+		id := order.Next()
+		if failed[id] {
+			continue
+		}
+
+		if next, err := p.play(id, last); err != nil {
+			failed[id] = true
+			p.warnings = append(p.warnings, fmt.Errorf("已跳过 %s: %w", id, err))
+			if !slices.ContainsFunc(ids, func(id string) bool { return !failed[id] }) {
+				return nil, errors.Join(append([]error{errors.New("播放列表中的特效都无法运行")}, p.warnings...)...)
+			}
+		} else {
+			last = next
+		}
+
+		select {
+		case <-p.exit:
+			return p.warnings, nil
+		default:
+		}
+	}
+}
+
+// play 运行一个特效，从上一个特效的最后一帧 from 过渡进入
+// 到达播放时长、切换到下一个或结束播放时返回本特效的最后一帧
+func (p *player) play(effectID string, from *frame.Frame) (*frame.Frame, error) {
+	effect, defaults, err := newEffect(effectID)
+	if err != nil {
+		return nil, err
+	}
+	pal, err := effectPalette(effectID)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := newClock()
+	if effects.ApplyClock(effect, clock) {
+		p.screen.Start(p.transition, from, p.playlist.TransitionDuration(), clock)
+	} else {
+		// 过渡进度依赖注入的时钟
+		p.screen.Start(nil, nil, 0, nil)
+	}
+
+	if err := effect.Init(effects.PaletteScreen(effect, p.screen, pal, p.depth)); err != nil {
+		return nil, fmt.Errorf("初始化失败: %w", err)
+	}
+	defer effect.Cleanup()
+
+	p.current.Store(&playing{effect: effect, clock: clock})
+	defer p.current.Store(nil)
+
+	stopWatch := watchConfig(effect, effectID, defaults, clock)
+	defer func() {
+		if warning := stopWatch(); warning != nil {
+			p.warnings = append(p.warnings, warning)
+		}
+	}()
+
+	// 播放时长到期、按 n 切换或结束播放时关闭 quit
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		timer := time.NewTimer(p.playlist.EachDuration())
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-p.skip:
+		case <-p.exit:
+		case <-done:
+		}
+		close(quit)
+	}()
+
+	if err := effect.Run(quit); err != nil {
+		return nil, err
+	}
+	return frame.Capture(p.screen), nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/playlist"
+)
+
+// playlistEffect 播放列表测试用的特效：初始化或运行时返回指定的错误，否则调用 onPlay 后等待结束
+type playlistEffect struct {
+	id      string
+	initErr error
+	runErr  error
+}
+
+// onPlay 测试特效 test-playable 开始运行时调用
+var onPlay func()
+
+func init() {
+	for _, e := range []*playlistEffect{
+		{id: "test-broken-init", initErr: errors.New("no init")},
+		{id: "test-broken-run", runErr: errors.New("no run")},
+		{id: "test-playable"},
+	} {
+		effects.Register(func() effects.Effect { return e })
+	}
+}
+
+func (e *playlistEffect) Metadata() effects.Metadata     { return effects.Metadata{ID: e.id} }
+func (e *playlistEffect) Init(screen tcell.Screen) error { return e.initErr }
+func (e *playlistEffect) Cleanup() error                 { return nil }
+func (e *playlistEffect) Run(quit <-chan struct{}) error {
+	if e.runErr != nil {
+		return e.runErr
+	}
+	onPlay()
+	<-quit
+	return nil
+}
+
+func newPlaylistScreen(t *testing.T) tcell.SimulationScreen {
+	t.Helper()
+
+	store, _ := config.Open(filepath.Join(t.TempDir(), "config.json"))
+	appConfig = store
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(20, 5)
+	t.Cleanup(screen.Fini)
+	return screen
+}
+
+func TestPlaylistSkipsFailingEffects(t *testing.T) {
+	screen := newPlaylistScreen(t)
+
+	// 播放到 test-playable 说明前面出错的特效被跳过，按 ESC 结束播放
+	played := 0
+	onPlay = func() {
+		played++
+		screen.PostEvent(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+	}
+
+	pl := &playlist.Playlist{}
+	warnings, err := playPlaylist(screen, pl, []string{"test-broken-init", "test-broken-run", "test-playable"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if played != 1 {
+		t.Errorf("Expected the playable effect to run once, ran %d times", played)
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 skipped effects, got %v", warnings)
+	}
+	for i, part := range []string{"已跳过 test-broken-init: 初始化失败: no init", "已跳过 test-broken-run: no run"} {
+		if !strings.Contains(warnings[i].Error(), part) {
+			t.Errorf("Expected %q in %q", part, warnings[i])
+		}
+	}
+
+	// 所有特效都出错时结束播放
+	warnings, err = playPlaylist(screen, pl, []string{"test-broken-init", "test-broken-run"})
+	if err == nil || !strings.Contains(err.Error(), "no init") || !strings.Contains(err.Error(), "no run") {
+		t.Errorf("Expected an error naming both failures, got %v", err)
+	}
+	if warnings != nil {
+		t.Errorf("Failures should be reported once in the error, got warnings %v", warnings)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"maps"
+	"time"
+
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+)
+
+// configPollInterval 运行特效时检查配置文件是否被修改的间隔
+const configPollInterval = 500 * time.Millisecond
+
+// applyConfigParams 把配置中的默认帧率和特效保存的参数应用到特效
+func applyConfigParams(effect effects.Effect, effectID string) {
+	effects.ApplySavedParams(effects.ParamsOf(effect), appConfig.Config().RunParams(effectID), "")
+}
+
+// paramValues 返回特效当前的参数值（特效不支持参数配置时返回 nil）
+func paramValues(effect effects.Effect) map[string]any {
+	ps := effects.ParamsOf(effect)
+	if ps == nil {
+		return nil
+	}
+	return ps.Values()
+}
+
+// configWarning 运行特效期间重新加载配置文件时出现的问题
+// 特效照常运行（文件有错误时继续使用当前参数），结束后提示用户，不影响退出状态
+type configWarning struct {
+	err error
+}
+
+func (w *configWarning) Error() string {
+	return "重新加载配置文件失败: " + w.err.Error()
+}
+
+func (w *configWarning) Unwrap() error {
+	return w.err
+}
+
+// watchConfig 监视配置文件，文件被修改后把新的参数推送给运行中的特效
+// 从配置中删除的参数恢复为 defaults，命令行 --set 指定的参数始终优先。
+// 返回的函数停止监视并等待正在进行的推送完成；最近一次重新加载时文件有错误或参数无法应用时，
+// 返回描述该问题的 *configWarning（之后修正了的问题不再返回）
+func watchConfig(effect effects.Effect, effectID string, defaults map[string]any, clock *effects.Clock) func() error {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	var last error
+
+	go func() {
+		defer close(done)
+		appConfig.Watch(configPollInterval, stop, func(cfg *config.Config, err error) {
+			// 文件有错误时继续使用当前参数，等待用户修正
+			last = err
+			if err != nil {
+				return
+			}
+			i18n.GetManager().SetLanguage(cfg.Language)
+
+			if defaults == nil {
+				return
+			}
+			values := maps.Clone(defaults)
+			maps.Copy(values, cfg.RunParams(effectID))
+			maps.Copy(values, effectParams)
+			_, last = effects.Reconfigure(effect, clock, values)
+		})
+	}()
+
+	return func() error {
+		close(stop)
+		<-done
+		if last != nil {
+			return &configWarning{err: last}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/symbolmove/symbol_move/pkg/config"
+)
+
+func TestWatchConfigReportsReloadErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"version": "2.0", "language": "zh"}`)
+
+	store, err := config.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appConfig = store
+	effect := &playlistEffect{id: "test-playable"}
+
+	// 运行期间配置文件被改坏：特效结束后返回警告
+	stopWatch := watchConfig(effect, effect.id, nil, nil)
+	write(`{"version": "2.0", "language": `)
+	time.Sleep(2 * configPollInterval)
+	var warning *configWarning
+	if err := stopWatch(); !errors.As(err, &warning) {
+		t.Fatalf("Expected a config warning, got %v", err)
+	}
+
+	// 之后修正了的问题不再返回
+	stopWatch = watchConfig(effect, effect.id, nil, nil)
+	write(`{"version": "2.0", "language": "zh", "default_fps": 20}`)
+	time.Sleep(2 * configPollInterval)
+	if err := stopWatch(); err != nil {
+		t.Errorf("Expected no warning after the file was fixed, got %v", err)
+	}
+}
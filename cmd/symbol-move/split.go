@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/compositor"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/viewport"
+)
+
+// runSplit 执行 split 子命令：把屏幕等分为多个窗格，每个窗格运行一个特效
+func runSplit(args []string) error {
+	fs := newFlagSet("split", "<effect-id> <effect-id>...")
+	registerRunFlags(fs)
+	registerParamFlag(fs)
+	fs.DurationVar(&runDuration, "duration", 0, "运行时长，到时自动退出，如 30s (默认 0 表示直到按 ESC)")
+	rows := fs.Bool("rows", false, "上下排列窗格（默认左右排列）")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		fs.Usage()
+		return fmt.Errorf("需要指定至少两个特效 ID")
+	}
+
+	// 在进入全屏之前校验特效和参数
+	effect, err := newSplitEffect(positional, *rows)
+	if err != nil {
+		return err
+	}
+
+	screen, err := openScreen()
+	if err != nil {
+		return err
+	}
+
+	err = runSplitEffect(screen, effect)
+	closeScreen(screen)
+	return err
+}
+
+// newSplitEffect 创建把 ids 中的特效并排显示的组合场景
+// 窗格的参数名带有特效 ID 前缀，配置文件中保存的各特效参数同样生效
+func newSplitEffect(ids []string, rows bool) (effects.Effect, error) {
+	layouts := viewport.Columns(len(ids))
+	if rows {
+		layouts = viewport.Rows(len(ids))
+	}
+
+	specs := make([]compositor.LayerSpec, len(ids))
+	for i, id := range ids {
+		if _, err := effects.Get(id); err != nil {
+			return nil, err
+		}
+		specs[i] = compositor.LayerSpec{ID: id, Z: i, Region: &layouts[i]}
+	}
+
+	effect := compositor.NewScene(effects.Metadata{
+		ID:     "split",
+		Name:   "分屏",
+		NameEN: "Split",
+	}, specs...)()
+	effects.ApplySeed(effect, seed)
+
+	cfg := appConfig.Config()
+	ps := effects.ParamsOf(effect)
+	for i, prefix := range compositor.ParamPrefixes(specs) {
+		effects.ApplySavedParams(ps, cfg.RunParams(ids[i]), prefix)
+	}
+
+	if err := effects.ApplyParams(effect, effectParams); err != nil {
+		return nil, err
+	}
+	return effect, nil
+}
+
+// runSplitEffect 运行分屏场景，直到按 ESC 或达到 --duration
+func runSplitEffect(screen tcell.Screen, effect effects.Effect) error {
+	clock := newClock()
+	effects.ApplyClock(effect, clock)
+
+	var effectScreen tcell.Screen = screen
+	if recorder != nil {
+		recScreen := recorder.Wrap(screen)
+		defer recScreen.Close()
+		effectScreen = recScreen
+	}
+
+	// 调色板作用于所有窗格（配置文件中按 split 设置或使用默认的调色板）
+	p, err := effectPalette("split")
+	if err != nil {
+		return err
+	}
+
+	if err := effect.Init(effects.PaletteScreen(effect, effectScreen, p, palette.Detect(screen))); err != nil {
+		return fmt.Errorf("初始化失败: %w", err)
+	}
+	defer effect.Cleanup()
+
+	quit := make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(quit) })
+	}
+
+	if runDuration > 0 {
+		timer := time.AfterFunc(runDuration, stop)
+		defer timer.Stop()
+	}
+
+	// 启动键盘与鼠标监听协程，特效结束后等待它退出
+	cur := &playing{effect: effect, clock: clock}
+	stopEvents := pumpEvents(screen, func() *playing { return cur }, stop, nil)
+	defer stopEvents()
+
+	return effect.Run(quit)
+}
@@ -0,0 +1,226 @@
+package canvas
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Mode 把单元格划分为像素的方式
+type Mode string
+
+const (
+	ModeCell    Mode = "cell"    // 每个单元格一个像素
+	ModeHalf    Mode = "half"    // 上下半格，每个单元格 1×2 个像素，两个像素各有颜色
+	ModeBraille Mode = "braille" // 盲文点阵，每个单元格 2×4 个像素，共用一种颜色
+)
+
+// Modes 按精度从低到高列出全部划分方式
+var Modes = []Mode{ModeCell, ModeHalf, ModeBraille}
+
+// Scale 返回每个单元格在水平和竖直方向上的像素数
+func (m Mode) Scale() (sx, sy int) {
+	switch m {
+	case ModeHalf:
+		return 1, 2
+	case ModeBraille:
+		return 2, 4
+	}
+	return 1, 1
+}
+
+// brailleBits 盲文字符中每个点对应的位，按 [列][行] 索引
+var brailleBits = [2][4]rune{{0x01, 0x02, 0x04, 0x40}, {0x08, 0x10, 0x20, 0x80}}
+
+// Canvas 以像素为单位绘图的画布，Draw 时按划分方式合成为终端字符
+// 没有点亮的像素不绘制，所在单元格保留屏幕上原有的内容
+type Canvas struct {
+	mode          Mode
+	width, height int // 单元格数
+	sx, sy        int // 每个单元格的像素数
+	lit           []bool
+	colors        []tcell.Color
+}
+
+// New 创建覆盖 width×height 个单元格的画布
+func New(mode Mode, width, height int) *Canvas {
+	c := &Canvas{mode: mode}
+	c.sx, c.sy = mode.Scale()
+	c.Resize(width, height)
+	return c
+}
+
+// Mode 返回画布的划分方式
+func (c *Canvas) Mode() Mode {
+	return c.mode
+}
+
+// Resize 调整画布覆盖的单元格数，并清空画布
+func (c *Canvas) Resize(width, height int) {
+	c.width, c.height = max(0, width), max(0, height)
+	n := c.width * c.sx * c.height * c.sy
+	c.lit = make([]bool, n)
+	c.colors = make([]tcell.Color, n)
+}
+
+// Size 返回画布的像素尺寸
+func (c *Canvas) Size() (width, height int) {
+	return c.width * c.sx, c.height * c.sy
+}
+
+// Clear 熄灭所有像素
+func (c *Canvas) Clear() {
+	clear(c.lit)
+}
+
+// index 返回像素在缓冲区中的下标，超出画布时返回 -1
+func (c *Canvas) index(x, y int) int {
+	w, h := c.Size()
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return -1
+	}
+	return y*w + x
+}
+
+// Set 以 color 点亮像素 (x, y)，超出画布的坐标被忽略
+func (c *Canvas) Set(x, y int, color tcell.Color) {
+	if i := c.index(x, y); i >= 0 {
+		c.lit[i] = true
+		c.colors[i] = color
+	}
+}
+
+// Get 返回像素 (x, y) 的颜色以及是否点亮
+func (c *Canvas) Get(x, y int) (tcell.Color, bool) {
+	if i := c.index(x, y); i >= 0 {
+		return c.colors[i], c.lit[i]
+	}
+	return tcell.ColorDefault, false
+}
+
+// Line 用 Bresenham 算法画出从 (x0, y0) 到 (x1, y1) 的线段，包含两个端点
+func (c *Canvas) Line(x0, y0, x1, y1 int, color tcell.Color) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	stepX, stepY := sign(x1-x0), sign(y1-y0)
+	err := dx + dy
+	for {
+		c.Set(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		if e2 := 2 * err; e2 >= dy {
+			err += dy
+			x0 += stepX
+		} else {
+			err += dx
+			y0 += stepY
+		}
+	}
+}
+
+// Circle 用中点画圆算法画出圆心为 (cx, cy)、半径为 r 的圆周
+func (c *Canvas) Circle(cx, cy, r int, color tcell.Color) {
+	if r < 0 {
+		return
+	}
+	x, y := r, 0
+	err := 1 - r
+	for x >= y {
+		for _, p := range [][2]int{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			c.Set(cx+p[0], cy+p[1], color)
+		}
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+}
+
+// Draw 把画布合成为字符绘制到屏幕上，画布左上角对齐屏幕的 (0, 0)
+func (c *Canvas) Draw(screen tcell.Screen) {
+	for row := 0; row < c.height; row++ {
+		for col := 0; col < c.width; col++ {
+			switch c.mode {
+			case ModeHalf:
+				c.drawHalf(screen, col, row)
+			case ModeBraille:
+				c.drawBraille(screen, col, row)
+			default:
+				if color, ok := c.Get(col, row); ok {
+					screen.SetContent(col, row, '█', nil, tcell.StyleDefault.Foreground(color))
+				}
+			}
+		}
+	}
+}
+
+// drawHalf 上半格用前景色的 '▀'，下半格用背景色；只有一半点亮时只画那一半
+func (c *Canvas) drawHalf(screen tcell.Screen, col, row int) {
+	top, topLit := c.Get(col, row*2)
+	bottom, bottomLit := c.Get(col, row*2+1)
+	switch {
+	case topLit && bottomLit && top == bottom:
+		screen.SetContent(col, row, '█', nil, tcell.StyleDefault.Foreground(top))
+	case topLit && bottomLit:
+		screen.SetContent(col, row, '▀', nil, tcell.StyleDefault.Foreground(top).Background(bottom))
+	case topLit:
+		screen.SetContent(col, row, '▀', nil, tcell.StyleDefault.Foreground(top))
+	case bottomLit:
+		screen.SetContent(col, row, '▄', nil, tcell.StyleDefault.Foreground(bottom))
+	}
+}
+
+// drawBraille 一个单元格只能有一种前景色，取点亮最多的颜色，数量相同时取先出现的
+func (c *Canvas) drawBraille(screen tcell.Screen, col, row int) {
+	var bits rune
+	var colors [8]tcell.Color
+	var counts [8]int
+	n := 0
+	for dx := 0; dx < 2; dx++ {
+		for dy := 0; dy < 4; dy++ {
+			color, ok := c.Get(col*2+dx, row*4+dy)
+			if !ok {
+				continue
+			}
+			bits |= brailleBits[dx][dy]
+			i := 0
+			for i < n && colors[i] != color {
+				i++
+			}
+			if i == n {
+				colors[n] = color
+				n++
+			}
+			counts[i]++
+		}
+	}
+	if bits == 0 {
+		return
+	}
+
+	best := 0
+	for i := 1; i < n; i++ {
+		if counts[i] > counts[best] {
+			best = i
+		}
+	}
+	screen.SetContent(col, row, 0x2800+bits, nil, tcell.StyleDefault.Foreground(colors[best]))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
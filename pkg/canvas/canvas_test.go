@@ -0,0 +1,96 @@
+package canvas
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newScreen(t *testing.T, width, height int) tcell.SimulationScreen {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(screen.Fini)
+	screen.SetSize(width, height)
+	return screen
+}
+
+func TestSize(t *testing.T) {
+	for mode, want := range map[Mode][2]int{ModeCell: {10, 5}, ModeHalf: {10, 10}, ModeBraille: {20, 20}} {
+		c := New(mode, 10, 5)
+		if w, h := c.Size(); w != want[0] || h != want[1] {
+			t.Errorf("%s: expected %dx%d pixels, got %dx%d", mode, want[0], want[1], w, h)
+		}
+	}
+
+	c := New(ModeBraille, 2, 2)
+	c.Set(-1, 0, tcell.ColorRed)
+	c.Set(4, 0, tcell.ColorRed)
+	if _, ok := c.Get(4, 0); ok {
+		t.Error("Expected pixels outside the canvas to be ignored")
+	}
+}
+
+func TestBraille(t *testing.T) {
+	screen := newScreen(t, 2, 1)
+	c := New(ModeBraille, 2, 1)
+
+	// 第一个单元格的左列和右下角：三个红点、一个绿点，取红色
+	c.Line(0, 0, 0, 2, tcell.ColorRed)
+	c.Set(1, 3, tcell.ColorGreen)
+	c.Draw(screen)
+	screen.Show()
+
+	ch, _, style, _ := screen.GetContent(0, 0)
+	if want := rune(0x2800 + 0x01 + 0x02 + 0x04 + 0x80); ch != want {
+		t.Errorf("Expected %q, got %q", want, ch)
+	}
+	if fg, _, _ := style.Decompose(); fg != tcell.ColorRed {
+		t.Errorf("Expected the majority colour red, got %v", fg)
+	}
+	if ch, _, _, _ := screen.GetContent(1, 0); ch != ' ' {
+		t.Errorf("Expected an empty cell to be left untouched, got %q", ch)
+	}
+}
+
+func TestHalf(t *testing.T) {
+	screen := newScreen(t, 3, 1)
+	c := New(ModeHalf, 3, 1)
+	c.Set(0, 0, tcell.ColorRed)
+	c.Set(0, 1, tcell.ColorBlue)
+	c.Set(1, 1, tcell.ColorBlue)
+	c.Set(2, 0, tcell.ColorRed)
+	c.Set(2, 1, tcell.ColorRed)
+	c.Draw(screen)
+	screen.Show()
+
+	for x, want := range []struct {
+		ch     rune
+		fg, bg tcell.Color
+	}{
+		{'▀', tcell.ColorRed, tcell.ColorBlue},
+		{'▄', tcell.ColorBlue, tcell.ColorDefault},
+		{'█', tcell.ColorRed, tcell.ColorDefault},
+	} {
+		ch, _, style, _ := screen.GetContent(x, 0)
+		fg, bg, _ := style.Decompose()
+		if ch != want.ch || fg != want.fg || bg != want.bg {
+			t.Errorf("Cell %d: expected %q %v/%v, got %q %v/%v", x, want.ch, want.fg, want.bg, ch, fg, bg)
+		}
+	}
+}
+
+func TestCircle(t *testing.T) {
+	c := New(ModeBraille, 10, 5)
+	c.Circle(10, 10, 6, tcell.ColorWhite)
+	for _, p := range [][2]int{{16, 10}, {4, 10}, {10, 4}, {10, 16}} {
+		if _, ok := c.Get(p[0], p[1]); !ok {
+			t.Errorf("Expected (%d, %d) on the circle", p[0], p[1])
+		}
+	}
+	if _, ok := c.Get(10, 10); ok {
+		t.Error("Expected the centre to stay empty")
+	}
+}
@@ -0,0 +1,133 @@
+package compositor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// BlendMode 图层与下方画面的混合方式
+type BlendMode int
+
+// 混合模式
+const (
+	BlendNormal   BlendMode = iota // 上层字符与颜色直接覆盖下层
+	BlendAdd                       // 颜色相加（变亮，适合光效）
+	BlendLighten                   // 逐通道取较亮者
+	BlendMultiply                  // 颜色相乘（变暗）
+	BlendScreen                    // 滤色（柔和地变亮）
+)
+
+// blendNames 混合模式的名称，顺序与常量一致
+var blendNames = []string{"normal", "add", "lighten", "multiply", "screen"}
+
+// BlendModes 返回所有混合模式
+func BlendModes() []BlendMode {
+	return []BlendMode{BlendNormal, BlendAdd, BlendLighten, BlendMultiply, BlendScreen}
+}
+
+// BlendNames 返回所有混合模式的名称
+func BlendNames() []string {
+	return append([]string(nil), blendNames...)
+}
+
+// String 返回混合模式的名称
+func (m BlendMode) String() string {
+	if m < 0 || int(m) >= len(blendNames) {
+		return fmt.Sprintf("BlendMode(%d)", int(m))
+	}
+	return blendNames[m]
+}
+
+// ParseBlendMode 按名称解析混合模式（忽略大小写），空字符串表示 normal
+func ParseBlendMode(name string) (BlendMode, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return BlendNormal, nil
+	}
+	for i, n := range blendNames {
+		if n == name {
+			return BlendMode(i), nil
+		}
+	}
+	return BlendNormal, fmt.Errorf("未知的混合模式 %q（可选 %s）", name, strings.Join(blendNames, ", "))
+}
+
+// 终端默认颜色没有 RGB 值，混合时按常见的深色终端处理
+var (
+	defaultForeground = tcell.ColorSilver
+	defaultBackground = tcell.ColorBlack
+)
+
+// Transparent 判断单元格是否透明：空白单元格（空格或未绘制）不遮挡下层
+func Transparent(cell frame.Cell) bool {
+	return cell.Rune == ' ' || cell.Rune == 0
+}
+
+// Over 把上层单元格按混合模式叠加到下层单元格上
+// 上层透明时保持下层不变；上层没有设置背景色时沿用下层的背景色
+func Over(lower, upper frame.Cell, mode BlendMode) frame.Cell {
+	if Transparent(upper) {
+		return lower
+	}
+
+	lfg, lbg, _ := lower.Style.Decompose()
+	ufg, ubg, attr := upper.Style.Decompose()
+
+	// 下层为空白时字符下方的颜色就是它的背景色
+	base := lfg
+	if Transparent(lower) {
+		base = resolve(lbg, defaultBackground)
+	}
+
+	fg, bg := ufg, ubg
+	if mode != BlendNormal {
+		fg = blendColor(mode, resolve(base, defaultForeground), resolve(ufg, defaultForeground))
+		if ubg != tcell.ColorDefault {
+			bg = blendColor(mode, resolve(lbg, defaultBackground), ubg)
+		}
+	}
+	if ubg == tcell.ColorDefault {
+		bg = lbg
+	}
+
+	style := tcell.StyleDefault.Foreground(fg).Background(bg).Attributes(attr)
+	return frame.Cell{Rune: upper.Rune, Style: style}
+}
+
+// blendColor 逐通道混合两种颜色
+func blendColor(mode BlendMode, a, b tcell.Color) tcell.Color {
+	ar, ag, ab := a.RGB()
+	br, bg, bb := b.RGB()
+	return tcell.NewRGBColor(
+		blendChannel(mode, ar, br),
+		blendChannel(mode, ag, bg),
+		blendChannel(mode, ab, bb),
+	)
+}
+
+// blendChannel 混合单个颜色通道（0 到 255）
+func blendChannel(mode BlendMode, a, b int32) int32 {
+	switch mode {
+	case BlendAdd:
+		return min(a+b, 255)
+	case BlendLighten:
+		return max(a, b)
+	case BlendMultiply:
+		return a * b / 255
+	case BlendScreen:
+		return 255 - (255-a)*(255-b)/255
+	default:
+		return b
+	}
+}
+
+// resolve 把终端默认颜色替换为 fallback
+func resolve(c, fallback tcell.Color) tcell.Color {
+	if c == tcell.ColorDefault || !c.Valid() {
+		return fallback
+	}
+	return c
+}
@@ -0,0 +1,150 @@
+package compositor
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// Compositor 图层合成器
+// 每个图层拥有独立的离屏单元格缓冲区，任一图层 Show 时按 Z 序把所有图层合成到真实屏幕。
+// 空白单元格透明，因此下层特效会从上层的空隙中透出来。
+type Compositor struct {
+	screen tcell.Screen
+
+	mu     sync.Mutex
+	layers []*Layer // 按 Z 序从下到上排列
+}
+
+// New 创建绘制到 screen 的合成器
+func New(screen tcell.Screen) *Compositor {
+	return &Compositor{screen: screen}
+}
+
+// AddLayer 添加图层，z 越大越靠上（相同 Z 值时后添加的在上）
+// 图层的尺寸与真实屏幕相同
+func (c *Compositor) AddLayer(z int, mode BlendMode) (*Layer, error) {
+	sim := tcell.NewSimulationScreen("UTF-8")
+	if err := sim.Init(); err != nil {
+		return nil, err
+	}
+	sim.SetSize(c.screen.Size())
+
+	layer := &Layer{SimulationScreen: sim, compositor: c, z: z, mode: mode}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.layers = append(c.layers, layer)
+	sort.SliceStable(c.layers, func(i, j int) bool {
+		return c.layers[i].z < c.layers[j].z
+	})
+	return layer, nil
+}
+
+// Layers 返回所有图层（按 Z 序从下到上）
+func (c *Compositor) Layers() []*Layer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*Layer(nil), c.layers...)
+}
+
+// Compose 按 Z 序合成所有图层的当前画面
+func (c *Compositor) Compose() *frame.Frame {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.compose()
+}
+
+// compose 合成画面（调用方须持有 mu）
+func (c *Compositor) compose() *frame.Frame {
+	width, height := c.screen.Size()
+	out := frame.New(width, height)
+
+	for _, layer := range c.layers {
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				r, _, style, _ := layer.GetContent(x, y)
+				i := y*width + x
+				out.Cells[i] = Over(out.Cells[i], frame.Cell{Rune: r, Style: style}, layer.mode)
+			}
+		}
+	}
+	return out
+}
+
+// present 合成画面写入真实屏幕后调用 show
+func (c *Compositor) present(show func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := c.compose()
+	for y := 0; y < out.Height; y++ {
+		for x := 0; x < out.Width; x++ {
+			cell := out.Cells[y*out.Width+x]
+			c.screen.SetContent(x, y, cell.Rune, nil, cell.Style)
+		}
+	}
+	show()
+}
+
+// Resize 真实屏幕尺寸变化后调整所有图层缓冲区的尺寸
+func (c *Compositor) Resize(width, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, layer := range c.layers {
+		layer.SetSize(width, height)
+	}
+}
+
+// Close 释放所有图层的缓冲区
+func (c *Compositor) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, layer := range c.layers {
+		layer.SimulationScreen.Fini()
+	}
+	c.layers = nil
+}
+
+// Layer 合成器中的一个图层
+// 实现 tcell.Screen，特效像使用真实屏幕一样在上面绘制；Show 与 Sync 触发合成
+type Layer struct {
+	tcell.SimulationScreen
+
+	compositor *Compositor
+	z          int
+	mode       BlendMode
+}
+
+// Z 返回图层的 Z 值
+func (l *Layer) Z() int {
+	return l.z
+}
+
+// Mode 返回图层的混合模式
+func (l *Layer) Mode() BlendMode {
+	l.compositor.mu.Lock()
+	defer l.compositor.mu.Unlock()
+	return l.mode
+}
+
+// SetMode 修改图层的混合模式，下次合成时生效
+func (l *Layer) SetMode(mode BlendMode) {
+	l.compositor.mu.Lock()
+	defer l.compositor.mu.Unlock()
+	l.mode = mode
+}
+
+// Show 合成所有图层并刷新真实屏幕
+func (l *Layer) Show() {
+	l.compositor.present(l.compositor.screen.Show)
+}
+
+// Sync 合成所有图层并完整刷新真实屏幕
+func (l *Layer) Sync() {
+	l.compositor.present(l.compositor.screen.Sync)
+}
@@ -0,0 +1,342 @@
+package compositor
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/headless"
+	"github.com/symbolmove/symbol_move/pkg/viewport"
+)
+
+func TestComposeZOrder(t *testing.T) {
+	screen, err := headless.NewScreen(4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+
+	c := New(screen)
+	defer c.Close()
+
+	// 后添加但 Z 值较小的图层在下
+	top, _ := c.AddLayer(1, BlendNormal)
+	bottom, _ := c.AddLayer(0, BlendNormal)
+
+	for x := 0; x < 4; x++ {
+		bottom.SetContent(x, 0, 'b', nil, tcell.StyleDefault)
+	}
+	top.SetContent(1, 0, 't', nil, tcell.StyleDefault)
+	top.SetContent(2, 0, ' ', nil, tcell.StyleDefault.Background(tcell.ColorRed))
+
+	if got, want := c.Compose().Text(), "btbb\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	top.Show()
+	if got, want := frame.Capture(screen).Text(), "btbb\n"; got != want {
+		t.Errorf("Show should draw the composed frame: expected %q, got %q", want, got)
+	}
+}
+
+func TestOver(t *testing.T) {
+	gray := tcell.NewRGBColor(100, 100, 100)
+	lower := frame.Cell{Rune: 'a', Style: tcell.StyleDefault.Foreground(gray).Background(tcell.ColorNavy)}
+	upper := frame.Cell{Rune: 'b', Style: tcell.StyleDefault.Foreground(gray)}
+
+	if got := Over(lower, frame.Cell{Rune: ' '}, BlendAdd); got != lower {
+		t.Errorf("Transparent cell should keep the lower cell, got %+v", got)
+	}
+
+	for _, tc := range []struct {
+		mode BlendMode
+		want int32
+	}{
+		{BlendNormal, 100},
+		{BlendAdd, 200},
+		{BlendLighten, 100},
+		{BlendMultiply, 39},
+		{BlendScreen, 161},
+	} {
+		got := Over(lower, upper, tc.mode)
+		fg, bg, _ := got.Style.Decompose()
+		if got.Rune != 'b' {
+			t.Errorf("%v: expected upper rune, got %q", tc.mode, got.Rune)
+		}
+		if r, _, _ := fg.RGB(); r != tc.want {
+			t.Errorf("%v: expected red channel %d, got %d", tc.mode, tc.want, r)
+		}
+		if bg != tcell.ColorNavy {
+			t.Errorf("%v: expected lower background to show through, got %v", tc.mode, bg)
+		}
+	}
+}
+
+func TestParseBlendMode(t *testing.T) {
+	for _, mode := range BlendModes() {
+		parsed, err := ParseBlendMode(" " + mode.String() + " ")
+		if err != nil || parsed != mode {
+			t.Errorf("ParseBlendMode(%q) = %v, %v", mode, parsed, err)
+		}
+	}
+	if _, err := ParseBlendMode("overlay"); err == nil {
+		t.Error("Expected error for unknown blend mode")
+	}
+}
+
+// textEffect 在固定位置绘制一个字符的测试特效
+type textEffect struct {
+	id     string
+	x      int
+	r      rune
+	screen tcell.Screen
+	clock  *effects.Clock
+}
+
+func (e *textEffect) Metadata() effects.Metadata    { return effects.Metadata{ID: e.id} }
+func (e *textEffect) SetClock(clock *effects.Clock) { e.clock = clock }
+func (e *textEffect) Cleanup() error                { return nil }
+
+func (e *textEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Int(effects.Param{Name: "x", Min: 0, Max: 10}, &e.x)
+	return ps
+}
+
+func (e *textEffect) Init(screen tcell.Screen) error {
+	e.screen = screen
+	return nil
+}
+
+func (e *textEffect) Run(quit <-chan struct{}) error {
+	return e.clock.Run(30, quit, func(float64) {
+		e.screen.Clear()
+		e.screen.SetContent(e.x, 0, e.r, nil, tcell.StyleDefault)
+		e.screen.Show()
+	})
+}
+
+func TestScene(t *testing.T) {
+	effects.Register(func() effects.Effect { return &textEffect{id: "test-scene-a", r: 'a'} })
+	effects.Register(func() effects.Effect { return &textEffect{id: "test-scene-b", r: 'b', x: 2} })
+
+	scene := NewScene(effects.Metadata{ID: "test-scene"},
+		LayerSpec{ID: "test-scene-a", Z: 0},
+		LayerSpec{ID: "test-scene-b", Z: 1, Params: map[string]any{"x": 3}},
+	)()
+
+	// 图层参数带特效 ID 前缀
+	if err := effects.ApplyParams(scene, map[string]any{"test-scene-a.x": 1, "test-scene-b.blend": "add"}); err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := headless.New(5, 1).Run(scene, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 模拟时钟下各图层轮流出帧：第一帧只有底层，之后两层都可见
+	want := []string{" a   \n", " a b \n", " a b \n", " a b \n"}
+	for i, f := range frames {
+		if got := f.Text(); got != want[i] {
+			t.Errorf("Frame %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+}
+
+func TestSceneRegions(t *testing.T) {
+	effects.Register(func() effects.Effect { return &textEffect{id: "test-region", r: 'r'} })
+
+	columns := viewport.Columns(2)
+	specs := []LayerSpec{
+		{ID: "test-region", Region: &columns[0]},
+		{ID: "test-region", Z: 1, Region: &columns[1]},
+	}
+	scene := NewScene(effects.Metadata{ID: "test-regions"}, specs...)()
+
+	// 同一特效出现多次时参数前缀依次编号
+	if got := ParamPrefixes(specs); got[0] != "test-region" || got[1] != "test-region#2" {
+		t.Fatalf("Unexpected prefixes %v", got)
+	}
+	if err := effects.ApplyParams(scene, map[string]any{"test-region#2.x": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := headless.New(6, 1).Run(scene, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := frames[1].Text(), "r   r \n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// mouseEffect 记录收到的鼠标事件的测试特效
+type mouseEffect struct {
+	textEffect
+	clicks [][2]int
+}
+
+func (e *mouseEffect) HandleMouse(event *tcell.EventMouse) {
+	x, y := event.Position()
+	e.clicks = append(e.clicks, [2]int{x, y})
+}
+
+func TestSceneMouse(t *testing.T) {
+	effects.Register(func() effects.Effect { return &mouseEffect{textEffect: textEffect{id: "test-mouse"}} })
+
+	columns := viewport.Columns(2)
+	scene := NewScene(effects.Metadata{ID: "test-mouse-scene"},
+		LayerSpec{ID: "test-mouse", Region: &columns[0]},
+		LayerSpec{ID: "test-mouse", Z: 1, Region: &columns[1]},
+	)().(*Scene)
+
+	screen, err := headless.NewScreen(10, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	if err := scene.Init(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer scene.Cleanup()
+
+	scene.HandleMouse(tcell.NewEventMouse(7, 2, tcell.Button1, tcell.ModNone))
+	scene.HandleMouse(tcell.NewEventMouse(1, 1, tcell.ButtonNone, tcell.ModNone))
+
+	left := scene.slots[0].effect.(*mouseEffect).clicks
+	right := scene.slots[1].effect.(*mouseEffect).clicks
+	if want := [][2]int{{2, 2}, {-4, 1}}; !reflect.DeepEqual(right, want) {
+		t.Errorf("Expected right pane to get %v, got %v", want, right)
+	}
+	if want := [][2]int{{1, 1}}; !reflect.DeepEqual(left, want) {
+		t.Errorf("Expected left pane to get only the release %v, got %v", want, left)
+	}
+}
+
+// resizeEffect 记录收到的尺寸变化通知的测试特效
+type resizeEffect struct {
+	textEffect
+	sizes [][2]int
+}
+
+func (e *resizeEffect) Resize(width, height int) {
+	e.sizes = append(e.sizes, [2]int{width, height})
+}
+
+func TestSceneResize(t *testing.T) {
+	effects.Register(func() effects.Effect { return &resizeEffect{textEffect: textEffect{id: "test-resize"}} })
+
+	columns := viewport.Columns(2)
+	scene := NewScene(effects.Metadata{ID: "test-resize-scene"},
+		LayerSpec{ID: "test-resize"},
+		LayerSpec{ID: "test-resize", Z: 1, Region: &columns[1]},
+	)().(*Scene)
+
+	screen, err := headless.NewScreen(10, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	if err := scene.Init(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer scene.Cleanup()
+
+	screen.SetSize(20, 6)
+	scene.Resize(20, 6)
+
+	full := scene.slots[0].effect.(*resizeEffect).sizes
+	pane := scene.slots[1].effect.(*resizeEffect).sizes
+	if want := [][2]int{{20, 6}}; !reflect.DeepEqual(full, want) {
+		t.Errorf("Expected full-screen layer to get %v, got %v", want, full)
+	}
+	if want := [][2]int{{10, 6}}; !reflect.DeepEqual(pane, want) {
+		t.Errorf("Expected pane to get %v, got %v", want, pane)
+	}
+	if got, want := scene.slots[1].rect, (viewport.Rect{X: 10, Width: 10, Height: 6}); got != want {
+		t.Errorf("Expected pane at %v, got %v", want, got)
+	}
+	if width, height := scene.slots[1].layer.Size(); width != 20 || height != 6 {
+		t.Errorf("Expected layer buffers to grow to 20x6, got %dx%d", width, height)
+	}
+}
+
+// sizedEffect 屏幕为空时初始化失败的测试特效（与按屏幕尺寸取随机数的特效一样无法在空窗格中运行）
+type sizedEffect struct {
+	textEffect
+}
+
+func (e *sizedEffect) Init(screen tcell.Screen) error {
+	if width, height := screen.Size(); width == 0 || height == 0 {
+		return fmt.Errorf("empty screen %dx%d", width, height)
+	}
+	return e.textEffect.Init(screen)
+}
+
+func TestSceneSplitOnTinyScreen(t *testing.T) {
+	effects.Register(func() effects.Effect { return &sizedEffect{textEffect{id: "test-sized", r: 's'}} })
+
+	// 4 个窗格分 2 列宽的屏幕，有两个窗格为空
+	columns := viewport.Columns(4)
+	specs := make([]LayerSpec, len(columns))
+	for i := range specs {
+		specs[i] = LayerSpec{ID: "test-sized", Z: i, Region: &columns[i]}
+	}
+	newScene := func() *Scene {
+		return NewScene(effects.Metadata{ID: "test-split"}, specs...)().(*Scene)
+	}
+
+	frames, err := headless.New(2, 1).Run(newScene(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := frames[2].Text(), "ss\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// 屏幕变大后空窗格中的特效初始化并开始运行
+	screen, err := headless.NewScreen(2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+
+	scene := newScene()
+	clock := effects.NewClockWithMode(effects.ClockSimulated)
+	scene.SetClock(clock)
+	if err := scene.Init(screen); err != nil {
+		t.Fatal(err)
+	}
+	defer scene.Cleanup()
+
+	quit := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() { errCh <- scene.Run(quit) }()
+
+	clock.Do(func() {
+		screen.SetSize(8, 1)
+		scene.Resize(8, 1)
+	})
+	deadline := time.Now().Add(2 * time.Second)
+	for clock.Loops() < len(specs) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected all %d panes to run, got %d", len(specs), clock.Loops())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(quit)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	for i, sl := range scene.slots {
+		if !sl.ready {
+			t.Errorf("Expected pane %d to be initialized", i)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+package compositor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/viewport"
+)
+
+// LayerSpec 场景中一个图层的定义
+type LayerSpec struct {
+	ID     string         // 特效 ID
+	Z      int            // Z 值，越大越靠上
+	Blend  BlendMode      // 混合模式
+	Params map[string]any // 覆盖特效默认值的参数
+
+	// Region 图层在屏幕上占据的区域（nil 表示整个屏幕）
+	// 特效看到的屏幕尺寸就是区域的尺寸，绘制到区域之外的内容被裁掉
+	Region *viewport.Layout
+}
+
+// Scene 组合场景：把多个特效按图层叠加为一个特效
+// 所有图层共享同一个时钟，场景的参数由各图层的参数加上前缀组成（见 ParamPrefixes）
+type Scene struct {
+	metadata effects.Metadata
+	specs    []LayerSpec
+
+	slots      []*slot // 首次使用时创建
+	clock      *effects.Clock
+	seed       int64
+	compositor *Compositor
+
+	mu      sync.Mutex
+	stop    chan struct{}  // Run 期间各图层的停止信号（nil 表示未在运行）
+	stopped bool           // stop 是否已关闭
+	wg      sync.WaitGroup // 正在运行的图层
+	errs    []error        // 各图层初始化或运行出错的原因
+}
+
+// slot 场景中图层的运行状态
+type slot struct {
+	spec   LayerSpec
+	prefix string // 参数名前缀
+	effect effects.Effect
+	mode   BlendMode
+	layer  *Layer           // Init 之后有效
+	region *viewport.Screen // 图层只占据部分屏幕时特效绘制的区域屏幕
+	rect   viewport.Rect    // 图层占据的屏幕区域，Init 之后有效
+	target tcell.Screen     // 特效绘制的屏幕（layer 或 region），Init 之后有效
+
+	// ready 特效是否已初始化
+	// 区域为空（终端太小或窗格太多）的图层暂不初始化，等 Resize 使区域非空时再初始化并运行
+	ready bool
+}
+
+// NewScene 返回创建组合场景的工厂函数，可直接传给 effects.Register
+// 图层中的特效在场景首次使用时才创建，因此被引用的特效可以晚于场景注册
+func NewScene(metadata effects.Metadata, layers ...LayerSpec) effects.EffectFactory {
+	return func() effects.Effect {
+		return &Scene{metadata: metadata, specs: layers}
+	}
+}
+
+// Metadata 返回场景元数据
+func (s *Scene) Metadata() effects.Metadata {
+	return s.metadata
+}
+
+// SetSeed 设置随机种子（实现 effects.Seeder 接口），各图层使用不同的派生种子
+func (s *Scene) SetSeed(seed int64) {
+	s.seed = seed
+}
+
+// SetClock 设置所有图层共享的时钟（实现 effects.Clocked 接口）
+func (s *Scene) SetClock(clock *effects.Clock) {
+	s.clock = clock
+}
+
+// Params 返回所有图层的参数与混合模式（实现 effects.Configurable 接口）
+func (s *Scene) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+
+	slots, err := s.load()
+	if err != nil {
+		return ps
+	}
+
+	for _, sl := range slots {
+		sl := sl
+		name := sl.effect.Metadata().Name
+		nameEN := sl.effect.Metadata().NameEN
+
+		ps.Bind(effects.Param{
+			Name: sl.prefix + ".blend", Type: effects.ParamEnum,
+			Label: name + " · 混合模式", LabelEN: nameEN + " · Blend",
+			Options: BlendNames(),
+		}, func() any {
+			return sl.mode.String()
+		}, func(v any) {
+			sl.mode, _ = ParseBlendMode(v.(string))
+			if sl.layer != nil {
+				sl.layer.SetMode(sl.mode)
+			}
+		})
+
+		inner := effects.ParamsOf(sl.effect)
+		if inner == nil {
+			continue
+		}
+		for _, p := range inner.Params() {
+			field := p.Name
+			p.Name = sl.prefix + "." + field
+			p.Label = name + " · " + p.Label
+			p.LabelEN = nameEN + " · " + p.LabelEN
+			ps.Bind(p, func() any {
+				value, _ := inner.Get(field)
+				return value
+			}, func(v any) {
+				inner.Set(field, v)
+			})
+		}
+	}
+	return ps
+}
+
+// Reconfigure 通知各图层参数已修改（实现 effects.Reconfigurable 接口）
+// 尚未初始化的图层在初始化时直接使用新的参数
+func (s *Scene) Reconfigure() {
+	for _, sl := range s.slots {
+		if !sl.ready {
+			continue
+		}
+		if reconfigurable, ok := sl.effect.(effects.Reconfigurable); ok {
+			reconfigurable.Reconfigure()
+		}
+	}
+}
+
+// ParamPrefixes 返回各图层的参数名前缀
+// 前缀为特效 ID，同一特效出现多次时第二个起为 “特效ID#2”、“特效ID#3” 等
+func ParamPrefixes(layers []LayerSpec) []string {
+	prefixes := make([]string, len(layers))
+	seen := make(map[string]int)
+	for i, layer := range layers {
+		seen[layer.ID]++
+		prefixes[i] = layer.ID
+		if n := seen[layer.ID]; n > 1 {
+			prefixes[i] = fmt.Sprintf("%s#%d", layer.ID, n)
+		}
+	}
+	return prefixes
+}
+
+// load 创建各图层的特效实例并应用图层定义中的参数
+func (s *Scene) load() ([]*slot, error) {
+	if s.slots != nil {
+		return s.slots, nil
+	}
+
+	prefixes := ParamPrefixes(s.specs)
+	slots := make([]*slot, 0, len(s.specs))
+	for i, spec := range s.specs {
+		if spec.ID == s.metadata.ID {
+			return nil, fmt.Errorf("场景 %s 不能包含自身", spec.ID)
+		}
+
+		factory, err := effects.Get(spec.ID)
+		if err != nil {
+			return nil, fmt.Errorf("场景 %s: %w", s.metadata.ID, err)
+		}
+		effect := factory()
+		if err := effects.ApplyParams(effect, spec.Params); err != nil {
+			return nil, fmt.Errorf("场景 %s 的图层 %s: %w", s.metadata.ID, spec.ID, err)
+		}
+		slots = append(slots, &slot{spec: spec, prefix: prefixes[i], effect: effect, mode: spec.Blend})
+	}
+
+	s.slots = slots
+	return slots, nil
+}
+
+// Init 为每个图层创建离屏缓冲区并初始化图层中的特效
+func (s *Scene) Init(screen tcell.Screen) error {
+	slots, err := s.load()
+	if err != nil {
+		return err
+	}
+	if len(slots) == 0 {
+		return fmt.Errorf("场景 %s 没有图层", s.metadata.ID)
+	}
+
+	if s.clock == nil {
+		s.clock = effects.NewClock()
+	}
+
+	s.compositor = New(screen)
+	s.errs = make([]error, len(slots))
+	for i, sl := range slots {
+		if s.seed != 0 {
+			effects.ApplySeed(sl.effect, s.seed+int64(i))
+		}
+		// 各图层使用时钟上以图层顺序为 rank 的 Lane，模拟时钟下同一时刻的帧按图层顺序执行
+		effects.ApplyClock(sl.effect, s.clock.Lane(i))
+
+		sl.layer, err = s.compositor.AddLayer(sl.spec.Z, sl.mode)
+		if err != nil {
+			return err
+		}
+
+		sl.target = sl.layer
+		width, height := sl.layer.Size()
+		sl.rect = viewport.Rect{Width: width, Height: height}
+		if sl.spec.Region != nil {
+			sl.region = viewport.New(sl.layer, sl.spec.Region.Rect(width, height))
+			sl.rect = sl.region.Rect()
+			sl.target = sl.region
+		}
+		if sl.rect.Empty() {
+			continue
+		}
+		if err := sl.effect.Init(sl.target); err != nil {
+			return fmt.Errorf("图层 %s 初始化失败: %w", sl.spec.ID, err)
+		}
+		sl.ready = true
+	}
+	return nil
+}
+
+// Resize 调整图层缓冲区的尺寸并按新尺寸重新划分图层区域，再通知图层中的特效
+// （实现 effects.Resizable 接口）
+func (s *Scene) Resize(width, height int) {
+	if s.compositor == nil {
+		return
+	}
+
+	s.compositor.Resize(width, height)
+	for i, sl := range s.slots {
+		if sl.layer == nil {
+			continue
+		}
+
+		sl.rect = viewport.Rect{Width: width, Height: height}
+		if sl.region != nil {
+			sl.region.SetRect(sl.spec.Region.Rect(width, height))
+			sl.rect = sl.region.Rect()
+		}
+
+		switch {
+		case sl.rect.Empty():
+			// 区域为空时不通知，特效保持原来的尺寸，绘制的内容都被裁掉
+		case !sl.ready:
+			s.startLate(i, sl)
+		default:
+			// 场景的 Resize 已在两帧之间调用，直接通知即可
+			effects.NotifyResize(sl.effect, nil, sl.rect.Width, sl.rect.Height)
+		}
+	}
+}
+
+// startLate 初始化区域刚变为非空的图层，场景正在运行时同时启动它
+func (s *Scene) startLate(i int, sl *slot) {
+	err := sl.effect.Init(sl.target)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err != nil {
+		s.errs[i] = fmt.Errorf("图层 %s 初始化失败: %w", sl.spec.ID, err)
+		if s.stop != nil {
+			s.haltLocked()
+		}
+		return
+	}
+
+	sl.ready = true
+	if s.stop != nil && !s.stopped {
+		s.start(i, sl)
+	}
+}
+
+// HandleKey 把按键转发给所有图层，返回是否有图层使用了该按键（实现 effects.KeyHandler 接口）
+func (s *Scene) HandleKey(event *tcell.EventKey) bool {
+	used := false
+	for _, sl := range s.slots {
+		if sl.ready && effects.SendKey(sl.effect, nil, event) {
+			used = true
+		}
+	}
+	return used
+}
+
+// HandleMouse 把鼠标事件转发给位于鼠标下方的图层，坐标换算为图层区域内的坐标
+// （实现 effects.MouseHandler 接口）。松开按键的事件转发给所有图层，
+// 以免拖动到其他区域后松开时，原来的图层仍以为按键处于按下状态
+func (s *Scene) HandleMouse(event *tcell.EventMouse) {
+	x, y := event.Position()
+	released := event.Buttons()&(tcell.Button1|tcell.Button2|tcell.Button3) == 0
+	for _, sl := range s.slots {
+		if !sl.ready {
+			continue
+		}
+
+		lx, ly := x-sl.rect.X, y-sl.rect.Y
+		if !released && !sl.rect.Contains(lx, ly) {
+			continue
+		}
+		// 场景的 HandleMouse 已在两帧之间调用，直接转发即可
+		effects.SendMouse(sl.effect, nil, tcell.NewEventMouse(lx, ly, event.Buttons(), event.Modifiers()))
+	}
+}
+
+// Run 同时运行所有已初始化的图层，直到 quit 关闭；任一图层结束或出错时停止全部图层
+// 区域为空的图层在 Resize 使其区域非空后才开始运行
+func (s *Scene) Run(quit <-chan struct{}) error {
+	s.mu.Lock()
+	if err := errors.Join(s.errs...); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	stop := make(chan struct{})
+	s.stop, s.stopped = stop, false
+
+	// 模拟时钟下等已初始化图层的帧循环都加入后才开始出帧，出帧顺序由各图层 Lane 的 rank 决定
+	ready := 0
+	for _, sl := range s.slots {
+		if sl.ready {
+			ready++
+		}
+	}
+	s.clock.Expect(ready)
+	for i, sl := range s.slots {
+		if sl.ready {
+			s.start(i, sl)
+		}
+	}
+	s.mu.Unlock()
+	defer s.clock.Expect(0)
+
+	select {
+	case <-quit:
+	case <-stop:
+	}
+	s.halt()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop = nil
+	return errors.Join(s.errs...)
+}
+
+// start 在后台运行图层，图层结束时停止全部图层（调用方须持有 mu）
+func (s *Scene) start(i int, sl *slot) {
+	stop := s.stop
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		// 图层可能没有启动帧循环就结束了，不再等待其余图层加入，让它们看到 stop 后退出
+		defer s.clock.Expect(0)
+		defer s.halt()
+		if err := sl.effect.Run(stop); err != nil {
+			s.mu.Lock()
+			s.errs[i] = fmt.Errorf("图层 %s: %w", sl.spec.ID, err)
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// halt 停止全部图层（可重复调用）
+func (s *Scene) halt() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.haltLocked()
+}
+
+// haltLocked 与 halt 相同（调用方须持有 mu）
+func (s *Scene) haltLocked() {
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+}
+
+// Cleanup 清理所有图层的特效并释放离屏缓冲区
+func (s *Scene) Cleanup() error {
+	var errs []error
+	for _, sl := range s.slots {
+		if sl.ready {
+			if err := sl.effect.Cleanup(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		sl.layer, sl.ready = nil, false
+	}
+	if s.compositor != nil {
+		s.compositor.Close()
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,354 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/playlist"
+	"github.com/symbolmove/symbol_move/pkg/transition"
+)
+
+// 配置文件版本
+const (
+	Version       = "2.0" // 当前版本
+	legacyVersion = "1.0" // 只包含 language 和 version 的旧版本
+)
+
+// MaxRecent 最多记录的最近运行特效数
+const MaxRecent = 8
+
+// Config 应用配置，保存在 ~/.symbolmove/config.json
+type Config struct {
+	Version    string                    `json:"version"`
+	Language   i18n.Language             `json:"language"`
+	DefaultFPS int                       `json:"default_fps,omitempty"` // 默认帧率（0 表示使用各特效自己的默认值）
+	LastRun    string                    `json:"last_run,omitempty"`    // 最近运行的特效 ID
+	Favorites  []string                  `json:"favorites,omitempty"`   // 收藏的特效 ID
+	Effects    map[string]map[string]any `json:"effects,omitempty"`     // 特效 ID -> 参数名 -> 参数值（只保存与默认值不同的参数）
+
+	Recent     []string          `json:"recent,omitempty"`      // 最近运行的特效 ID（最近的在前，最多 MaxRecent 个）
+	PlayCounts map[string]int    `json:"play_counts,omitempty"` // 特效 ID -> 运行次数
+	SortOrder  effects.SortOrder `json:"sort_order,omitempty"`  // 选择器中特效列表的排序方式（空表示注册顺序）
+
+	Transition     string                        `json:"transition,omitempty"`      // 切换特效时的过渡效果（空表示 crossfade），播放列表可单独设置
+	TransitionTime playlist.Duration             `json:"transition_time,omitempty"` // 过渡时长（0 表示使用默认值）
+	Playlists      map[string]*playlist.Playlist `json:"playlists,omitempty"`       // 播放列表名称 -> 播放列表
+
+	Palette  string            `json:"palette,omitempty"`  // 所有特效默认使用的调色板：名称或逗号分隔的颜色（空表示各特效自己的配色）
+	Palettes map[string]string `json:"palettes,omitempty"` // 特效 ID -> 调色板，优先于 palette
+
+	Theme string `json:"theme,omitempty"` // 界面与特效默认配色的主题（空表示 dark），可以是 themes 目录中的用户主题
+}
+
+// Default 返回默认配置
+func Default() *Config {
+	return &Config{
+		Version:  Version,
+		Language: i18n.LanguageChinese,
+		Effects:  make(map[string]map[string]any),
+	}
+}
+
+// DefaultPath 返回默认的配置文件路径 ~/.symbolmove/config.json
+func DefaultPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("无法确定用户目录: %w", err)
+	}
+	return filepath.Join(homeDir, ".symbolmove", "config.json"), nil
+}
+
+// Load 读取并解析配置文件，旧版本的配置会自动迁移
+// 文件不存在时返回默认配置；文件有错误时返回默认配置和描述错误位置的错误
+func Load(path string) (*Config, error) {
+	cfg, _, err := load(path)
+	return cfg, err
+}
+
+// load 读取配置文件，并返回是否需要写回（旧版本的配置）
+func load(path string) (*Config, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Default(), false, nil
+	}
+	if err != nil {
+		return Default(), false, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	cfg, migrated, err := parse(data)
+	if err != nil {
+		return Default(), false, fmt.Errorf("配置文件 %s %w", path, err)
+	}
+	return cfg, migrated, nil
+}
+
+// Parse 解析配置文件内容，旧版本的配置会自动迁移
+func Parse(data []byte) (*Config, error) {
+	cfg, _, err := parse(data)
+	return cfg, err
+}
+
+// parse 解析配置文件内容，并返回是否从旧版本迁移
+func parse(data []byte) (*Config, bool, error) {
+	cfg := Default()
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(cfg); err != nil {
+		return nil, false, describeError(data, err)
+	}
+	if dec.More() {
+		line, col := position(data, dec.InputOffset())
+		return nil, false, fmt.Errorf("第 %d 行第 %d 列: 配置之后有多余的内容", line, col)
+	}
+
+	migrated, err := cfg.migrate()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, false, err
+	}
+	cfg.normalizeNumbers()
+	return cfg, migrated, nil
+}
+
+// migrate 把旧版本的配置升级到当前版本，返回是否进行了迁移
+func (c *Config) migrate() (bool, error) {
+	switch c.Version {
+	case Version:
+		return false, nil
+	case "", legacyVersion:
+		// 1.0 只有 language 字段，其余字段使用默认值
+		c.Version = Version
+		return true, nil
+	}
+	return false, fmt.Errorf("版本 %s 不受支持（当前程序支持 %s）", c.Version, Version)
+}
+
+// validate 校验配置内容
+func (c *Config) validate() error {
+	switch c.Language {
+	case "":
+		c.Language = i18n.LanguageChinese
+	case i18n.LanguageChinese, i18n.LanguageEnglish:
+	default:
+		return fmt.Errorf("未知的界面语言 %q（可选 %s 或 %s）", c.Language, i18n.LanguageChinese, i18n.LanguageEnglish)
+	}
+
+	if c.DefaultFPS < 0 {
+		return fmt.Errorf("default_fps 不能为负数: %d", c.DefaultFPS)
+	}
+
+	if c.Effects == nil {
+		c.Effects = make(map[string]map[string]any)
+	}
+
+	if _, err := effects.ParseSortOrder(string(c.SortOrder)); err != nil {
+		return err
+	}
+
+	if c.Transition != "" {
+		if _, err := transition.Get(c.Transition); err != nil {
+			return err
+		}
+	}
+	if c.TransitionTime < 0 {
+		return fmt.Errorf("transition_time 不能为负数: %v", time.Duration(c.TransitionTime))
+	}
+
+	if c.Palette != "" {
+		if _, err := palette.Parse(c.Palette); err != nil {
+			return fmt.Errorf("palette: %w", err)
+		}
+	}
+	for id, spec := range c.Palettes {
+		if _, err := palette.Parse(spec); err != nil {
+			return fmt.Errorf("palettes.%s: %w", id, err)
+		}
+	}
+
+	for name, p := range c.Playlists {
+		if p == nil {
+			return fmt.Errorf("播放列表 %s 为空", name)
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("播放列表 %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// normalizeNumbers 把特效参数中的 json.Number 转换为 int 或 float64
+func (c *Config) normalizeNumbers() {
+	for _, values := range c.Effects {
+		for name, value := range values {
+			number, ok := value.(json.Number)
+			if !ok {
+				continue
+			}
+			if n, err := number.Int64(); err == nil {
+				values[name] = int(n)
+			} else if f, err := number.Float64(); err == nil {
+				values[name] = f
+			}
+		}
+	}
+}
+
+// describeError 把 JSON 解析错误转换为带行列号的错误
+func describeError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, col := position(data, syntaxErr.Offset)
+		return fmt.Errorf("第 %d 行第 %d 列: 格式错误: %v", line, col, syntaxErr)
+	case errors.As(err, &typeErr):
+		line, col := position(data, typeErr.Offset)
+		return fmt.Errorf("第 %d 行第 %d 列: 字段 %s 需要 %s 类型，实际为 %s", line, col, typeErr.Field, typeErr.Type, typeErr.Value)
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("内容为空或不完整")
+	}
+	return fmt.Errorf("解析失败: %w", err)
+}
+
+// position 把字节偏移换算为行号和列号（均从 1 开始，列按字符计）
+func position(data []byte, offset int64) (line, col int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	line, col = 1, 1
+	for _, r := range string(data[:offset]) {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+// EffectParams 返回特效保存的参数（没有时返回 nil）
+func (c *Config) EffectParams(effectID string) map[string]any {
+	return c.Effects[effectID]
+}
+
+// SetEffectParams 设置特效的参数，values 为空时清除该特效的设置
+func (c *Config) SetEffectParams(effectID string, values map[string]any) {
+	if len(values) == 0 {
+		delete(c.Effects, effectID)
+		return
+	}
+	if c.Effects == nil {
+		c.Effects = make(map[string]map[string]any)
+	}
+	c.Effects[effectID] = values
+}
+
+// RunParams 返回运行特效时应用的参数：默认帧率与特效保存的参数（后者优先）
+func (c *Config) RunParams(effectID string) map[string]any {
+	values := make(map[string]any)
+	if c.DefaultFPS > 0 {
+		values["fps"] = c.DefaultFPS
+	}
+	maps.Copy(values, c.EffectParams(effectID))
+	return values
+}
+
+// EffectPalette 返回特效使用的调色板，没有单独设置时返回默认的调色板（都没有时返回空字符串）
+func (c *Config) EffectPalette(effectID string) string {
+	if spec := c.Palettes[effectID]; spec != "" {
+		return spec
+	}
+	return c.Palette
+}
+
+// IsFavorite 判断特效是否已收藏
+func (c *Config) IsFavorite(effectID string) bool {
+	return slices.Contains(c.Favorites, effectID)
+}
+
+// ToggleFavorite 收藏或取消收藏特效，返回操作后是否已收藏
+func (c *Config) ToggleFavorite(effectID string) bool {
+	if i := slices.Index(c.Favorites, effectID); i >= 0 {
+		c.Favorites = slices.Delete(c.Favorites, i, i+1)
+		return false
+	}
+	c.Favorites = append(c.Favorites, effectID)
+	return true
+}
+
+// RecordRun 记录运行了特效：更新最近运行的特效与运行次数
+func (c *Config) RecordRun(effectID string) {
+	c.LastRun = effectID
+
+	c.Recent = slices.DeleteFunc(c.Recent, func(id string) bool { return id == effectID })
+	c.Recent = slices.Insert(c.Recent, 0, effectID)
+	if len(c.Recent) > MaxRecent {
+		c.Recent = c.Recent[:MaxRecent]
+	}
+
+	if c.PlayCounts == nil {
+		c.PlayCounts = make(map[string]int)
+	}
+	c.PlayCounts[effectID]++
+}
+
+// clone 返回配置的深拷贝
+func (c *Config) clone() *Config {
+	copied := *c
+	copied.Favorites = slices.Clone(c.Favorites)
+	copied.Recent = slices.Clone(c.Recent)
+	copied.PlayCounts = maps.Clone(c.PlayCounts)
+	copied.Palettes = maps.Clone(c.Palettes)
+	copied.Effects = make(map[string]map[string]any, len(c.Effects))
+	for id, values := range c.Effects {
+		copied.Effects[id] = maps.Clone(values)
+	}
+	if c.Playlists != nil {
+		copied.Playlists = make(map[string]*playlist.Playlist, len(c.Playlists))
+		for name, p := range c.Playlists {
+			copied.Playlists[name] = p.Clone()
+		}
+	}
+	return &copied
+}
+
+// Save 把配置写入文件（先写临时文件再重命名，避免写入中断损坏配置）
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建配置目录失败: %w", err)
+	}
+
+	c.Version = Version
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("写入配置文件失败: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("写入配置文件失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+	"github.com/symbolmove/symbol_move/pkg/playlist"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Version != Version || cfg.Language != i18n.LanguageChinese {
+		t.Errorf("Expected default config, got %+v", cfg)
+	}
+}
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	writeFile(t, path, `{"language": "en", "version": "1.0"}`)
+	other := filepath.Join(dir, "effects.json")
+	writeFile(t, other, `{"matrix-rain": {"trail": 20}}`)
+
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := store.Config()
+	if cfg.Language != i18n.LanguageEnglish {
+		t.Errorf("Expected language to survive migration, got %q", cfg.Language)
+	}
+	if got := cfg.EffectParams("matrix-rain"); got != nil {
+		t.Errorf("Expected no effect params, got %v", got)
+	}
+
+	// 迁移后立即写回新版本，目录中的其他文件保持不变
+	saved, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Version != Version || saved.Language != i18n.LanguageEnglish {
+		t.Errorf("Expected migrated file to be written, got %+v", saved)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("Expected unrelated file to be kept: %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"{\n  \"language\": \"en\",\n  \"version\": \"2.0\",\n}", "第 4 行"},
+		{"{\n  \"default_fps\": \"fast\"\n}", "default_fps"},
+		{`{"language": "fr"}`, "未知的界面语言"},
+		{`{"version": "9.0"}`, "不受支持"},
+		{"", "为空"},
+		{`{"transition": "spin"}`, "未知的过渡效果"},
+		{`{"playlists": {"idle": {"transition": "spin"}}}`, "播放列表 idle"},
+		{`{"playlists": {"idle": {"duration": "soon"}}}`, "soon"},
+		{`{"sort_order": "random"}`, "未知的排序方式"},
+		{`{"palette": "sunset"}`, "未知的调色板"},
+		{`{"palettes": {"fire-effect": "#000,#zz0000"}}`, "palettes.fire-effect"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q): expected error containing %q, got %v", tt.content, tt.want, err)
+		}
+	}
+}
+
+func TestBrokenFileIsNotOverwritten(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	broken := `{"language": "en",`
+	writeFile(t, path, broken)
+
+	store, err := Open(path)
+	if err == nil || !strings.Contains(err.Error(), path) {
+		t.Fatalf("Expected error mentioning %s, got %v", path, err)
+	}
+	if store.Config().Language != i18n.LanguageChinese {
+		t.Error("Expected defaults when the file is broken")
+	}
+
+	if err := store.SetEffectParams("plasma", map[string]any{"speed": 2.0}); err == nil {
+		t.Error("Expected saving to fail while the file is broken")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != broken {
+		t.Errorf("Broken file was overwritten: %s", data)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "config.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Update(func(cfg *Config) {
+		cfg.DefaultFPS = 24
+		cfg.RecordRun("snowfall")
+		cfg.RecordRun("plasma")
+		cfg.RecordRun("snowfall")
+		cfg.RecordRun("plasma")
+		cfg.SortOrder = effects.SortPlays
+		cfg.ToggleFavorite("fireworks")
+		cfg.ToggleFavorite("snowfall")
+		cfg.ToggleFavorite("fireworks")
+		cfg.SetEffectParams("plasma", map[string]any{"speed": 1.5, "fps": 60})
+		cfg.Playlists = map[string]*playlist.Playlist{
+			"idle": {Effects: []string{"plasma", "自然"}, Duration: playlist.Duration(time.Minute), Shuffle: true},
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultFPS != 24 || cfg.LastRun != "plasma" || cfg.SortOrder != effects.SortPlays {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+	if !reflect.DeepEqual(cfg.Recent, []string{"plasma", "snowfall"}) || cfg.PlayCounts["plasma"] != 2 {
+		t.Errorf("Unexpected run history: %v, %v", cfg.Recent, cfg.PlayCounts)
+	}
+	if !reflect.DeepEqual(cfg.Favorites, []string{"snowfall"}) || !cfg.IsFavorite("snowfall") {
+		t.Errorf("Unexpected favorites: %v", cfg.Favorites)
+	}
+	want := map[string]any{"speed": 1.5, "fps": 60}
+	if got := cfg.EffectParams("plasma"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if p := cfg.Playlists["idle"]; p == nil || p.EachDuration() != time.Minute || !p.Shuffle || len(p.Effects) != 2 {
+		t.Errorf("Unexpected playlist: %+v", p)
+	}
+}
+
+func TestReloadExternalChanges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	store, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Store 自身的写入不算外部修改
+	store.Update(func(cfg *Config) { cfg.LastRun = "plasma" })
+	if _, ok, _ := store.reload(); ok {
+		t.Error("Own save should not trigger reload")
+	}
+
+	writeFile(t, path, `{"version": "2.0", "effects": {"plasma": {"speed": 2}}}`)
+	cfg, ok, err := store.reload()
+	if !ok || err != nil {
+		t.Fatalf("Expected reload, got ok=%v err=%v", ok, err)
+	}
+	if got := store.EffectParams("plasma"); !reflect.DeepEqual(got, map[string]any{"speed": 2}) {
+		t.Errorf("Unexpected params after reload: %v", got)
+	}
+	if cfg.LastRun != "" {
+		t.Errorf("Expected config from file, got LastRun %q", cfg.LastRun)
+	}
+
+	// 文件有错误时保留当前配置并拒绝保存
+	writeFile(t, path, `{"version": "2.0",`)
+	if _, ok, err := store.reload(); !ok || err == nil {
+		t.Fatalf("Expected reload error, got ok=%v err=%v", ok, err)
+	}
+	if got := store.EffectParams("plasma"); got["speed"] != 2 {
+		t.Errorf("Config should be kept on error, got %v", got)
+	}
+	if err := store.Update(func(cfg *Config) {}); err == nil {
+		t.Error("Expected save to be refused while the file is broken")
+	}
+}
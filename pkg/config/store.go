@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// Store 配置文件的读写入口，可被多个界面组件共享
+// 加载失败时使用默认配置运行，但拒绝写入，以免覆盖用户有错误待修正的配置文件
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	cfg     *Config
+	loadErr error
+	stamp   fileStamp // 最近一次加载或写入后的文件状态（Watch 据此忽略自身的写入）
+}
+
+// Open 打开配置文件，path 为空时使用默认路径
+// 旧版本的配置会迁移并立即写回；返回的错误不影响使用 Store（此时为默认配置）
+func Open(path string) (*Store, error) {
+	if path == "" {
+		var err error
+		path, err = DefaultPath()
+		if err != nil {
+			return &Store{cfg: Default(), loadErr: err}, err
+		}
+	}
+
+	cfg, dirty, err := load(path)
+	store := &Store{path: path, cfg: cfg, loadErr: err, stamp: stampOf(path)}
+	if err != nil {
+		return store, err
+	}
+
+	if dirty {
+		if err := cfg.Save(path); err != nil {
+			return store, fmt.Errorf("迁移配置文件失败: %w", err)
+		}
+		store.stamp = stampOf(path)
+	}
+	return store, nil
+}
+
+// Path 返回配置文件路径
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Config 返回当前配置的副本
+func (s *Store) Config() *Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg.clone()
+}
+
+// Update 修改配置并写入文件
+func (s *Store) Update(fn func(cfg *Config)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.cfg)
+
+	if s.loadErr != nil {
+		return fmt.Errorf("配置文件存在错误，修正后才能保存: %w", s.loadErr)
+	}
+	if err := s.cfg.Save(s.path); err != nil {
+		return err
+	}
+	s.stamp = stampOf(s.path)
+	return nil
+}
+
+// EffectParams 返回特效保存的参数（没有时返回 nil）
+func (s *Store) EffectParams(effectID string) map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.cfg.EffectParams(effectID))
+}
+
+// SetEffectParams 保存特效的参数，values 为空时清除该特效的设置
+func (s *Store) SetEffectParams(effectID string, values map[string]any) error {
+	return s.Update(func(cfg *Config) {
+		cfg.SetEffectParams(effectID, values)
+	})
+}
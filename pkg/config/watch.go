@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+// fileStamp 配置文件的修改时间与大小，用于判断文件是否被外部修改
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// stampOf 返回文件当前的 fileStamp（文件不存在时为零值）
+func stampOf(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// Watch 每隔 interval 检查一次配置文件，文件被外部修改后重新加载并调用 changed
+// 重新加载成功时 changed 收到新配置的副本；文件有错误时保留当前配置，changed 收到错误，
+// 并且在文件修正之前拒绝保存。Store 自身的写入不会触发 changed。stop 关闭后返回
+func (s *Store) Watch(interval time.Duration, stop <-chan struct{}, changed func(cfg *Config, err error)) {
+	if s.path == "" {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if cfg, ok, err := s.reload(); ok {
+				changed(cfg, err)
+			}
+		}
+	}
+}
+
+// reload 文件被修改时重新加载，返回新配置的副本、文件是否被修改以及加载错误
+func (s *Store) reload() (*Config, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stamp := stampOf(s.path)
+	if stamp == s.stamp {
+		return nil, false, nil
+	}
+	s.stamp = stamp
+
+	cfg, _, err := load(s.path)
+	s.loadErr = err
+	if err != nil {
+		return nil, true, err
+	}
+
+	s.cfg = cfg
+	return cfg.clone(), true, nil
+}
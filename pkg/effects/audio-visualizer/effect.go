@@ -8,6 +8,7 @@ import (
 type AudioVisualizerEffect struct {
 	visualizer *AudioVisualizer
 	config     *Config
+	clock      *effects.Clock
 }
 
 func NewEffect() effects.Effect {
@@ -20,32 +21,97 @@ func (e *AudioVisualizerEffect) Metadata() effects.Metadata {
 	return effects.Metadata{
 		ID:            "audio-visualizer",
 		Name:          "音频可视化",
-		Description:   "模拟音频波形展示,使用随机或正弦波数据,频谱柱状图效果",
+		Description:   "频谱柱、示波器、频谱瀑布和电平表,可读取 PCM 音频显示真实音乐,否则使用模拟数据",
 		NameEN:        "Audio Visualizer",
-		DescriptionEN: "Simulated audio waveform display with spectrum bar graph effect",
+		DescriptionEN: "Spectrum bars, oscilloscope, spectrogram and VU meters for PCM audio input, or simulated data",
 		LongDescription: `
-音频可视化特效模拟音频频谱显示效果。
+音频可视化特效以多种方式显示音频。
+
+显示方式（mode 参数）：
+- bars：频谱柱状图，频段按对数间隔划分
+- mirror：以中线上下对称的频谱柱，顶端按八分之一格细分
+- scope：盲文点阵绘制的波形示波器，对齐过零点让周期波形稳定
+- spectrogram：按强度着色、从右向左滚动的频谱瀑布图
+- vu：带峰值保持的立体声电平表
 
 特点：
-- 频谱柱状图
-- 平滑动画过渡
+- 读取 PCM 音频（s16le/f32le 原始数据或 WAV 文件），实时 FFT 分析
+- 柱子按起音与释放时间平滑过渡
 - 动态颜色渐变
-- 模拟音频数据
-- 30 FPS 流畅运行
+- 没有音频输入时使用模拟数据
+
+音频输入：
+- 标准输入：ffmpeg -i song.mp3 -f s16le -ac 2 -ar 44100 - | symbol-move run audio-visualizer --pcm -
+- 命名管道或 WAV 文件：--pcm /tmp/audio.fifo、--pcm song.wav
+- 原始 PCM 的格式用 pcm-format、pcm-rate、pcm-channels 参数指定（默认 s16le、44100Hz、双声道），WAV 文件按文件头
+- 音频按特效的时钟播放，暂停和调速同样有效
 
 完美用于：
 - 音乐播放器效果
 - 音频展示
 - 可视化演示
-`,
+
+控制：按 m 切换显示方式`,
 		Author:  "SymbolMove",
 		Version: "1.0.0",
 		Tags:    []string{"音频", "可视化", "频谱", "动画"},
 	}
 }
 
+func (e *AudioVisualizerEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+func (e *AudioVisualizerEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+func (e *AudioVisualizerEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	effects.Enum(ps, effects.Param{
+		Name: "mode", Label: "显示方式", LabelEN: "Mode",
+		Options: []string{"bars", "mirror", "scope", "spectrogram", "vu"},
+	}, &e.config.Mode, Modes...)
+	ps.Int(effects.Param{Name: "bars", Label: "柱子数量", LabelEN: "Bars", Min: 8, Max: 120}, &e.config.BarCount)
+	ps.String(effects.Param{Name: "pcm", Label: "PCM 音频输入", LabelEN: "PCM input"}, &e.config.PCM)
+	effects.Enum(ps, effects.Param{
+		Name: "pcm-format", Label: "PCM 采样格式", LabelEN: "PCM sample format",
+		Options: []string{"s16le", "f32le"},
+	}, &e.config.Format, FormatS16LE, FormatF32LE)
+	ps.Int(effects.Param{Name: "pcm-rate", Label: "PCM 采样率", LabelEN: "PCM sample rate", Min: 8000, Max: 192000, Step: 100}, &e.config.SampleRate)
+	ps.Int(effects.Param{Name: "pcm-channels", Label: "PCM 声道数", LabelEN: "PCM channels", Min: 1, Max: 8}, &e.config.Channels)
+	ps.Float(effects.Param{Name: "attack", Label: "起音时间", LabelEN: "Attack", Min: 0, Max: 1, Step: 0.01}, &e.config.Attack)
+	ps.Float(effects.Param{Name: "decay", Label: "释放时间", LabelEN: "Decay", Min: 0, Max: 3, Step: 0.05}, &e.config.Decay)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+func (e *AudioVisualizerEffect) Reconfigure() {
+	if e.visualizer != nil {
+		e.visualizer.Reconfigure()
+	}
+}
+
+// HandleKey m 键切换到下一个显示方式（实现 effects.KeyHandler 接口）
+func (e *AudioVisualizerEffect) HandleKey(event *tcell.EventKey) bool {
+	if e.visualizer == nil || event.Key() != tcell.KeyRune || (event.Rune() != 'm' && event.Rune() != 'M') {
+		return false
+	}
+	e.config.Mode = nextMode(e.config.Mode)
+	e.visualizer.Reconfigure()
+	return true
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *AudioVisualizerEffect) Resize(width, height int) {
+	if e.visualizer != nil {
+		e.visualizer.Resize(width, height)
+	}
+}
+
 func (e *AudioVisualizerEffect) Init(screen tcell.Screen) error {
 	e.visualizer = New(screen, e.config)
+	e.visualizer.SetClock(e.clock)
 	return e.visualizer.Init()
 }
 
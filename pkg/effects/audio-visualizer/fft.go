@@ -0,0 +1,125 @@
+package audiovisualizer
+
+import (
+	"math"
+	"math/bits"
+)
+
+// fftSize 频谱分析的窗口长度（采样数），44.1kHz 下约 46ms，频率分辨率约 21.5Hz
+const fftSize = 2048
+
+// 频谱柱覆盖的频率范围与电平范围
+const (
+	minFrequency = 30.0    // 最低频率（Hz）
+	maxFrequency = 16000.0 // 最高频率（Hz），不超过采样率的一半
+	dynamicRange = 60.0    // 柱子从空到满对应的分贝范围，满刻度正弦波为 0dB
+)
+
+// fft 原地计算复数序列的快速傅里叶变换，长度必须是 2 的幂
+func fft(re, im []float64) {
+	n := len(re)
+	shift := 64 - bits.Len(uint(n-1))
+
+	// 按位反转的顺序重排
+	for i := 0; i < n; i++ {
+		j := int(bits.Reverse64(uint64(i)) >> shift)
+		if j > i {
+			re[i], re[j] = re[j], re[i]
+			im[i], im[j] = im[j], im[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half := size / 2
+		step := -2 * math.Pi / float64(size)
+		for start := 0; start < n; start += size {
+			for k := 0; k < half; k++ {
+				wr, wi := math.Cos(step*float64(k)), math.Sin(step*float64(k))
+				a, b := start+k, start+k+half
+				tr := wr*re[b] - wi*im[b]
+				ti := wr*im[b] + wi*re[b]
+				re[b], im[b] = re[a]-tr, im[a]-ti
+				re[a], im[a] = re[a]+tr, im[a]+ti
+			}
+		}
+	}
+}
+
+// analyzer 对最近的采样做频谱分析
+type analyzer struct {
+	samples []float64 // 最近 fftSize 个单声道采样，最新的在末尾
+	window  []float64 // Hann 窗
+	re, im  []float64
+}
+
+// newAnalyzer 创建频谱分析器
+func newAnalyzer() *analyzer {
+	a := &analyzer{
+		samples: make([]float64, fftSize),
+		window:  make([]float64, fftSize),
+		re:      make([]float64, fftSize),
+		im:      make([]float64, fftSize),
+	}
+	for i := range a.window {
+		a.window[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(fftSize-1))
+	}
+	return a
+}
+
+// push 追加左右声道的采样（混合为单声道）
+func (a *analyzer) push(left, right []float64) {
+	if len(left) >= fftSize {
+		left, right = left[len(left)-fftSize:], right[len(right)-fftSize:]
+	}
+	n := len(left)
+	copy(a.samples, a.samples[n:])
+	for i := range left {
+		a.samples[fftSize-n+i] = (left[i] + right[i]) / 2
+	}
+}
+
+// bands 把当前窗口的频谱划分为 len(levels) 个对数间隔的频段，写入各频段 0~1 的电平
+func (a *analyzer) bands(rate int, levels []float64) {
+	for i, s := range a.samples {
+		a.re[i] = s * a.window[i]
+		a.im[i] = 0
+	}
+	fft(a.re, a.im)
+
+	// 加 Hann 窗后振幅为 A 的正弦波在频谱中的峰值约为 A·N/4
+	amplitude := func(bin int) float64 {
+		return math.Hypot(a.re[bin], a.im[bin]) * 4 / fftSize
+	}
+
+	high := min(maxFrequency, float64(rate)/2)
+	binWidth := float64(rate) / fftSize
+	n := len(levels)
+	for i := range levels {
+		lo := minFrequency * math.Pow(high/minFrequency, float64(i)/float64(n))
+		hi := minFrequency * math.Pow(high/minFrequency, float64(i+1)/float64(n))
+
+		// 低频段可能比一个频点还窄，至少取一个频点
+		first := max(1, int(math.Round(lo/binWidth)))
+		last := max(first, int(math.Round(hi/binWidth))-1)
+		peak := 0.0
+		for bin := first; bin <= last && bin < fftSize/2; bin++ {
+			peak = max(peak, amplitude(bin))
+		}
+
+		db := 20 * math.Log10(peak+1e-12)
+		levels[i] = max(0, min(1, (db+dynamicRange)/dynamicRange))
+	}
+}
+
+// smooth 按起音与释放时间常数（秒）把 current 平滑地推向 target
+// 电平上升时使用 attack，下降时使用 decay，时间常数为 0 时立即到达
+func smooth(current, target, deltaTime, attack, decay float64) float64 {
+	tau := decay
+	if target > current {
+		tau = attack
+	}
+	if tau <= 0 {
+		return target
+	}
+	return current + (target-current)*(1-math.Exp(-deltaTime/tau))
+}
@@ -0,0 +1,350 @@
+package audiovisualizer
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
+)
+
+// Mode 音频的显示方式
+type Mode string
+
+const (
+	ModeBars        Mode = "bars"        // 频谱柱状图
+	ModeMirror      Mode = "mirror"      // 以中线上下对称的频谱柱
+	ModeScope       Mode = "scope"       // 盲文点阵绘制的波形示波器
+	ModeSpectrogram Mode = "spectrogram" // 从右向左滚动、按强度着色的频谱瀑布图
+	ModeVU          Mode = "vu"          // 带峰值保持的立体声电平表
+)
+
+// Modes 按切换顺序列出全部显示方式
+var Modes = []Mode{ModeBars, ModeMirror, ModeScope, ModeSpectrogram, ModeVU}
+
+// modeNames 切换时提示的显示方式名称
+var modeNames = map[Mode]string{
+	ModeBars:        "频谱柱",
+	ModeMirror:      "镜像频谱",
+	ModeScope:       "示波器",
+	ModeSpectrogram: "频谱瀑布",
+	ModeVU:          "电平表",
+}
+
+// nextMode 返回切换顺序中 mode 的下一个显示方式
+func nextMode(mode Mode) Mode {
+	for i, m := range Modes {
+		if m == mode {
+			return Modes[(i+1)%len(Modes)]
+		}
+	}
+	return Modes[0]
+}
+
+// 电平表的峰值保持
+const (
+	peakHold = 1.0 // 峰值停留的时间（秒）
+	peakFall = 0.5 // 停留结束后每秒回落的电平
+)
+
+// bannerTime 切换显示方式后提示名称的时间（秒）
+const bannerTime = 2.0
+
+// meter 一个声道的电平表
+type meter struct {
+	level float64 // 当前电平 0~1
+	peak  float64 // 保持的峰值
+	hold  float64 // 峰值剩余的停留时间（秒）
+}
+
+// update 让电平按起音与释放时间跟随 target，并更新峰值
+func (m *meter) update(target, deltaTime, attack, decay float64) {
+	m.level = smooth(m.level, target, deltaTime, attack, decay)
+	switch {
+	case m.level >= m.peak:
+		m.peak = m.level
+		m.hold = peakHold
+	case m.hold > 0:
+		m.hold -= deltaTime
+	default:
+		m.peak = max(m.level, m.peak-peakFall*deltaTime)
+	}
+}
+
+// rmsLevel 把一段采样的均方根换算为 0~1 的电平，满刻度正弦波为满格
+func rmsLevel(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, s := range samples {
+		sum += s * s
+	}
+	rms := math.Sqrt(sum/float64(len(samples))) * math.Sqrt2
+	db := 20 * math.Log10(rms+1e-12)
+	return max(0, min(1, (db+dynamicRange)/dynamicRange))
+}
+
+// applyMode 切换到配置中的显示方式，并在右上角提示一段时间
+func (a *AudioVisualizer) applyMode() {
+	if a.config.Mode != a.mode {
+		a.mode = a.config.Mode
+		a.banner = bannerTime
+	}
+}
+
+// updateModes 更新各显示方式共用的状态：电平表与频谱瀑布的历史
+func (a *AudioVisualizer) updateModes(deltaTime float64) {
+	if a.banner > 0 {
+		a.banner -= deltaTime
+	}
+
+	// 模拟数据没有声道之分，低频一半的柱子作为左声道，高频一半作为右声道
+	if a.source == nil {
+		half := len(a.barHeights) / 2
+		a.vuTarget = [2]float64{mean(a.barHeights[:half]), mean(a.barHeights[half:])}
+	}
+	for i := range a.meters {
+		a.meters[i].update(a.vuTarget[i], deltaTime, a.config.Attack, a.config.Decay)
+	}
+
+	// 每帧记录一列，最多保留一屏
+	column := append([]float64(nil), a.barHeights...)
+	a.history = append(a.history, column)
+	if over := len(a.history) - max(a.width, 1); over > 0 {
+		a.history = append(a.history[:0], a.history[over:]...)
+	}
+}
+
+// mean 返回平均值，空切片为 0
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// renderBanner 切换显示方式后在右上角提示当前的显示方式
+func (a *AudioVisualizer) renderBanner() {
+	if a.banner <= 0 {
+		return
+	}
+	text := fmt.Sprintf(" %s · m 切换 ", modeNames[a.mode])
+	x := max(0, a.width-uniseg.StringWidth(text))
+	a.screen.PutStrStyled(x, 0, text, tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorSilver))
+}
+
+// renderMirror 柱子从中线同时向上和向下伸展，顶端用八分之一方块细分高度，下半部分颜色较暗
+func (a *AudioVisualizer) renderMirror() {
+	barWidth := max(1, a.width/a.config.BarCount)
+	center := a.height / 2
+	half := max(1, center)
+	bright := []tcell.Color{tcell.ColorGreen, tcell.ColorYellow, tcell.ColorOrange, tcell.ColorRed}
+	dim := []tcell.Color{tcell.ColorDarkGreen, tcell.ColorOlive, tcell.ColorDarkOrange, tcell.ColorDarkRed}
+
+	for i, h := range a.barHeights {
+		eighths := int(max(0, min(1, h)) * float64(half*8))
+		for dy := 0; dy*8 < eighths; dy++ {
+			ch := '█'
+			if rest := eighths - dy*8; rest < 8 {
+				ch = a.chars[rest-1]
+			}
+			colorIdx := min(dy*len(bright)/half, len(bright)-1)
+			up := tcell.StyleDefault.Foreground(bright[colorIdx])
+			down := tcell.StyleDefault.Foreground(dim[colorIdx])
+			for dx := 0; dx < barWidth; dx++ {
+				x := i*barWidth + dx
+				if x >= a.width {
+					break
+				}
+				a.screen.SetContent(x, center-1-dy, ch, nil, up)
+				// 倒影只画整格，不足一格的顶端画成半格
+				if ch == '█' {
+					a.screen.SetContent(x, center+dy, '█', nil, down)
+				} else if eighths-dy*8 >= 4 {
+					a.screen.SetContent(x, center+dy, '▀', nil, down)
+				}
+			}
+		}
+	}
+}
+
+// renderScope 用盲文点阵绘制波形，每个字符 2×4 个点，相邻采样之间连线
+func (a *AudioVisualizer) renderScope() {
+	if a.width <= 0 || a.height <= 0 {
+		return
+	}
+	if a.scope == nil {
+		a.scope = canvas.New(canvas.ModeBraille, a.width, a.height)
+	}
+	a.scope.Clear()
+
+	dotsW, dotsH := a.scope.Size()
+	toDot := func(v float64) int {
+		return int(math.Round((1 - max(-1, min(1, v))) / 2 * float64(dotsH-1)))
+	}
+	prev := 0
+	for x, v := range a.waveform(dotsW) {
+		y := toDot(v)
+		if x == 0 {
+			prev = y
+		}
+		// 与前一个采样连线，波形陡峭时也是连续的
+		a.scope.Line(max(0, x-1), prev, x, y, tcell.ColorLime)
+		prev = y
+	}
+
+	// 中线画在波形下面，点阵只覆盖有点的单元格
+	axis := tcell.StyleDefault.Foreground(tcell.ColorDarkSlateGray)
+	for col := 0; col < a.width; col++ {
+		a.screen.SetContent(col, a.height/2, '─', nil, axis)
+	}
+	a.scope.Draw(a.screen)
+}
+
+// waveform 返回 n 个 -1~1 的采样用于示波器
+// 有音频输入时取最近的采样，并对齐到上升过零点让周期信号的波形稳定；模拟数据由柱子高度合成
+func (a *AudioVisualizer) waveform(n int) []float64 {
+	wave := make([]float64, n)
+	if a.analyzer != nil {
+		samples := a.analyzer.samples
+		start := len(samples) - min(n, len(samples))
+		if 2*n <= len(samples) {
+			// 在倒数第二屏的范围内寻找上升过零点
+			for i := len(samples) - 2*n; i < len(samples)-n; i++ {
+				if samples[i] <= 0 && samples[i+1] > 0 {
+					start = i + 1
+					break
+				}
+			}
+		}
+		copy(wave, samples[start:])
+		return wave
+	}
+
+	// 模拟数据：以若干根柱子的高度作为谐波的振幅
+	const partials = 6
+	total := 0.0
+	for k := 0; k < partials; k++ {
+		h := a.barHeights[k*len(a.barHeights)/partials]
+		total += h
+		for x := range wave {
+			phase := 2*math.Pi*float64(k+1)*1.5*float64(x)/float64(n) + a.time*float64(k+1)
+			wave[x] += h * math.Sin(phase)
+		}
+	}
+	if total > 0 {
+		gain := mean(a.barHeights) / total
+		for x := range wave {
+			wave[x] *= gain
+		}
+	}
+	return wave
+}
+
+// heatStops 频谱瀑布从弱到强的颜色
+var heatStops = [][3]float64{
+	{0, 0, 40}, {40, 0, 140}, {150, 0, 160}, {230, 40, 40}, {255, 190, 0}, {255, 255, 230},
+}
+
+// heatColor 按 0~1 的强度在 heatStops 间插值
+func heatColor(level float64) tcell.Color {
+	pos := max(0, min(1, level)) * float64(len(heatStops)-1)
+	i := min(int(pos), len(heatStops)-2)
+	t := pos - float64(i)
+	lerp := func(c int) int32 {
+		return int32(heatStops[i][c] + (heatStops[i+1][c]-heatStops[i][c])*t)
+	}
+	return tcell.NewRGBColor(lerp(0), lerp(1), lerp(2))
+}
+
+// renderSpectrogram 最新的频谱在最右列，历史向左滚动；低频在下，每行取对应频段中的最大值
+func (a *AudioVisualizer) renderSpectrogram() {
+	offset := a.width - len(a.history)
+	for i, column := range a.history {
+		x := offset + i
+		if x < 0 || len(column) == 0 {
+			continue
+		}
+		for row := 0; row < a.height; row++ {
+			band := a.height - 1 - row
+			lo := band * len(column) / a.height
+			hi := max(lo+1, (band+1)*len(column)/a.height)
+			level := 0.0
+			for _, v := range column[lo:min(hi, len(column))] {
+				level = max(level, v)
+			}
+			if level < 0.05 {
+				continue
+			}
+			a.screen.SetContent(x, row, '█', nil, tcell.StyleDefault.Foreground(heatColor(level)))
+		}
+	}
+}
+
+// vuScale 电平表刻度标注的分贝值
+var vuScale = []int{-60, -48, -36, -24, -12, -6, 0}
+
+// renderVU 画左右声道的水平电平表：绿、黄、红三段，白色竖线标出保持的峰值，下方是分贝刻度
+func (a *AudioVisualizer) renderVU() {
+	const left = 3 // 声道标签占用的列数
+	length := a.width - left - 2
+	if length < 4 || a.height < 1 {
+		return
+	}
+	thick := max(1, min(5, (a.height-3)/3))
+	top := max(0, (a.height-(2*thick+2))/2)
+	label := tcell.StyleDefault.Foreground(tcell.ColorSilver)
+
+	for ch, name := range []string{"L", "R"} {
+		m := a.meters[ch]
+		y0 := top + ch*(thick+1)
+		filled := int(m.level * float64(length))
+		peak := min(length-1, int(m.peak*float64(length)))
+
+		for dy := 0; dy < thick; dy++ {
+			y := y0 + dy
+			if y >= a.height {
+				break
+			}
+			if dy == thick/2 {
+				a.screen.PutStrStyled(1, y, name, label)
+			}
+			for i := 0; i < length; i++ {
+				var color tcell.Color
+				switch p := float64(i) / float64(length); {
+				case p < 0.7:
+					color = tcell.ColorGreen
+				case p < 0.9:
+					color = tcell.ColorYellow
+				default:
+					color = tcell.ColorRed
+				}
+				switch {
+				case i < filled:
+					a.screen.SetContent(left+i, y, '█', nil, tcell.StyleDefault.Foreground(color))
+				case i == peak && m.peak > 0:
+					a.screen.SetContent(left+i, y, '▌', nil, tcell.StyleDefault.Foreground(tcell.ColorWhite))
+				default:
+					a.screen.SetContent(left+i, y, '·', nil, tcell.StyleDefault.Foreground(tcell.ColorDarkSlateGray))
+				}
+			}
+		}
+	}
+
+	y := top + 2*(thick+1)
+	if y >= a.height {
+		return
+	}
+	for _, db := range vuScale {
+		text := fmt.Sprint(db)
+		x := left + int(float64(db+dynamicRange)/dynamicRange*float64(length-1)) - len(text)/2
+		a.screen.PutStrStyled(max(0, min(x, a.width-len(text))), y, text, label)
+	}
+	a.screen.PutStrStyled(max(0, a.width-2), y, "dB", label)
+}
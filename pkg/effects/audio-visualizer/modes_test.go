@@ -0,0 +1,63 @@
+package audiovisualizer
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMeterPeakHold(t *testing.T) {
+	var m meter
+	m.update(1, 0.1, 0, 0)
+	if m.level != 1 || m.peak != 1 {
+		t.Fatalf("Expected level and peak to jump to 1, got %+v", m)
+	}
+
+	// 电平回落后峰值先停留 peakHold 秒，再逐渐回落
+	m.update(0, 0.5, 0, 0)
+	if m.level != 0 || m.peak != 1 {
+		t.Fatalf("Expected peak to be held, got %+v", m)
+	}
+	m.update(0, 0.6, 0, 0)
+	m.update(0, 0.5, 0, 0)
+	if want := 1 - peakFall*0.5; m.peak != want {
+		t.Errorf("Expected peak to fall to %v after the hold, got %v", want, m.peak)
+	}
+}
+
+func TestSwitchMode(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 12)
+
+	e := NewEffect().(*AudioVisualizerEffect)
+	if err := e.Params().Set("mode", "vu"); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Init(screen); err != nil {
+		t.Fatal(err)
+	}
+	if e.visualizer.mode != ModeVU || e.visualizer.banner != 0 {
+		t.Fatalf("Expected to start in vu mode without a banner, got %q", e.visualizer.mode)
+	}
+
+	// m 键按顺序循环切换，并提示新的显示方式
+	if !e.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'm', tcell.ModNone)) {
+		t.Fatal("Expected m to be handled")
+	}
+	if e.config.Mode != ModeBars || e.visualizer.mode != ModeBars || e.visualizer.banner <= 0 {
+		t.Errorf("Expected to wrap around to bars with a banner, got %q", e.visualizer.mode)
+	}
+	if e.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)) {
+		t.Error("Expected other keys to be ignored")
+	}
+
+	for range Modes {
+		e.visualizer.Update(1.0 / 30)
+		e.visualizer.Render()
+		e.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'M', tcell.ModNone))
+	}
+}
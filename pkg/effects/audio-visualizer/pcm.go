@@ -0,0 +1,182 @@
+package audiovisualizer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Format 原始 PCM 数据的采样格式
+type Format string
+
+const (
+	FormatS16LE Format = "s16le" // 16 位有符号整数，小端序
+	FormatF32LE Format = "f32le" // 32 位浮点数，小端序
+)
+
+// sampleSize 返回每个采样占用的字节数
+func (f Format) sampleSize() int {
+	if f == FormatF32LE {
+		return 4
+	}
+	return 2
+}
+
+// StreamFormat PCM 数据流的格式
+type StreamFormat struct {
+	Format     Format
+	SampleRate int
+	Channels   int
+}
+
+// Decoder 把交错存储的 PCM 字节流解码为 [-1, 1] 范围的采样
+type Decoder struct {
+	r      *bufio.Reader
+	format StreamFormat
+	buf    []byte
+}
+
+// NewDecoder 创建解码器
+// 数据以 RIFF 文件头开头时按 WAV 文件头确定格式并跳到采样数据，否则按 format 解码原始 PCM
+func NewDecoder(r io.Reader, format StreamFormat) (*Decoder, error) {
+	d := &Decoder{r: bufio.NewReader(r), format: format}
+
+	if magic, err := d.r.Peek(4); err == nil && string(magic) == "RIFF" {
+		if err := d.readWAVHeader(); err != nil {
+			return nil, err
+		}
+	}
+
+	if d.format.Format != FormatS16LE && d.format.Format != FormatF32LE {
+		return nil, fmt.Errorf("不支持的 PCM 采样格式 %q（可选 s16le、f32le）", d.format.Format)
+	}
+	if d.format.SampleRate <= 0 || d.format.Channels <= 0 {
+		return nil, fmt.Errorf("无效的 PCM 格式：采样率 %d，声道数 %d", d.format.SampleRate, d.format.Channels)
+	}
+	return d, nil
+}
+
+// Format 返回数据流的实际格式（WAV 文件为文件头中的格式）
+func (d *Decoder) Format() StreamFormat {
+	return d.format
+}
+
+// readWAVHeader 解析 WAV 文件头，读取 fmt 块中的格式后停在 data 块的采样数据处
+func (d *Decoder) readWAVHeader() error {
+	var riff [12]byte
+	if _, err := io.ReadFull(d.r, riff[:]); err != nil {
+		return fmt.Errorf("读取 WAV 文件头失败: %w", err)
+	}
+	if string(riff[8:12]) != "WAVE" {
+		return errors.New("不是 WAV 文件")
+	}
+
+	hasFormat := false
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(d.r, header[:]); err != nil {
+			return fmt.Errorf("WAV 文件中没有采样数据: %w", err)
+		}
+		id := string(header[:4])
+		size := int64(binary.LittleEndian.Uint32(header[4:]))
+
+		switch id {
+		case "fmt ":
+			chunk := make([]byte, size)
+			if _, err := io.ReadFull(d.r, chunk); err != nil || size < 16 {
+				return errors.New("WAV 文件的 fmt 块不完整")
+			}
+			if err := d.parseWAVFormat(chunk); err != nil {
+				return err
+			}
+			hasFormat = true
+		case "data":
+			if !hasFormat {
+				return errors.New("WAV 文件缺少 fmt 块")
+			}
+			return nil
+		default:
+			if _, err := io.CopyN(io.Discard, d.r, size); err != nil {
+				return fmt.Errorf("读取 WAV 文件失败: %w", err)
+			}
+		}
+
+		// 块的长度为奇数时后面有一个填充字节
+		if size%2 == 1 {
+			if _, err := d.r.Discard(1); err != nil {
+				return fmt.Errorf("读取 WAV 文件失败: %w", err)
+			}
+		}
+	}
+}
+
+// parseWAVFormat 解析 fmt 块，只支持 16 位整数与 32 位浮点 PCM
+func (d *Decoder) parseWAVFormat(chunk []byte) error {
+	code := binary.LittleEndian.Uint16(chunk[0:])
+	channels := int(binary.LittleEndian.Uint16(chunk[2:]))
+	rate := int(binary.LittleEndian.Uint32(chunk[4:]))
+	bits := binary.LittleEndian.Uint16(chunk[14:])
+
+	// WAVE_FORMAT_EXTENSIBLE 的实际格式位于子格式 GUID 的前两个字节
+	if code == 0xFFFE && len(chunk) >= 26 {
+		code = binary.LittleEndian.Uint16(chunk[24:])
+	}
+
+	switch {
+	case code == 1 && bits == 16:
+		d.format.Format = FormatS16LE
+	case code == 3 && bits == 32:
+		d.format.Format = FormatF32LE
+	default:
+		return fmt.Errorf("不支持的 WAV 格式（编码 %d，%d 位），仅支持 16 位整数与 32 位浮点 PCM", code, bits)
+	}
+	d.format.SampleRate = rate
+	d.format.Channels = channels
+	return nil
+}
+
+// ReadFrames 读取最多 len(left) 帧，返回读取的帧数
+// 单声道数据左右声道相同，多于两个声道时只取前两个声道；数据读完时返回 io.EOF
+func (d *Decoder) ReadFrames(left, right []float64) (int, error) {
+	frameSize := d.format.Format.sampleSize() * d.format.Channels
+	if need := len(left) * frameSize; cap(d.buf) < need {
+		d.buf = make([]byte, need)
+	}
+
+	n, err := io.ReadFull(d.r, d.buf[:len(left)*frameSize])
+	frames := n / frameSize
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// 末尾不足一帧的数据直接丢弃
+		err = io.EOF
+	}
+
+	for i := 0; i < frames; i++ {
+		frame := d.buf[i*frameSize:]
+		left[i] = d.sample(frame, 0)
+		right[i] = left[i]
+		if d.format.Channels > 1 {
+			right[i] = d.sample(frame, 1)
+		}
+	}
+	if frames > 0 && err == io.EOF {
+		err = nil
+	}
+	return frames, err
+}
+
+// sample 解码一帧中第 channel 个声道的采样
+func (d *Decoder) sample(frame []byte, channel int) float64 {
+	size := d.format.Format.sampleSize()
+	b := frame[channel*size:]
+	if d.format.Format == FormatF32LE {
+		v := float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		if math.IsNaN(v) {
+			return 0
+		}
+		return max(-1, min(1, v))
+	}
+	return float64(int16(binary.LittleEndian.Uint16(b))) / 32768
+}
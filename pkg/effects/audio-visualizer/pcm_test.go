@@ -0,0 +1,170 @@
+package audiovisualizer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// encodeWAV 把单声道采样编码为 16 位 PCM 的 WAV 数据
+func encodeWAV(samples []float64, rate int) []byte {
+	var data bytes.Buffer
+	for _, s := range samples {
+		binary.Write(&data, binary.LittleEndian, int16(s*32767))
+	}
+
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	binary.Write(&out, binary.LittleEndian, uint32(36+data.Len()))
+	out.WriteString("WAVEfmt ")
+	for _, v := range []any{uint32(16), uint16(1), uint16(1), uint32(rate), uint32(rate * 2), uint16(2), uint16(16)} {
+		binary.Write(&out, binary.LittleEndian, v)
+	}
+	out.WriteString("data")
+	binary.Write(&out, binary.LittleEndian, uint32(data.Len()))
+	out.Write(data.Bytes())
+	return out.Bytes()
+}
+
+// sine 生成振幅为 amplitude 的正弦波
+func sine(frequency, amplitude float64, rate, n int) []float64 {
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = amplitude * math.Sin(2*math.Pi*frequency*float64(i)/float64(rate))
+	}
+	return samples
+}
+
+func TestDecodeRawPCM(t *testing.T) {
+	var raw bytes.Buffer
+	for _, v := range []int16{16384, -16384, 32767, 0} {
+		binary.Write(&raw, binary.LittleEndian, v)
+	}
+	raw.WriteByte(1) // 不足一帧的数据被丢弃
+
+	d, err := NewDecoder(&raw, StreamFormat{Format: FormatS16LE, SampleRate: 8000, Channels: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	left, right := make([]float64, 4), make([]float64, 4)
+	n, err := d.ReadFrames(left, right)
+	if n != 2 || err != nil {
+		t.Fatalf("Expected 2 frames, got %d, %v", n, err)
+	}
+	if left[0] != 0.5 || right[0] != -0.5 || right[1] != 0 {
+		t.Errorf("Unexpected samples %v %v", left[:n], right[:n])
+	}
+	if _, err := d.ReadFrames(left, right); err != io.EOF {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	var floats bytes.Buffer
+	binary.Write(&floats, binary.LittleEndian, []float32{0.25, 2})
+	d, err = NewDecoder(&floats, StreamFormat{Format: FormatF32LE, SampleRate: 8000, Channels: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, _ = d.ReadFrames(left, right)
+	if n != 2 || left[0] != 0.25 || right[0] != 0.25 || left[1] != 1 {
+		t.Errorf("Expected mono float samples to be clamped and duplicated, got %v %v", left[:n], right[:n])
+	}
+
+	if _, err := NewDecoder(&raw, StreamFormat{Format: "u8", SampleRate: 8000, Channels: 1}); err == nil {
+		t.Error("Expected unknown sample format to be rejected")
+	}
+}
+
+func TestDecodeWAV(t *testing.T) {
+	// WAV 文件头中的格式优先于指定的格式
+	d, err := NewDecoder(bytes.NewReader(encodeWAV([]float64{0.5, -0.5}, 22050)),
+		StreamFormat{Format: FormatF32LE, SampleRate: 44100, Channels: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := d.Format(), (StreamFormat{Format: FormatS16LE, SampleRate: 22050, Channels: 1}); got != want {
+		t.Errorf("Expected format %v, got %v", want, got)
+	}
+
+	left, right := make([]float64, 4), make([]float64, 4)
+	n, _ := d.ReadFrames(left, right)
+	if n != 2 || math.Abs(left[0]-0.5) > 1e-3 || math.Abs(left[1]+0.5) > 1e-3 {
+		t.Errorf("Unexpected samples %v", left[:n])
+	}
+}
+
+func TestBands(t *testing.T) {
+	const rate = 44100
+	levels := make([]float64, 20)
+
+	a := newAnalyzer()
+	a.bands(rate, levels)
+	for i, level := range levels {
+		if level != 0 {
+			t.Fatalf("Expected silence to give empty bands, band %d is %v", i, level)
+		}
+	}
+
+	// 1kHz 满刻度正弦波落在对应的频段上，电平接近满格
+	tone := sine(1000, 1, rate, fftSize)
+	a.push(tone, tone)
+	a.bands(rate, levels)
+
+	loudest := 0
+	for i, level := range levels {
+		if level > levels[loudest] {
+			loudest = i
+		}
+	}
+	lo := minFrequency * math.Pow(maxFrequency/minFrequency, float64(loudest)/20)
+	hi := minFrequency * math.Pow(maxFrequency/minFrequency, float64(loudest+1)/20)
+	if lo > 1000 || hi < 1000 {
+		t.Errorf("Expected the loudest band to contain 1kHz, got %.0f-%.0fHz", lo, hi)
+	}
+	if levels[loudest] < 0.95 {
+		t.Errorf("Expected a full-scale tone to fill the band, got %v", levels[loudest])
+	}
+	if levels[0] > 0.5 {
+		t.Errorf("Expected the lowest band to stay low, got %v", levels[0])
+	}
+}
+
+func TestSourcePacing(t *testing.T) {
+	const rate = 8000
+	path := filepath.Join(t.TempDir(), "tone.wav")
+	if err := os.WriteFile(path, encodeWAV(sine(440, 0.5, rate, rate), rate), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := openSource(path, StreamFormat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// 等待后台读取填满缓冲区
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		s.mu.Lock()
+		buffered := len(s.left)
+		s.mu.Unlock()
+		if buffered >= rate/2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// 按模拟时间取出采样，而不是一次读完整个文件
+	left, _, got, ended := s.take(0.1)
+	if got != rate || len(left) != rate/10 || ended {
+		t.Fatalf("Expected %d frames at %dHz, got %d at %dHz (ended %v)", rate/10, rate, len(left), got, ended)
+	}
+
+	if _, err := openSource(filepath.Join(t.TempDir(), "missing.wav"), StreamFormat{}); err == nil {
+		t.Error("Expected missing input file to be rejected")
+	}
+}
@@ -0,0 +1,200 @@
+package audiovisualizer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// readChunk 后台每次读取的帧数
+const readChunk = 1024
+
+// source PCM 音频输入
+// 后台协程读取并解码数据，特效按模拟时间取出采样：输入比播放快时（文件、不带 -re 的 ffmpeg）
+// 缓冲区满后暂停读取，实时输入则有多少取多少，因此暂停和调速同样作用于音频
+type source struct {
+	mu      sync.Mutex
+	cond    *sync.Cond   // 缓冲区的数据或状态变化时广播
+	format  StreamFormat // 解析文件头后有效
+	ready   bool
+	limit   int       // 缓冲区的上限（帧）
+	left    []float64 // 已读取、尚未播放的采样
+	right   []float64
+	pending float64 // 尚未取出的不足一帧的播放进度
+	err     error   // 读取结束的原因，io.EOF 表示输入正常结束
+	closed  bool
+	closer  io.Closer
+
+	// file 输入为普通文件：读取不会无限期阻塞，取出采样时等待数据读入，
+	// 模拟时钟下（无头渲染、导出）的结果因此是确定的
+	file bool
+}
+
+// openSource 打开 PCM 音频输入，path 为 "-" 时读取标准输入
+// 普通文件立即打开并解析文件头；命名管道在写入端打开之前、标准输入在数据到达之前都会阻塞，
+// 不能让特效的初始化等待它们，因此在后台打开
+func openSource(path string, format StreamFormat) (*source, error) {
+	s := &source{}
+	s.cond = sync.NewCond(&s.mu)
+
+	if path == "-" {
+		go s.open(os.Stdin, nil, format)
+		return s, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开 PCM 音频输入失败: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		go func() {
+			f, err := os.Open(path)
+			if err != nil {
+				s.finish(fmt.Errorf("打开 PCM 音频输入失败: %w", err))
+				return
+			}
+			s.open(f, f, format)
+		}()
+		return s, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开 PCM 音频输入失败: %w", err)
+	}
+	decoder, err := NewDecoder(f, format)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	s.file = true
+	s.closer = f
+	s.start(decoder)
+	go s.read(decoder)
+	return s, nil
+}
+
+// open 解析输入的文件头后开始读取（在后台协程中调用）
+// closer 为 nil 时 Close 不关闭输入（标准输入）
+func (s *source) open(r io.Reader, closer io.Closer, format StreamFormat) {
+	s.mu.Lock()
+	s.closer = closer
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		if closer != nil {
+			closer.Close()
+		}
+		return
+	}
+
+	decoder, err := NewDecoder(r, format)
+	if err != nil {
+		s.finish(err)
+		return
+	}
+	s.start(decoder)
+	s.read(decoder)
+}
+
+// start 记录解码器确定的数据格式，此后可以取出采样
+func (s *source) start(decoder *Decoder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.format = decoder.Format()
+	s.limit = max(s.format.SampleRate/2, readChunk) // 最多缓冲半秒
+	s.ready = true
+	s.cond.Broadcast()
+}
+
+// read 读取数据直到输入结束或 source 关闭
+func (s *source) read(decoder *Decoder) {
+	left := make([]float64, readChunk)
+	right := make([]float64, readChunk)
+	for {
+		n, err := decoder.ReadFrames(left, right)
+
+		s.mu.Lock()
+		for len(s.left) >= s.limit && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		s.left = append(s.left, left[:n]...)
+		s.right = append(s.right, right[:n]...)
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		if err != nil {
+			s.finish(err)
+			return
+		}
+	}
+}
+
+// finish 记录读取结束的原因
+func (s *source) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+	s.cond.Broadcast()
+}
+
+// take 取出 deltaTime 秒播放的采样，同时返回采样率
+// 实时输入的数据不足时只返回已有的部分；ended 表示输入已结束且缓冲区中的数据已全部取出
+func (s *source) take(deltaTime float64) (left, right []float64, rate int, ended bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.ready {
+		return nil, nil, 0, s.err != nil
+	}
+
+	s.pending += deltaTime * float64(s.format.SampleRate)
+	want := int(s.pending)
+	s.pending -= float64(want)
+
+	if s.file {
+		// 缓冲区满时读取方也在等待，只能取出已有的数据
+		for len(s.left) < want && len(s.left) < s.limit && s.err == nil && !s.closed {
+			s.cond.Wait()
+		}
+	}
+
+	n := min(want, len(s.left))
+	left = append([]float64(nil), s.left[:n]...)
+	right = append([]float64(nil), s.right[:n]...)
+	s.left = s.left[n:]
+	s.right = s.right[n:]
+	s.cond.Broadcast()
+
+	return left, right, s.format.SampleRate, s.err != nil && len(s.left) == 0
+}
+
+// failure 返回导致输入中断的错误（输入正常结束时返回 nil）
+func (s *source) failure() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+// Close 停止后台读取并关闭输入（标准输入不关闭）
+func (s *source) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	closer := s.closer
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	if closer != nil {
+		return closer.Close()
+	}
+	return nil
+}
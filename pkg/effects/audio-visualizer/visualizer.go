@@ -1,28 +1,48 @@
 package audiovisualizer
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 type Config struct {
 	BarCount int
 	FPS      int
+	Seed     int64
+	Mode     Mode // 显示方式，运行中可以切换
+
+	// PCM 音频输入："-" 表示标准输入，也可以是命名管道或 WAV 文件的路径；为空时使用模拟数据
+	// 输入在特效启动时打开，运行中修改不生效
+	PCM        string
+	Format     Format  // 原始 PCM 的采样格式（WAV 文件按文件头）
+	SampleRate int     // 原始 PCM 的采样率（Hz）
+	Channels   int     // 原始 PCM 的声道数
+	Attack     float64 // 柱子上升的时间常数（秒）
+	Decay      float64 // 柱子回落的时间常数（秒）
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		BarCount: 40,
-		FPS:      30,
+		BarCount:   40,
+		FPS:        30,
+		Mode:       ModeBars,
+		Format:     FormatS16LE,
+		SampleRate: 44100,
+		Channels:   2,
+		Attack:     0.02,
+		Decay:      0.25,
 	}
 }
 
 type AudioVisualizer struct {
 	screen   tcell.Screen
 	config   *Config
+	clock    *effects.Clock
 	barHeights []float64
 	targetHeights []float64
 	width    int
@@ -30,6 +50,15 @@ type AudioVisualizer struct {
 	time     float64
 	rand     *rand.Rand
 	chars    []rune
+	source   *source   // PCM 音频输入，为 nil 时使用模拟数据
+	analyzer *analyzer
+	levels   []float64 // 当前窗口各频段的电平
+	mode     Mode      // 正在显示的方式，配置变化后由 Reconfigure 切换
+	banner   float64   // 切换显示方式的提示剩余的时间（秒）
+	vuTarget [2]float64 // 本帧左右声道的电平
+	meters   [2]meter
+	history  [][]float64 // 频谱瀑布的历史，每帧一列，最新的在末尾
+	scope    *canvas.Canvas // 示波器的盲文点阵
 }
 
 func New(screen tcell.Screen, config *Config) *AudioVisualizer {
@@ -40,7 +69,8 @@ func New(screen tcell.Screen, config *Config) *AudioVisualizer {
 	return &AudioVisualizer{
 		screen: screen,
 		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:  effects.NewClock(),
+		rand:   effects.NewRand(config.Seed),
 		chars:  []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'},
 	}
 }
@@ -50,18 +80,41 @@ func (a *AudioVisualizer) Init() error {
 	a.barHeights = make([]float64, a.config.BarCount)
 	a.targetHeights = make([]float64, a.config.BarCount)
 	a.time = 0
+	a.mode = a.config.Mode
 
 	for i := 0; i < a.config.BarCount; i++ {
 		a.barHeights[i] = 0
 		a.targetHeights[i] = a.rand.Float64()
 	}
 
+	if a.config.PCM != "" {
+		source, err := openSource(a.config.PCM, StreamFormat{
+			Format:     a.config.Format,
+			SampleRate: a.config.SampleRate,
+			Channels:   a.config.Channels,
+		})
+		if err != nil {
+			return err
+		}
+		a.source = source
+		a.analyzer = newAnalyzer()
+	}
+
 	return nil
 }
 
 func (a *AudioVisualizer) Update(deltaTime float64) {
 	a.time += deltaTime
+	if a.source != nil {
+		a.updatePCM(deltaTime)
+	} else {
+		a.updateSimulated(deltaTime)
+	}
+	a.updateModes(deltaTime)
+}
 
+// updateSimulated 没有音频输入时用正弦波和随机值模拟各频段的电平
+func (a *AudioVisualizer) updateSimulated(deltaTime float64) {
 	// 每隔一段时间更新目标高度
 	if int(a.time*10)%5 == 0 {
 		for i := 0; i < a.config.BarCount; i++ {
@@ -79,9 +132,80 @@ func (a *AudioVisualizer) Update(deltaTime float64) {
 	}
 }
 
+// updatePCM 取出本帧播放的采样做频谱分析，柱子按起音与释放时间平滑地跟随各频段的电平
+func (a *AudioVisualizer) updatePCM(deltaTime float64) {
+	left, right, rate, ended := a.source.take(deltaTime)
+	if ended {
+		// 输入结束后以静音填充，柱子逐渐回落
+		left = make([]float64, int(deltaTime*float64(max(rate, 1))))
+		right = left
+	}
+	if len(left) > 0 {
+		a.vuTarget = [2]float64{rmsLevel(left), rmsLevel(right)}
+		a.analyzer.push(left, right)
+		if len(a.levels) != len(a.barHeights) {
+			a.levels = make([]float64, len(a.barHeights))
+		}
+		a.analyzer.bands(rate, a.levels)
+	}
+
+	for i := range a.barHeights {
+		var target float64
+		if i < len(a.levels) {
+			target = a.levels[i]
+		}
+		a.barHeights[i] = smooth(a.barHeights[i], target, deltaTime, a.config.Attack, a.config.Decay)
+	}
+}
+
+func (a *AudioVisualizer) Reconfigure() {
+	a.applyMode()
+
+	// 柱子数量变化时保留已有柱子的高度
+	for len(a.barHeights) < a.config.BarCount {
+		a.barHeights = append(a.barHeights, 0)
+		a.targetHeights = append(a.targetHeights, a.rand.Float64())
+	}
+	a.barHeights = a.barHeights[:a.config.BarCount]
+	a.targetHeights = a.targetHeights[:a.config.BarCount]
+}
+
+// Resize 按新尺寸绘制，柱子高度按屏幕高度的比例保存，无需调整
+func (a *AudioVisualizer) Resize(width, height int) {
+	a.width, a.height = width, height
+	if a.scope != nil {
+		a.scope.Resize(width, height)
+	}
+}
+
 func (a *AudioVisualizer) Render() {
 	a.screen.Clear()
 
+	switch a.mode {
+	case ModeMirror:
+		a.renderMirror()
+	case ModeScope:
+		a.renderScope()
+	case ModeSpectrogram:
+		a.renderSpectrogram()
+	case ModeVU:
+		a.renderVU()
+	default:
+		a.renderBars()
+	}
+	a.renderBanner()
+
+	if a.source != nil {
+		if err := a.source.failure(); err != nil {
+			a.screen.PutStrStyled(0, 0, fmt.Sprintf(" PCM: %v ", err), tcell.StyleDefault.Foreground(tcell.ColorRed))
+		}
+	}
+
+	a.screen.Show()
+}
+
+// renderBars 频谱柱从底部向上伸展
+func (a *AudioVisualizer) renderBars() {
 	barWidth := a.width / a.config.BarCount
 	if barWidth < 1 {
 		barWidth = 1
@@ -93,8 +217,6 @@ func (a *AudioVisualizer) Render() {
 
 		a.drawBar(x, barWidth, barHeight)
 	}
-
-	a.screen.Show()
 }
 
 func (a *AudioVisualizer) drawBar(x, width, height int) {
@@ -126,27 +248,22 @@ func (a *AudioVisualizer) drawBar(x, width, height int) {
 	}
 }
 
-func (a *AudioVisualizer) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(a.config.FPS))
-	defer ticker.Stop()
-
-	lastUpdate := time.Now()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(lastUpdate).Seconds()
-			lastUpdate = now
-
-			a.Update(deltaTime)
-			a.Render()
-		}
+func (a *AudioVisualizer) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		a.clock = clock
 	}
 }
 
+func (a *AudioVisualizer) Run(quit <-chan struct{}) error {
+	return a.clock.RunVar(&a.config.FPS, quit, func(deltaTime float64) {
+		a.Update(deltaTime)
+		a.Render()
+	})
+}
+
 func (a *AudioVisualizer) Cleanup() error {
+	if a.source != nil {
+		return a.source.Close()
+	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 type Config struct {
@@ -21,6 +22,7 @@ func DefaultConfig() *Config {
 type BigClock struct {
 	screen tcell.Screen
 	config *Config
+	clock  *effects.Clock
 	width  int
 	height int
 	digits map[rune][]string
@@ -34,6 +36,7 @@ func New(screen tcell.Screen, config *Config) *BigClock {
 	return &BigClock{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		digits: initDigits(),
 	}
 }
@@ -125,6 +128,11 @@ func (b *BigClock) Init() error {
 	return nil
 }
 
+// Resize 按新尺寸居中显示
+func (b *BigClock) Resize(width, height int) {
+	b.width, b.height = width, height
+}
+
 func (b *BigClock) Render() {
 	b.screen.Clear()
 
@@ -176,20 +184,18 @@ func (b *BigClock) renderDigit(x, y int, lines []string) {
 	}
 }
 
-func (b *BigClock) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(b.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			b.Render()
-		}
+func (b *BigClock) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		b.clock = clock
 	}
 }
 
+func (b *BigClock) Run(quit <-chan struct{}) error {
+	return b.clock.RunVar(&b.config.FPS, quit, func(float64) {
+		b.Render()
+	})
+}
+
 func (b *BigClock) Cleanup() error {
 	return nil
 }
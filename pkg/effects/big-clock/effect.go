@@ -6,8 +6,9 @@ import (
 )
 
 type BigClockEffect struct {
-	clock  *BigClock
-	config *Config
+	clock      *BigClock
+	config     *Config
+	frameClock *effects.Clock
 }
 
 func NewEffect() effects.Effect {
@@ -44,8 +45,27 @@ func (e *BigClockEffect) Metadata() effects.Metadata {
 	}
 }
 
+func (e *BigClockEffect) SetClock(clock *effects.Clock) {
+	e.frameClock = clock
+}
+
+func (e *BigClockEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Color(effects.Param{Name: "color", Label: "颜色", LabelEN: "Color"}, &e.config.Color)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *BigClockEffect) Resize(width, height int) {
+	if e.clock != nil {
+		e.clock.Resize(width, height)
+	}
+}
+
 func (e *BigClockEffect) Init(screen tcell.Screen) error {
 	e.clock = New(screen, e.config)
+	e.clock.SetClock(e.frameClock)
 	return e.clock.Init()
 }
 
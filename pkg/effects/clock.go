@@ -0,0 +1,386 @@
+package effects
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultFPS 未指定帧率时使用的默认帧率
+const DefaultFPS = 30
+
+// 速度倍率范围
+const (
+	MinSpeed = 1.0 / 16
+	MaxSpeed = 16.0
+)
+
+// ClockMode 时钟模式
+type ClockMode int
+
+const (
+	ClockRealtime  ClockMode = iota // 实时：帧步长取真实的帧间隔
+	ClockFixed                      // 固定步长：每帧步长固定为 1/FPS，按真实时间节奏出帧
+	ClockSimulated                  // 模拟：固定步长且不等待真实时间，尽快出帧（用于无头渲染）
+)
+
+// Clock 模拟时钟
+// 由宿主创建并注入特效，统一驱动特效的帧循环。
+// 支持实时/固定步长、暂停、单步与速度倍率，特效无需关心这些细节。
+// 多个特效可以共享同一个时钟，此时它们的帧会串行执行；
+// 模拟时钟下各帧循环按模拟时间轮流出帧，结果与调度顺序无关。
+type Clock struct {
+	*clockState
+	rank int // 模拟时钟下推进时间相同的帧循环的出帧顺序，越小越先（见 Lane）
+}
+
+// clockState 同一时钟的各个 Lane 共享的状态
+type clockState struct {
+	mu      sync.Mutex
+	frameMu sync.Mutex // 帧执行互斥锁，保证 Do 与帧回调互斥
+	mode    ClockMode
+	speed   float64
+	paused  bool
+	steps   int     // 单步计数，每个帧循环各自消费
+	elapsed float64 // 已推进的模拟时间（秒，多个帧循环时取推进最多的循环）
+
+	turn    *sync.Cond // 模拟时钟下通知帧循环轮到自己出帧
+	loops   []*loop    // 正在运行的帧循环（按加入顺序）
+	waitFor int        // 模拟时钟下还需等待加入的帧循环数量
+	busy    bool       // 模拟时钟下是否有帧循环已推进时间但还未执行完帧回调
+}
+
+// loop 正在运行的帧循环
+type loop struct {
+	local *float64 // 已推进的时间
+	rank  int      // 所属 Lane 的出帧顺序
+}
+
+// Clocked 可选接口：支持由宿主注入时钟的特效
+type Clocked interface {
+	// SetClock 设置驱动帧循环的时钟，须在 Init 之前调用
+	SetClock(clock *Clock)
+}
+
+// NewClock 创建实时时钟
+func NewClock() *Clock {
+	return NewClockWithMode(ClockRealtime)
+}
+
+// NewClockWithMode 创建指定模式的时钟
+func NewClockWithMode(mode ClockMode) *Clock {
+	c := &Clock{clockState: &clockState{
+		mode:  mode,
+		speed: 1.0,
+	}}
+	c.turn = sync.NewCond(&c.mu)
+	return c
+}
+
+// Lane 返回共享此时钟的另一个句柄，通过它运行的帧循环在推进时间相同时按 rank 从小到大出帧
+// 同时启动多个帧循环的宿主（如组合场景）为每个帧循环分配固定的 rank，出帧顺序因此与加入时钟的先后无关
+func (c *Clock) Lane(rank int) *Clock {
+	return &Clock{clockState: c.clockState, rank: rank}
+}
+
+// ApplyClock 向特效注入时钟
+// 特效未实现 Clocked 或 clock 为 nil 时不做任何处理，返回是否已注入
+func ApplyClock(effect Effect, clock *Clock) bool {
+	if clock == nil {
+		return false
+	}
+
+	clocked, ok := effect.(Clocked)
+	if !ok {
+		return false
+	}
+
+	clocked.SetClock(clock)
+	return true
+}
+
+// Mode 返回时钟模式
+func (c *Clock) Mode() ClockMode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mode
+}
+
+// Speed 返回速度倍率
+func (c *Clock) Speed() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.speed
+}
+
+// SetSpeed 设置速度倍率（限制在 MinSpeed 到 MaxSpeed 之间）
+func (c *Clock) SetSpeed(speed float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.speed = math.Max(MinSpeed, math.Min(MaxSpeed, speed))
+}
+
+// Paused 判断是否已暂停
+func (c *Clock) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// Pause 暂停时钟
+func (c *Clock) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+}
+
+// Resume 恢复时钟
+func (c *Clock) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = false
+}
+
+// TogglePause 切换暂停状态，返回切换后是否处于暂停
+func (c *Clock) TogglePause() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = !c.paused
+	return c.paused
+}
+
+// Step 在暂停状态下前进一帧（步长为 1/FPS，不受速度倍率影响）
+// 未暂停时调用会先暂停时钟
+func (c *Clock) Step() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+	c.steps++
+}
+
+// Elapsed 返回时钟已推进的模拟时间（秒，已计入速度倍率）
+// 多个帧循环共享时钟时（如组合场景中的各图层）取推进最多的循环的时间
+func (c *Clock) Elapsed() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.elapsed
+}
+
+// Loops 返回正在运行的帧循环数量
+func (c *Clock) Loops() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.loops)
+}
+
+// Expect 模拟时钟下，在又有 n 个帧循环加入之前暂不出帧
+// 同时启动多个帧循环的宿主（如组合场景）借此避免率先启动的循环独自跑完；n 为 0 时取消等待
+func (c *Clock) Expect(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waitFor = max(n, 0)
+	c.turn.Broadcast()
+}
+
+// Do 在两帧之间执行 fn，与所有共享此时钟的帧回调互斥
+// 宿主可以借此安全地修改正在运行的特效的状态
+func (c *Clock) Do(fn func()) {
+	c.frameMu.Lock()
+	defer c.frameMu.Unlock()
+	fn()
+}
+
+// Run 以指定帧率运行帧循环，直到 quit 关闭
+// 每帧调用 frame，参数为本帧的模拟时间步长（秒）
+func (c *Clock) Run(fps int, quit <-chan struct{}, frame func(deltaTime float64)) error {
+	return c.RunVar(&fps, quit, frame)
+}
+
+// RunVar 与 Run 相同，但帧率从 *fps 读取，并在每帧结束后重新检查
+// 宿主通过 Do 修改 *fps 后，帧循环从下一帧起按新帧率运行
+func (c *Clock) RunVar(fps *int, quit <-chan struct{}, frame func(deltaTime float64)) error {
+	var rate int
+	c.Do(func() { rate = *fps })
+	if rate <= 0 {
+		rate = DefaultFPS
+	}
+
+	step := 1.0 / float64(rate)
+	interval := time.Second / time.Duration(rate)
+
+	// retime 在帧率变化时重新计算步长与帧间隔，返回帧率是否变化
+	retime := func(next int) bool {
+		if next <= 0 {
+			next = DefaultFPS
+		}
+		if next == rate {
+			return false
+		}
+		rate = next
+		step = 1.0 / float64(rate)
+		interval = time.Second / time.Duration(rate)
+		return true
+	}
+
+	// 本循环已消费的单步计数与已推进的时间
+	c.mu.Lock()
+	seen := c.steps
+	local := c.elapsed
+	self := &loop{local: &local, rank: c.rank}
+	c.join(self)
+	c.mu.Unlock()
+	defer c.leave(self)
+
+	if c.Mode() == ClockSimulated {
+		for {
+			select {
+			case <-quit:
+				return nil
+			default:
+			}
+
+			if deltaTime, ok := c.advance(step, step, &seen, &local); ok {
+				retime(c.exec(frame, deltaTime, fps))
+			} else {
+				time.Sleep(interval)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastTick := time.Now()
+
+	for {
+		select {
+		case <-quit:
+			return nil
+		case now := <-ticker.C:
+			wall := now.Sub(lastTick).Seconds()
+			lastTick = now
+
+			if deltaTime, ok := c.advance(wall, step, &seen, &local); ok {
+				if retime(c.exec(frame, deltaTime, fps)) {
+					ticker.Reset(interval)
+				}
+			}
+		}
+	}
+}
+
+// advance 计算本帧的步长，返回 false 表示本帧应跳过（已暂停）
+// local 为本循环已推进的时间
+func (c *Clock) advance(wall, step float64, seen *int, local *float64) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.mode == ClockSimulated && !c.isTurn(local) {
+		c.turn.Wait()
+	}
+	defer c.turn.Broadcast()
+
+	// 模拟时钟下本帧执行完之前其他帧循环不能推进，帧回调的执行顺序因此与出帧顺序一致
+	c.busy = c.mode == ClockSimulated
+
+	deltaTime := step
+	if c.paused {
+		if *seen >= c.steps {
+			c.busy = false
+			return 0, false
+		}
+		*seen++
+	} else {
+		*seen = c.steps
+		if c.mode == ClockRealtime {
+			deltaTime = wall
+		}
+		deltaTime *= c.speed
+	}
+
+	*local += deltaTime
+	c.elapsed = max(c.elapsed, *local)
+	return deltaTime, true
+}
+
+// join 登记帧循环（调用方须持有 mu）
+func (c *Clock) join(l *loop) {
+	c.loops = append(c.loops, l)
+	if c.waitFor > 0 {
+		c.waitFor--
+	}
+	c.turn.Broadcast()
+}
+
+// leave 注销帧循环
+func (c *Clock) leave(self *loop) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loops = slices.DeleteFunc(c.loops, func(l *loop) bool { return l == self })
+	c.turn.Broadcast()
+}
+
+// isTurn 判断是否轮到该帧循环出帧：推进时间最少的循环优先，相同时 rank 小的优先，
+// 再相同时先加入的优先（调用方须持有 mu）
+func (c *Clock) isTurn(local *float64) bool {
+	if c.waitFor > 0 || c.busy {
+		return false
+	}
+
+	before := true
+	for _, l := range c.loops {
+		if l.local == local {
+			before = false
+			continue
+		}
+		if *l.local < *local {
+			return false
+		}
+		if *l.local == *local && (l.rank < c.rank || (l.rank == c.rank && before)) {
+			return false
+		}
+	}
+	return true
+}
+
+// exec 在帧互斥锁内执行帧回调，并返回帧结束时的帧率
+func (c *Clock) exec(frame func(deltaTime float64), deltaTime float64, fps *int) int {
+	c.frameMu.Lock()
+	defer c.frameMu.Unlock()
+	defer c.finish()
+	frame(deltaTime)
+	return *fps
+}
+
+// finish 帧回调执行完毕，允许其他帧循环推进
+func (c *Clock) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.busy = false
+	c.turn.Broadcast()
+}
+
+// Stepper 把连续的时间步长换算为离散的步数
+// 适用于按“代”推进的特效（如生命游戏），使其同样遵循速度倍率与单步
+type Stepper struct {
+	Rate float64 // 每秒步数
+	acc  float64 // 累计的步数（带小数）
+}
+
+// Steps 累加时间步长并返回本帧应推进的步数
+// 采用四舍五入进位，真实帧间隔的抖动不会造成跳帧
+func (s *Stepper) Steps(deltaTime float64) int {
+	if s.Rate <= 0 {
+		return 0
+	}
+
+	s.acc += deltaTime * s.Rate
+	n := int(math.Floor(s.acc + 0.5))
+	if n < 0 {
+		n = 0
+	}
+	s.acc -= float64(n)
+
+	return n
+}
@@ -0,0 +1,147 @@
+package effects
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLaneOrder(t *testing.T) {
+	clock := NewClockWithMode(ClockSimulated)
+	clock.Expect(2)
+
+	var mu sync.Mutex
+	var order []int
+	quit := make(chan struct{})
+	var once sync.Once
+
+	var wg sync.WaitGroup
+	run := func(rank int) {
+		defer wg.Done()
+		clock.Lane(rank).Run(10, quit, func(float64) {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, rank)
+			if len(order) == 6 {
+				once.Do(func() { close(quit) })
+			}
+		})
+	}
+
+	// rank 大的帧循环先加入，出帧顺序仍按 rank
+	wg.Add(2)
+	go run(1)
+	for clock.Loops() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	go run(0)
+	wg.Wait()
+
+	if want := []int{0, 1, 0, 1, 0, 1}; !slices.Equal(order[:6], want) {
+		t.Errorf("Expected frames in rank order %v, got %v", want, order)
+	}
+}
+
+func TestClockAdvance(t *testing.T) {
+	const wall, step = 0.05, 0.1
+
+	for _, tc := range []struct {
+		name   string
+		mode   ClockMode
+		speed  float64
+		pause  bool
+		steps  int
+		frames int
+		want   []float64 // 每帧的步长，0 表示跳过
+	}{
+		{"realtime", ClockRealtime, 1, false, 0, 2, []float64{0.05, 0.05}},
+		{"realtime scaled", ClockRealtime, 2, false, 0, 1, []float64{0.1}},
+		{"fixed", ClockFixed, 1, false, 0, 2, []float64{0.1, 0.1}},
+		{"fixed scaled", ClockFixed, 0.5, false, 0, 1, []float64{0.05}},
+		{"simulated scaled", ClockSimulated, 4, false, 0, 1, []float64{0.4}},
+		{"paused", ClockRealtime, 1, true, 0, 2, []float64{0, 0}},
+		{"step ignores speed", ClockRealtime, 4, true, 1, 2, []float64{0.1, 0}},
+		{"steps queue up", ClockFixed, 1, true, 2, 3, []float64{0.1, 0.1, 0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := NewClockWithMode(tc.mode)
+			clock.SetSpeed(tc.speed)
+			seen, local := 0, 0.0
+			if tc.pause {
+				clock.Pause()
+			}
+			for range tc.steps {
+				clock.Step()
+			}
+
+			var got []float64
+			for range tc.frames {
+				deltaTime, ok := clock.advance(wall, step, &seen, &local)
+				if ok {
+					clock.finish()
+				}
+				got = append(got, deltaTime)
+			}
+
+			if !slices.EqualFunc(got, tc.want, func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }) {
+				t.Errorf("Expected steps %v, got %v", tc.want, got)
+			}
+			if math.Abs(clock.Elapsed()-local) > 1e-9 {
+				t.Errorf("Expected elapsed %v, got %v", local, clock.Elapsed())
+			}
+		})
+	}
+}
+
+func TestClockSpeedAndPause(t *testing.T) {
+	clock := NewClock()
+	for _, tc := range []struct {
+		set, want float64
+	}{
+		{2, 2},
+		{100, MaxSpeed},
+		{0, MinSpeed},
+		{-1, MinSpeed},
+	} {
+		clock.SetSpeed(tc.set)
+		if got := clock.Speed(); got != tc.want {
+			t.Errorf("SetSpeed(%v): expected %v, got %v", tc.set, tc.want, got)
+		}
+	}
+
+	if !clock.TogglePause() || !clock.Paused() {
+		t.Error("TogglePause should pause a running clock")
+	}
+	clock.Resume()
+	clock.Step()
+	if !clock.Paused() {
+		t.Error("Step should pause the clock")
+	}
+}
+
+func TestStepper(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		rate   float64
+		deltas []float64
+		want   []int
+	}{
+		{"one per frame", 10, []float64{0.1, 0.1, 0.1}, []int{1, 1, 1}},
+		{"accumulates", 10, []float64{0.04, 0.04, 0.04, 0.04}, []int{0, 1, 0, 1}},
+		{"several per frame", 10, []float64{0.5}, []int{5}},
+		{"stopped", 0, []float64{1}, []int{0}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Stepper{Rate: tc.rate}
+			var got []int
+			for _, deltaTime := range tc.deltas {
+				got = append(got, s.Steps(deltaTime))
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 type DigitalWaterfallEffect struct {
 	waterfall *DigitalWaterfall
 	config    *Config
+	clock     *effects.Clock
 }
 
 // NewEffect 创建数字瀑布特效实例
@@ -49,9 +50,38 @@ func (e *DigitalWaterfallEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *DigitalWaterfallEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *DigitalWaterfallEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *DigitalWaterfallEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "min-speed", Label: "最小速度", LabelEN: "Min speed", Min: 0.1, Max: 10, Step: 0.1}, &e.config.MinSpeed)
+	ps.Float(effects.Param{Name: "max-speed", Label: "最大速度", LabelEN: "Max speed", Min: 0.1, Max: 10, Step: 0.1}, &e.config.MaxSpeed)
+	ps.Int(effects.Param{Name: "min-length", Label: "最小长度", LabelEN: "Min length", Min: 1, Max: 50}, &e.config.MinLength)
+	ps.Int(effects.Param{Name: "max-length", Label: "最大长度", LabelEN: "Max length", Min: 1, Max: 50}, &e.config.MaxLength)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *DigitalWaterfallEffect) Resize(width, height int) {
+	if e.waterfall != nil {
+		e.waterfall.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *DigitalWaterfallEffect) Init(screen tcell.Screen) error {
 	e.waterfall = New(screen, e.config)
+	e.waterfall.SetClock(e.clock)
 	return e.waterfall.Init()
 }
 
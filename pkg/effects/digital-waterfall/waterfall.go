@@ -2,9 +2,9 @@ package digitalwaterfall
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 数字瀑布配置
@@ -14,6 +14,7 @@ type Config struct {
 	MinLength int     // 最小流长度
 	MaxLength int     // 最大流长度
 	FPS       int     // 帧率
+	Seed      int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -41,6 +42,7 @@ type Column struct {
 type DigitalWaterfall struct {
 	screen  tcell.Screen
 	config  *Config
+	clock   *effects.Clock
 	columns []*Column
 	width   int
 	height  int
@@ -56,7 +58,8 @@ func New(screen tcell.Screen, config *Config) *DigitalWaterfall {
 	return &DigitalWaterfall{
 		screen: screen,
 		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:  effects.NewClock(),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -78,9 +81,27 @@ func (d *DigitalWaterfall) initColumns() {
 	}
 }
 
+// Resize 按新宽度增减列，原有的列继续流动
+func (d *DigitalWaterfall) Resize(width, height int) {
+	d.width, d.height = width, height
+	if len(d.columns) > width {
+		d.columns = d.columns[:width]
+	}
+	for x := len(d.columns); x < width; x++ {
+		column := d.createColumn(x)
+		column.y = -float64(d.rand.Intn(max(height, 1)))
+		d.columns = append(d.columns, column)
+	}
+}
+
 // createColumn 创建新的数字流列
 func (d *DigitalWaterfall) createColumn(x int) *Column {
-	length := d.config.MinLength + d.rand.Intn(d.config.MaxLength-d.config.MinLength+1)
+	// 最大长度小于最小长度时使用最小长度
+	span := d.config.MaxLength - d.config.MinLength
+	if span < 0 {
+		span = 0
+	}
+	length := d.config.MinLength + d.rand.Intn(span+1)
 	speed := d.config.MinSpeed + d.rand.Float64()*(d.config.MaxSpeed-d.config.MinSpeed)
 
 	column := &Column{
@@ -105,10 +126,13 @@ func (d *DigitalWaterfall) randomDigit() rune {
 }
 
 // Update 更新数字瀑布状态
-func (d *DigitalWaterfall) Update() {
+func (d *DigitalWaterfall) Update(deltaTime float64) {
+	// 流动速度以“格/帧”计，按配置帧率换算为本帧位移
+	frames := deltaTime * float64(d.config.FPS)
+
 	for _, column := range d.columns {
 		// 移动列
-		column.y += column.speed
+		column.y += column.speed * frames
 
 		// 随机改变头部数字（制造变化效果）
 		if d.rand.Float64() < 0.3 {
@@ -176,20 +200,19 @@ func (d *DigitalWaterfall) getDigitStyle(brightness float64, isHead bool) tcell.
 	return style
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (d *DigitalWaterfall) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		d.clock = clock
+	}
+}
+
 // Run 运行数字瀑布特效
 func (d *DigitalWaterfall) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(d.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			d.Update()
-			d.Render()
-		}
-	}
+	return d.clock.RunVar(&d.config.FPS, quit, func(deltaTime float64) {
+		d.Update(deltaTime)
+		d.Render()
+	})
 }
 
 // Cleanup 清理资源
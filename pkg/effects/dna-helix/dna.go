@@ -2,9 +2,9 @@ package dnahelix
 
 import (
 	"math"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config DNA双螺旋配置
@@ -27,12 +27,12 @@ func DefaultConfig() *Config {
 
 // DNAHelix DNA双螺旋特效
 type DNAHelix struct {
-	screen     tcell.Screen
-	config     *Config
-	angle      float64
-	width      int
-	height     int
-	lastUpdate time.Time
+	screen tcell.Screen
+	config *Config
+	clock  *effects.Clock
+	angle  float64
+	width  int
+	height int
 }
 
 // New 创建DNA双螺旋特效实例
@@ -44,6 +44,7 @@ func New(screen tcell.Screen, config *Config) *DNAHelix {
 	return &DNAHelix{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 	}
 }
 
@@ -51,7 +52,6 @@ func New(screen tcell.Screen, config *Config) *DNAHelix {
 func (d *DNAHelix) Init() error {
 	d.width, d.height = d.screen.Size()
 	d.angle = 0
-	d.lastUpdate = time.Now()
 	return nil
 }
 
@@ -64,6 +64,11 @@ func (d *DNAHelix) Update(deltaTime float64) {
 	}
 }
 
+// Resize 调整尺寸，螺旋始终位于屏幕中央
+func (d *DNAHelix) Resize(width, height int) {
+	d.width, d.height = width, height
+}
+
 // Render 渲染DNA双螺旋
 func (d *DNAHelix) Render() {
 	d.screen.Clear()
@@ -133,24 +138,19 @@ func (d *DNAHelix) Render() {
 	d.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (d *DNAHelix) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		d.clock = clock
+	}
+}
+
 // Run 运行DNA双螺旋特效
 func (d *DNAHelix) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(d.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(d.lastUpdate).Seconds()
-			d.lastUpdate = now
-
-			d.Update(deltaTime)
-			d.Render()
-		}
-	}
+	return d.clock.RunVar(&d.config.FPS, quit, func(deltaTime float64) {
+		d.Update(deltaTime)
+		d.Render()
+	})
 }
 
 // Cleanup 清理资源
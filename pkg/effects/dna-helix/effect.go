@@ -9,6 +9,7 @@ import (
 type DNAHelixEffect struct {
 	dna    *DNAHelix
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建DNA双螺旋特效实例
@@ -47,9 +48,32 @@ DNA双螺旋特效展示了生命的基本结构 - DNA的双螺旋形态。
 	}
 }
 
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *DNAHelixEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *DNAHelixEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "rotation-speed", Label: "旋转速度", LabelEN: "Rotation speed", Min: 0, Max: 10, Step: 0.1}, &e.config.RotationSpeed)
+	ps.Float(effects.Param{Name: "radius", Label: "螺旋半径", LabelEN: "Radius", Min: 2, Max: 30, Step: 1}, &e.config.HelixRadius)
+	ps.Float(effects.Param{Name: "spacing", Label: "螺旋紧密度", LabelEN: "Spacing", Min: 0.05, Max: 1, Step: 0.05}, &e.config.BaseSpacing)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *DNAHelixEffect) Resize(width, height int) {
+	if e.dna != nil {
+		e.dna.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *DNAHelixEffect) Init(screen tcell.Screen) error {
 	e.dna = New(screen, e.config)
+	e.dna.SetClock(e.clock)
 	return e.dna.Init()
 }
 
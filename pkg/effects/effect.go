@@ -1,6 +1,9 @@
 package effects
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+)
 
 // Effect 定义特效的标准接口
 // 所有特效都必须实现此接口以便被主程序管理
@@ -23,6 +26,71 @@ type Effect interface {
 	Cleanup() error
 }
 
+// Resizable 可选接口：屏幕尺寸变化后需要调整内部状态的特效
+// 未实现的特效只能在 Init 时获取一次屏幕尺寸，之后的画面可能越界或留出空白
+type Resizable interface {
+	// Resize 在屏幕尺寸变化后调用，width 和 height 为特效屏幕的新尺寸
+	// 宿主保证它与帧回调互斥。特效应尽量保留已有的状态（细胞、粒子、已生成的迷宫等），
+	// 只裁掉超出新尺寸的部分并填充新增的区域，使画面保持连贯
+	Resize(width, height int)
+}
+
+// NotifyResize 通知特效屏幕尺寸已变化
+// clock 为注入特效的时钟，不为 nil 时在两帧之间通知。特效未实现 Resizable 时返回 false
+// 宽或高为 0 时画面不可见，不通知特效，特效保留原有的状态，恢复尺寸后继续
+func NotifyResize(effect Effect, clock *Clock, width, height int) bool {
+	resizable, ok := effect.(Resizable)
+	if !ok {
+		return false
+	}
+	if width <= 0 || height <= 0 {
+		return true
+	}
+
+	if clock != nil {
+		clock.Do(func() { resizable.Resize(width, height) })
+	} else {
+		resizable.Resize(width, height)
+	}
+	return true
+}
+
+// Paletted 可选接口：用渐变取色、可以直接换用调色板的特效
+// 未实现的特效由宿主包装屏幕，按颜色的亮度从调色板中取色（palette.Screen）
+type Paletted interface {
+	// SetPalette 设置特效使用的调色板，须在 Init 之前调用
+	SetPalette(p *palette.Palette)
+}
+
+// ApplyPalette 向特效注入调色板
+// 特效未实现 Paletted 或 p 为 nil 时不做任何处理，返回是否已注入
+func ApplyPalette(effect Effect, p *palette.Palette) bool {
+	if p == nil {
+		return false
+	}
+
+	paletted, ok := effect.(Paletted)
+	if !ok {
+		return false
+	}
+
+	paletted.SetPalette(p)
+	return true
+}
+
+// PaletteScreen 让特效使用调色板 p（可以为 nil），返回特效应当绘制的屏幕，须在 Init 之前调用
+// 实现 Paletted 的特效直接换用调色板，其余特效的画面按颜色的亮度重新着色；
+// depth 低于真彩色时同时把颜色量化为终端能显示的颜色
+func PaletteScreen(effect Effect, screen tcell.Screen, p *palette.Palette, depth palette.Depth) tcell.Screen {
+	if ApplyPalette(effect, p) {
+		p = nil
+	}
+	if p == nil && depth >= palette.DepthTrueColor {
+		return screen
+	}
+	return palette.NewScreen(screen, p, depth)
+}
+
 // Metadata 特效元数据
 type Metadata struct {
 	// ID 特效唯一标识符（kebab-case）
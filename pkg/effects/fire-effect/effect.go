@@ -3,11 +3,13 @@ package fireeffect
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 type FireEffectEffect struct {
 	fire   *FireEffect
 	config *Config
+	clock  *effects.Clock
 }
 
 func NewEffect() effects.Effect {
@@ -44,8 +46,42 @@ func (e *FireEffectEffect) Metadata() effects.Metadata {
 	}
 }
 
+func (e *FireEffectEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetPalette 设置火焰的配色（实现 effects.Paletted 接口）
+func (e *FireEffectEffect) SetPalette(p *palette.Palette) {
+	e.config.Palette = p
+}
+
+func (e *FireEffectEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+func (e *FireEffectEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "intensity", Label: "火焰强度", LabelEN: "Intensity", Min: 0.1, Max: 2, Step: 0.1}, &e.config.Intensity)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+func (e *FireEffectEffect) Reconfigure() {
+	if e.fire != nil {
+		e.fire.Reconfigure()
+	}
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *FireEffectEffect) Resize(width, height int) {
+	if e.fire != nil {
+		e.fire.Resize(width, height)
+	}
+}
+
 func (e *FireEffectEffect) Init(screen tcell.Screen) error {
 	e.fire = New(screen, e.config)
+	e.fire.SetClock(e.clock)
 	return e.fire.Init()
 }
 
@@ -2,31 +2,37 @@ package fireeffect
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 type Config struct {
 	Intensity float64
 	FPS       int
+	Seed      int64
+	Palette   *palette.Palette // 火焰的配色，按热量从暗到亮取色
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		Intensity: 1.0,
 		FPS:       30,
+		Palette:   palette.Fire,
 	}
 }
 
 type FireEffect struct {
 	screen  tcell.Screen
 	config  *Config
+	clock   *effects.Clock
 	buffer  [][]float64
 	width   int
 	height  int
 	rand    *rand.Rand
 	chars   []rune
+	stepper effects.Stepper
 }
 
 func New(screen tcell.Screen, config *Config) *FireEffect {
@@ -35,10 +41,12 @@ func New(screen tcell.Screen, config *Config) *FireEffect {
 	}
 
 	return &FireEffect{
-		screen: screen,
-		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
-		chars:  []rune{' ', '.', ':', '*', 's', 'S', '#', '$', '@'},
+		screen:  screen,
+		config:  config,
+		clock:   effects.NewClock(),
+		rand:    effects.NewRand(config.Seed),
+		chars:   []rune{' ', '.', ':', '*', 's', 'S', '#', '$', '@'},
+		stepper: effects.Stepper{Rate: float64(config.FPS)},
 	}
 }
 
@@ -51,7 +59,32 @@ func (f *FireEffect) Init() error {
 	return nil
 }
 
-func (f *FireEffect) Update() {
+// Resize 调整热量缓冲区的尺寸
+// 火焰从底部燃起，因此按底边对齐保留原有的热量，新增的区域从零开始
+func (f *FireEffect) Resize(width, height int) {
+	buffer := make([][]float64, height)
+	for y := range buffer {
+		buffer[y] = make([]float64, width)
+		if old := y - height + f.height; old >= 0 && old < f.height {
+			copy(buffer[y], f.buffer[old])
+		}
+	}
+	f.buffer = buffer
+	f.width, f.height = width, height
+}
+
+func (f *FireEffect) Reconfigure() {
+	f.stepper.Rate = float64(f.config.FPS)
+}
+
+func (f *FireEffect) Update(deltaTime float64) {
+	// 火焰按帧传播，每秒推进 FPS 步
+	for n := f.stepper.Steps(deltaTime); n > 0; n-- {
+		f.step()
+	}
+}
+
+func (f *FireEffect) step() {
 	// 底部热源
 	for x := 0; x < f.width; x++ {
 		f.buffer[f.height-1][x] = f.rand.Float64() * f.config.Intensity
@@ -107,33 +140,20 @@ func (f *FireEffect) heatToChar(heat float64) (rune, tcell.Color) {
 
 	char := f.chars[idx]
 
-	var color tcell.Color
-	if heat > 0.8 {
-		color = tcell.ColorYellow
-	} else if heat > 0.5 {
-		color = tcell.ColorOrange
-	} else if heat > 0.3 {
-		color = tcell.ColorRed
-	} else {
-		color = tcell.ColorDarkRed
-	}
+	return char, f.config.Palette.At(heat)
+}
 
-	return char, color
+func (f *FireEffect) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		f.clock = clock
+	}
 }
 
 func (f *FireEffect) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(f.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			f.Update()
-			f.Render()
-		}
-	}
+	return f.clock.RunVar(&f.config.FPS, quit, func(deltaTime float64) {
+		f.Update(deltaTime)
+		f.Render()
+	})
 }
 
 func (f *FireEffect) Cleanup() error {
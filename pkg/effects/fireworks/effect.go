@@ -9,6 +9,8 @@ import (
 type FireworksEffect struct {
 	fireworks *Fireworks
 	config    *Config
+	clock     *effects.Clock
+	buttons   effects.MouseButtons // 鼠标按键状态
 }
 
 // NewEffect 创建烟花绽放特效实例
@@ -47,12 +49,47 @@ func (e *FireworksEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *FireworksEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *FireworksEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *FireworksEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "interval", Label: "发射间隔（秒）", LabelEN: "Launch interval (s)", Min: 0.1, Max: 10, Step: 0.1}, &e.config.LaunchInterval)
+	ps.Int(effects.Param{Name: "particles", Label: "粒子数量", LabelEN: "Particles", Min: 5, Max: 300, Step: 5}, &e.config.ParticlesPerBurst)
+	ps.Float(effects.Param{Name: "gravity", Label: "重力", LabelEN: "Gravity", Min: 0, Max: 100, Step: 1}, &e.config.Gravity)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *FireworksEffect) Resize(width, height int) {
+	if e.fireworks != nil {
+		e.fireworks.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *FireworksEffect) Init(screen tcell.Screen) error {
 	e.fireworks = New(screen, e.config)
+	e.fireworks.SetClock(e.clock)
 	return e.fireworks.Init()
 }
 
+// HandleMouse 点击处发射一枚烟花（实现 effects.MouseHandler 接口）
+func (e *FireworksEffect) HandleMouse(event *tcell.EventMouse) {
+	if e.fireworks != nil && e.buttons.Pressed(event)&tcell.Button1 != 0 {
+		e.fireworks.LaunchAt(event.Position())
+	}
+}
+
 // Run 运行特效
 func (e *FireworksEffect) Run(quit <-chan struct{}) error {
 	return e.fireworks.Run(quit)
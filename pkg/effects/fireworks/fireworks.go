@@ -3,9 +3,9 @@ package fireworks
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 烟花配置
@@ -14,6 +14,7 @@ type Config struct {
 	ParticlesPerBurst int     // 每次爆炸的粒子数
 	Gravity           float64 // 重力加速度
 	FPS               int     // 帧率
+	Seed              int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -49,11 +50,11 @@ type Firework struct {
 type Fireworks struct {
 	screen         tcell.Screen
 	config         *Config
+	clock          *effects.Clock
 	fireworks      []*Firework
 	width          int
 	height         int
 	timeSinceLaunch float64
-	lastUpdate     time.Time
 	rand           *rand.Rand
 }
 
@@ -66,8 +67,9 @@ func New(screen tcell.Screen, config *Config) *Fireworks {
 	return &Fireworks{
 		screen:    screen,
 		config:    config,
+		clock:     effects.NewClock(),
 		fireworks: make([]*Firework, 0),
-		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:      effects.NewRand(config.Seed),
 	}
 }
 
@@ -75,21 +77,21 @@ func New(screen tcell.Screen, config *Config) *Fireworks {
 func (f *Fireworks) Init() error {
 	f.width, f.height = f.screen.Size()
 	f.timeSinceLaunch = 0
-	f.lastUpdate = time.Now()
 	return nil
 }
 
+// colors 烟花的颜色
+var colors = []tcell.Color{
+	tcell.ColorRed,
+	tcell.ColorGreen,
+	tcell.ColorBlue,
+	tcell.ColorYellow,
+	tcell.ColorPurple,
+	tcell.ColorTeal,
+}
+
 // launch 发射新烟花
 func (f *Fireworks) launch() {
-	colors := []tcell.Color{
-		tcell.ColorRed,
-		tcell.ColorGreen,
-		tcell.ColorBlue,
-		tcell.ColorYellow,
-		tcell.ColorPurple,
-		tcell.ColorTeal,
-	}
-
 	fw := &Firework{
 		x:       float64(f.rand.Intn(f.width)),
 		y:       float64(f.height),
@@ -101,14 +103,31 @@ func (f *Fireworks) launch() {
 	f.fireworks = append(f.fireworks, fw)
 }
 
+// LaunchAt 从 x 列底部发射一枚烟花，在 y 行的高度爆炸
+func (f *Fireworks) LaunchAt(x, y int) {
+	// 初速度至少要能克服重力升到目标高度
+	height := float64(f.height - y)
+	vy := min(-40.0-f.rand.Float64()*20.0, -math.Sqrt(2*f.config.Gravity*height)*1.1)
+
+	fw := &Firework{
+		x:       float64(x),
+		y:       float64(f.height),
+		vy:      vy,
+		stage:   0,
+		targetY: float64(y),
+		color:   colors[f.rand.Intn(len(colors))],
+	}
+	f.fireworks = append(f.fireworks, fw)
+}
+
 // explode 爆炸
-func (fw *Firework) explode(config *Config) {
+func (fw *Firework) explode(config *Config, rng *rand.Rand) {
 	fw.stage = 1
 	fw.particles = make([]*Particle, config.ParticlesPerBurst)
 
 	for i := 0; i < config.ParticlesPerBurst; i++ {
-		angle := rand.Float64() * 2 * math.Pi
-		speed := 10.0 + rand.Float64()*15.0
+		angle := rng.Float64() * 2 * math.Pi
+		speed := 10.0 + rng.Float64()*15.0
 
 		fw.particles[i] = &Particle{
 			x:     fw.x,
@@ -116,12 +135,26 @@ func (fw *Firework) explode(config *Config) {
 			vx:    speed * math.Cos(angle),
 			vy:    speed * math.Sin(angle),
 			life:  1.0,
-			decay: 0.5 + rand.Float64()*0.5, // 0.5-1.0
+			decay: 0.5 + rng.Float64()*0.5, // 0.5-1.0
 			color: fw.color,
 		}
 	}
 }
 
+// Resize 调整尺寸
+// 烟花从底部升起，高度变化时整体平移，使烟花与粒子到底边的距离保持不变
+func (f *Fireworks) Resize(width, height int) {
+	dy := float64(height - f.height)
+	for _, fw := range f.fireworks {
+		fw.y += dy
+		fw.targetY += dy
+		for _, p := range fw.particles {
+			p.y += dy
+		}
+	}
+	f.width, f.height = width, height
+}
+
 // Update 更新烟花绽放状态
 func (f *Fireworks) Update(deltaTime float64) {
 	// 发射新烟花
@@ -141,7 +174,7 @@ func (f *Fireworks) Update(deltaTime float64) {
 
 			// 检测是否到达目标高度
 			if fw.y <= fw.targetY {
-				fw.explode(f.config)
+				fw.explode(f.config, f.rand)
 			}
 			activeFireworks = append(activeFireworks, fw)
 		} else {
@@ -207,24 +240,19 @@ func (f *Fireworks) Render() {
 	f.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (f *Fireworks) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		f.clock = clock
+	}
+}
+
 // Run 运行烟花绽放特效
 func (f *Fireworks) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(f.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(f.lastUpdate).Seconds()
-			f.lastUpdate = now
-
-			f.Update(deltaTime)
-			f.Render()
-		}
-	}
+	return f.clock.RunVar(&f.config.FPS, quit, func(deltaTime float64) {
+		f.Update(deltaTime)
+		f.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -6,8 +6,11 @@ import (
 )
 
 type GameOfLifeEffect struct {
-	game   *GameOfLife
-	config *Config
+	game    *GameOfLife
+	config  *Config
+	clock   *effects.Clock
+	buttons effects.MouseButtons // 鼠标按键状态
+	paint   bool                 // 按住拖动时细胞设置成的状态
 }
 
 func NewEffect() effects.Effect {
@@ -33,6 +36,10 @@ func (e *GameOfLifeEffect) Metadata() effects.Metadata {
 - 绿色细胞显示
 - 支持边界循环
 
+控制：
+- 鼠标点击切换细胞的生死，按住拖动连续绘制
+- p 暂停/继续演化，r 重新随机生成，c 清空
+
 完美用于：
 - 算法演示
 - 数学教学
@@ -44,11 +51,79 @@ func (e *GameOfLifeEffect) Metadata() effects.Metadata {
 	}
 }
 
+func (e *GameOfLifeEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+func (e *GameOfLifeEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+func (e *GameOfLifeEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "density", Label: "初始密度", LabelEN: "Initial density", Min: 0.05, Max: 0.95, Step: 0.05}, &e.config.InitDensity)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+func (e *GameOfLifeEffect) Reconfigure() {
+	if e.game != nil {
+		e.game.Reconfigure()
+	}
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *GameOfLifeEffect) Resize(width, height int) {
+	if e.game != nil {
+		e.game.Resize(width, height)
+	}
+}
+
 func (e *GameOfLifeEffect) Init(screen tcell.Screen) error {
 	e.game = New(screen, e.config)
+	e.game.SetClock(e.clock)
 	return e.game.Init()
 }
 
+// HandleKey 处理生命游戏的按键（实现 effects.KeyHandler 接口）
+// p: 暂停/继续演化 | r: 重新随机生成 | c: 清空
+func (e *GameOfLifeEffect) HandleKey(event *tcell.EventKey) bool {
+	if e.game == nil || event.Key() != tcell.KeyRune {
+		return false
+	}
+
+	switch event.Rune() {
+	case 'p', 'P':
+		e.game.TogglePause()
+	case 'r', 'R':
+		e.game.Reseed()
+	case 'c', 'C':
+		e.game.ClearCells()
+	default:
+		return false
+	}
+	e.game.Render()
+	return true
+}
+
+// HandleMouse 点击切换细胞的生死，按住拖动时把经过的细胞设为同样的状态
+// （实现 effects.MouseHandler 接口）。暂停时也立即重绘，便于先画好图案再继续演化
+func (e *GameOfLifeEffect) HandleMouse(event *tcell.EventMouse) {
+	if e.game == nil {
+		return
+	}
+
+	x, y := event.Position()
+	switch {
+	case e.buttons.Pressed(event)&tcell.Button1 != 0:
+		e.paint = !e.game.Cell(x, y)
+	case event.Buttons()&tcell.Button1 == 0:
+		return
+	}
+	e.game.SetCell(x, y, e.paint)
+	e.game.Render()
+}
+
 func (e *GameOfLifeEffect) Run(quit <-chan struct{}) error {
 	return e.game.Run(quit)
 }
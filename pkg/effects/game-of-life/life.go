@@ -2,14 +2,15 @@ package gameoflife
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 type Config struct {
 	InitDensity float64
 	FPS         int
+	Seed        int64
 }
 
 func DefaultConfig() *Config {
@@ -22,11 +23,15 @@ func DefaultConfig() *Config {
 type GameOfLife struct {
 	screen  tcell.Screen
 	config  *Config
+	clock   *effects.Clock
 	grid    [][]bool
 	newGrid [][]bool
 	width   int
 	height  int
 	rand    *rand.Rand
+	stepper effects.Stepper
+	density float64 // 生成当前网格时的初始密度
+	paused  bool    // 暂停演化（画面照常刷新，便于用鼠标绘制图案）
 }
 
 func New(screen tcell.Screen, config *Config) *GameOfLife {
@@ -35,9 +40,11 @@ func New(screen tcell.Screen, config *Config) *GameOfLife {
 	}
 
 	return &GameOfLife{
-		screen: screen,
-		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		screen:  screen,
+		config:  config,
+		clock:   effects.NewClock(),
+		rand:    effects.NewRand(config.Seed),
+		stepper: effects.Stepper{Rate: float64(config.FPS)},
 	}
 }
 
@@ -49,13 +56,48 @@ func (g *GameOfLife) Init() error {
 	for y := 0; y < g.height; y++ {
 		g.grid[y] = make([]bool, g.width)
 		g.newGrid[y] = make([]bool, g.width)
+	}
+	g.populate()
 
+	return nil
+}
+
+func (g *GameOfLife) populate() {
+	g.density = g.config.InitDensity
+	for y := 0; y < g.height; y++ {
 		for x := 0; x < g.width; x++ {
 			g.grid[y][x] = g.rand.Float64() < g.config.InitDensity
 		}
 	}
+}
 
-	return nil
+// Resize 调整网格尺寸
+// 原有的细胞保持在原来的位置，新增的区域按当前的初始密度随机生成
+func (g *GameOfLife) Resize(width, height int) {
+	grid := make([][]bool, height)
+	g.newGrid = make([][]bool, height)
+	for y := 0; y < height; y++ {
+		grid[y] = make([]bool, width)
+		g.newGrid[y] = make([]bool, width)
+		for x := 0; x < width; x++ {
+			if y < g.height && x < g.width {
+				grid[y][x] = g.grid[y][x]
+			} else {
+				grid[y][x] = g.rand.Float64() < g.density
+			}
+		}
+	}
+	g.grid = grid
+	g.width, g.height = width, height
+}
+
+func (g *GameOfLife) Reconfigure() {
+	g.stepper.Rate = float64(g.config.FPS)
+
+	// 初始密度变化时重新开始演化
+	if g.density != g.config.InitDensity {
+		g.populate()
+	}
 }
 
 func (g *GameOfLife) countNeighbors(x, y int) int {
@@ -77,7 +119,36 @@ func (g *GameOfLife) countNeighbors(x, y int) int {
 	return count
 }
 
-func (g *GameOfLife) Update() {
+func (g *GameOfLife) Update(deltaTime float64) {
+	// 每秒演化 FPS 代，暂停期间的时间不累积
+	n := g.stepper.Steps(deltaTime)
+	if g.paused {
+		return
+	}
+	for ; n > 0; n-- {
+		g.step()
+	}
+}
+
+// TogglePause 暂停或继续演化，返回切换后是否处于暂停
+func (g *GameOfLife) TogglePause() bool {
+	g.paused = !g.paused
+	return g.paused
+}
+
+// Reseed 按初始密度重新随机生成细胞
+func (g *GameOfLife) Reseed() {
+	g.populate()
+}
+
+// ClearCells 清空所有细胞
+func (g *GameOfLife) ClearCells() {
+	for y := range g.grid {
+		clear(g.grid[y])
+	}
+}
+
+func (g *GameOfLife) step() {
 	for y := 0; y < g.height; y++ {
 		for x := 0; x < g.width; x++ {
 			neighbors := g.countNeighbors(x, y)
@@ -96,6 +167,22 @@ func (g *GameOfLife) Update() {
 	g.grid, g.newGrid = g.newGrid, g.grid
 }
 
+// SetCell 设置 (x, y) 处细胞的状态，坐标超出网格时忽略
+func (g *GameOfLife) SetCell(x, y int, alive bool) {
+	if x < 0 || y < 0 || x >= g.width || y >= g.height {
+		return
+	}
+	g.grid[y][x] = alive
+}
+
+// Cell 返回 (x, y) 处的细胞是否存活
+func (g *GameOfLife) Cell(x, y int) bool {
+	if x < 0 || y < 0 || x >= g.width || y >= g.height {
+		return false
+	}
+	return g.grid[y][x]
+}
+
 func (g *GameOfLife) Render() {
 	g.screen.Clear()
 
@@ -112,21 +199,19 @@ func (g *GameOfLife) Render() {
 	g.screen.Show()
 }
 
-func (g *GameOfLife) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(g.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			g.Update()
-			g.Render()
-		}
+func (g *GameOfLife) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		g.clock = clock
 	}
 }
 
+func (g *GameOfLife) Run(quit <-chan struct{}) error {
+	return g.clock.RunVar(&g.config.FPS, quit, func(deltaTime float64) {
+		g.Update(deltaTime)
+		g.Render()
+	})
+}
+
 func (g *GameOfLife) Cleanup() error {
 	return nil
 }
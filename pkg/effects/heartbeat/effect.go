@@ -9,6 +9,7 @@ import (
 type HeartbeatEffect struct {
 	heartbeat *Heartbeat
 	config    *Config
+	clock     *effects.Clock
 }
 
 // NewEffect 创建心跳律动特效实例
@@ -47,9 +48,31 @@ func (e *HeartbeatEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *HeartbeatEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *HeartbeatEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Int(effects.Param{Name: "bpm", Label: "心率（次/分钟）", LabelEN: "Heart rate (BPM)", Min: 30, Max: 200}, &e.config.BPM)
+	ps.Float(effects.Param{Name: "scale", Label: "缩放幅度", LabelEN: "Scale", Min: 0, Max: 1, Step: 0.05}, &e.config.MaxScale)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *HeartbeatEffect) Resize(width, height int) {
+	if e.heartbeat != nil {
+		e.heartbeat.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *HeartbeatEffect) Init(screen tcell.Screen) error {
 	e.heartbeat = New(screen, e.config)
+	e.heartbeat.SetClock(e.clock)
 	return e.heartbeat.Init()
 }
 
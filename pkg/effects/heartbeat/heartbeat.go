@@ -2,9 +2,9 @@ package heartbeat
 
 import (
 	"math"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 心跳配置
@@ -27,11 +27,11 @@ func DefaultConfig() *Config {
 type Heartbeat struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	time       float64
 	width      int
 	height     int
 	asciiArt   []string
-	lastUpdate time.Time
 }
 
 // heartASCII 心形ASCII艺术
@@ -58,6 +58,7 @@ func New(screen tcell.Screen, config *Config) *Heartbeat {
 	return &Heartbeat{
 		screen:   screen,
 		config:   config,
+		clock:    effects.NewClock(),
 		asciiArt: heartASCII,
 	}
 }
@@ -66,7 +67,6 @@ func New(screen tcell.Screen, config *Config) *Heartbeat {
 func (h *Heartbeat) Init() error {
 	h.width, h.height = h.screen.Size()
 	h.time = 0
-	h.lastUpdate = time.Now()
 	return nil
 }
 
@@ -75,6 +75,11 @@ func (h *Heartbeat) Update(deltaTime float64) {
 	h.time += deltaTime
 }
 
+// Resize 调整尺寸，心形始终位于屏幕中央
+func (h *Heartbeat) Resize(width, height int) {
+	h.width, h.height = width, height
+}
+
 // Render 渲染心跳
 func (h *Heartbeat) Render() {
 	h.screen.Clear()
@@ -132,24 +137,19 @@ func (h *Heartbeat) Render() {
 	h.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (h *Heartbeat) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		h.clock = clock
+	}
+}
+
 // Run 运行心跳特效
 func (h *Heartbeat) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(h.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(h.lastUpdate).Seconds()
-			h.lastUpdate = now
-
-			h.Update(deltaTime)
-			h.Render()
-		}
-	}
+	return h.clock.RunVar(&h.config.FPS, quit, func(deltaTime float64) {
+		h.Update(deltaTime)
+		h.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -0,0 +1,66 @@
+package effects
+
+import "github.com/gdamore/tcell/v2"
+
+// KeyHandler 可选接口：提供自己的按键控制的特效
+// 宿主先处理自己的按键（ESC、播放列表的 n），其余按键交给特效，特效不使用的按键再用于时钟控制。
+// 特效应避开时钟控制键（空格 . + = - 0），以免用户无法暂停或调速
+type KeyHandler interface {
+	// HandleKey 处理按键，返回 false 表示特效不使用该按键
+	// 宿主保证它与帧回调互斥，特效可以直接修改自己的状态
+	HandleKey(event *tcell.EventKey) bool
+}
+
+// SendKey 把按键转发给特效，返回特效是否使用了该按键
+// clock 为注入特效的时钟，不为 nil 时在两帧之间转发
+func SendKey(effect Effect, clock *Clock, event *tcell.EventKey) bool {
+	handler, ok := effect.(KeyHandler)
+	if !ok {
+		return false
+	}
+
+	var used bool
+	if clock != nil {
+		clock.Do(func() { used = handler.HandleKey(event) })
+	} else {
+		used = handler.HandleKey(event)
+	}
+	return used
+}
+
+// MouseHandler 可选接口：响应鼠标的特效
+type MouseHandler interface {
+	// HandleMouse 处理鼠标事件，坐标相对于特效的屏幕
+	// 宿主保证它与帧回调互斥，特效可以直接修改自己的状态
+	HandleMouse(event *tcell.EventMouse)
+}
+
+// SendMouse 把鼠标事件转发给特效
+// clock 为注入特效的时钟，不为 nil 时在两帧之间转发。特效未实现 MouseHandler 时返回 false
+func SendMouse(effect Effect, clock *Clock, event *tcell.EventMouse) bool {
+	handler, ok := effect.(MouseHandler)
+	if !ok {
+		return false
+	}
+
+	if clock != nil {
+		clock.Do(func() { handler.HandleMouse(event) })
+	} else {
+		handler.HandleMouse(event)
+	}
+	return true
+}
+
+// MouseButtons 记录鼠标按键的状态
+// 终端只报告按键当前是否按住，MouseButtons 据此区分按下的瞬间与按住拖动
+type MouseButtons struct {
+	held tcell.ButtonMask
+}
+
+// Pressed 返回本次事件中刚按下的按键（不含滚轮）
+func (m *MouseButtons) Pressed(event *tcell.EventMouse) tcell.ButtonMask {
+	buttons := event.Buttons() & (tcell.Button1 | tcell.Button2 | tcell.Button3)
+	pressed := buttons &^ m.held
+	m.held = buttons
+	return pressed
+}
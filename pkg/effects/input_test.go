@@ -0,0 +1,55 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// keyEffect 只使用 x 键的测试特效
+type keyEffect struct {
+	metadataEffect
+	keys int
+}
+
+func (e *keyEffect) HandleKey(event *tcell.EventKey) bool {
+	if event.Rune() != 'x' {
+		return false
+	}
+	e.keys++
+	return true
+}
+
+func TestSendKey(t *testing.T) {
+	effect := &keyEffect{}
+	clock := NewClock()
+
+	if !SendKey(effect, clock, tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)) || effect.keys != 1 {
+		t.Error("Expected x to be used by the effect")
+	}
+	if SendKey(effect, clock, tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)) {
+		t.Error("Expected unused keys to fall through to the host")
+	}
+	if SendKey(&metadataEffect{}, nil, tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone)) {
+		t.Error("Expected effects without KeyHandler to ignore keys")
+	}
+}
+
+func TestMouseButtonsPressed(t *testing.T) {
+	var buttons MouseButtons
+	events := []struct {
+		buttons tcell.ButtonMask
+		want    tcell.ButtonMask
+	}{
+		{tcell.Button1, tcell.Button1},
+		{tcell.Button1, tcell.ButtonNone}, // 按住拖动
+		{tcell.Button1 | tcell.Button2, tcell.Button2},
+		{tcell.ButtonNone, tcell.ButtonNone},
+		{tcell.Button1 | tcell.WheelUp, tcell.Button1},
+	}
+	for i, ev := range events {
+		if got := buttons.Pressed(tcell.NewEventMouse(0, 0, ev.buttons, tcell.ModNone)); got != ev.want {
+			t.Errorf("Event %d: expected %v, got %v", i, ev.want, got)
+		}
+	}
+}
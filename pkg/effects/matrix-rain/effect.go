@@ -1,22 +1,23 @@
 package matrixrain
 
 import (
-	"time"
-
 	"github.com/gdamore/tcell/v2"
 	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 // MatrixRainEffect 实现 Effect 接口的矩阵字符雨特效
 type MatrixRainEffect struct {
 	rain   *Rain
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建新的字符雨特效实例（工厂函数）
 func NewEffect() effects.Effect {
 	return &MatrixRainEffect{
 		config: DefaultConfig(),
+		clock:  effects.NewClock(),
 	}
 }
 
@@ -24,6 +25,7 @@ func NewEffect() effects.Effect {
 func NewEffectWithConfig(config *Config) effects.Effect {
 	return &MatrixRainEffect{
 		config: config,
+		clock:  effects.NewClock(),
 	}
 }
 
@@ -41,13 +43,91 @@ func (e *MatrixRainEffect) Metadata() effects.Metadata {
 • 多种字符集（数字、字母、日文片假名、混合）
 • 颜色渐变效果（亮白→亮绿→绿色→暗绿）
 • 可调节速度和密度
-• 平滑动画和自适应终端`,
+• 平滑动画和自适应终端
+
+控制：按 c 切换字符集`,
 		Author:  "SymbolMove",
 		Version: "1.0.0",
 		Tags:    []string{"classic", "matrix", "animation", "green"},
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *MatrixRainEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetPalette 设置字符流的配色（实现 effects.Paletted 接口）
+func (e *MatrixRainEffect) SetPalette(p *palette.Palette) {
+	e.config.Palette = p
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *MatrixRainEffect) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		e.clock = clock
+	}
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *MatrixRainEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	effects.Enum(ps, effects.Param{
+		Name: "charset", Label: "字符集", LabelEN: "Charset",
+		Options: []string{"digits", "letters", "katakana", "mixed", "custom"},
+	}, &e.config.CharSet, CharSetDigits, CharSetLetters, CharSetKatakana, CharSetMixed, CharSetCustom)
+	ps.Bind(effects.Param{Name: "chars", Type: effects.ParamString, Label: "自定义字符", LabelEN: "Custom chars"},
+		func() any { return string(e.config.CustomChars) },
+		func(v any) { e.config.CustomChars = []rune(v.(string)) })
+	effects.Enum(ps, effects.Param{
+		Name: "speed", Label: "下落速度", LabelEN: "Speed",
+		Options: []string{"slow", "medium", "fast"},
+	}, &e.config.Speed, SpeedSlow, SpeedMedium, SpeedFast)
+	effects.Enum(ps, effects.Param{
+		Name: "density", Label: "密度", LabelEN: "Density",
+		Options: []string{"sparse", "medium", "dense"},
+	}, &e.config.Density, DensitySparse, DensityMedium, DensityDense)
+	ps.Int(effects.Param{Name: "trail", Label: "尾迹长度", LabelEN: "Trail length", Min: 1, Max: 40}, &e.config.TrailLength)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Reconfigure 运行中参数变化后更新字符雨（实现 effects.Reconfigurable 接口）
+func (e *MatrixRainEffect) Reconfigure() {
+	if e.rain != nil {
+		e.rain.Reconfigure()
+	}
+}
+
+// HandleKey c 键切换到下一个字符集（实现 effects.KeyHandler 接口）
+// 没有设置自定义字符时跳过自定义字符集
+func (e *MatrixRainEffect) HandleKey(event *tcell.EventKey) bool {
+	if e.rain == nil || event.Key() != tcell.KeyRune || (event.Rune() != 'c' && event.Rune() != 'C') {
+		return false
+	}
+
+	charsets := []CharSet{CharSetDigits, CharSetLetters, CharSetKatakana, CharSetMixed}
+	if len(e.config.CustomChars) > 0 {
+		charsets = append(charsets, CharSetCustom)
+	}
+	next := 0
+	for i, charset := range charsets {
+		if charset == e.config.CharSet {
+			next = (i + 1) % len(charsets)
+		}
+	}
+	e.config.CharSet = charsets[next]
+	e.rain.Reconfigure()
+	return true
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *MatrixRainEffect) Resize(width, height int) {
+	if e.rain != nil {
+		e.rain.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *MatrixRainEffect) Init(screen tcell.Screen) error {
 	e.rain = New(screen, e.config)
@@ -60,18 +140,10 @@ func (e *MatrixRainEffect) Run(quit <-chan struct{}) error {
 		return nil
 	}
 
-	ticker := time.NewTicker(time.Second / time.Duration(e.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			e.rain.Update()
-			e.rain.Render()
-		}
-	}
+	return e.clock.RunVar(&e.config.FPS, quit, func(deltaTime float64) {
+		e.rain.Update(deltaTime)
+		e.rain.Render()
+	})
 }
 
 // Cleanup 清理资源
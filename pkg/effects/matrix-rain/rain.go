@@ -2,9 +2,10 @@ package matrixrain
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 // CharSet 定义字符集类型
@@ -43,7 +44,9 @@ type Config struct {
 	Speed         Speed     // 下落速度
 	Density       Density   // 字符雨密度
 	FPS           int       // 帧率
+	Seed          int64     // 随机种子（0 表示使用当前时间）
 	TrailLength   int       // 字符流尾迹长度
+	Palette       *palette.Palette // 字符流的配色，尾部取最暗端，头部取最亮端
 }
 
 // DefaultConfig 返回默认配置
@@ -54,6 +57,7 @@ func DefaultConfig() *Config {
 		Density:     DensityMedium,
 		FPS:         30,
 		TrailLength: 15,
+		Palette:     palette.Matrix,
 	}
 }
 
@@ -75,7 +79,6 @@ type Rain struct {
 	height      int
 	charPool    []rune
 	rand        *rand.Rand
-	lastUpdate  time.Time
 }
 
 // New 创建新的字符雨效果
@@ -85,11 +88,10 @@ func New(screen tcell.Screen, config *Config) *Rain {
 	}
 
 	r := &Rain{
-		screen:     screen,
-		config:     config,
-		drops:      make([]*RainDrop, 0),
-		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
-		lastUpdate: time.Now(),
+		screen: screen,
+		config: config,
+		drops:  make([]*RainDrop, 0),
+		rand:   effects.NewRand(config.Seed),
 	}
 
 	r.updateSize()
@@ -139,20 +141,23 @@ func (r *Rain) initCharPool() {
 
 // initDrops 初始化字符流
 func (r *Rain) initDrops() {
-	// 根据密度计算字符流数量
-	var dropCount int
+	dropCount := r.dropCount()
+	for i := 0; i < dropCount; i++ {
+		r.drops = append(r.drops, r.createRandomDrop())
+	}
+}
+
+// dropCount 根据密度计算字符流数量
+func (r *Rain) dropCount() int {
 	switch r.config.Density {
 	case DensitySparse:
-		dropCount = r.width / 4
+		return r.width / 4
 	case DensityMedium:
-		dropCount = r.width / 2
+		return r.width / 2
 	case DensityDense:
-		dropCount = r.width
-	}
-
-	for i := 0; i < dropCount; i++ {
-		r.drops = append(r.drops, r.createRandomDrop())
+		return r.width
 	}
+	return 0
 }
 
 // createRandomDrop 创建随机字符流
@@ -192,17 +197,14 @@ func (r *Rain) randomChar() rune {
 }
 
 // Update 更新字符雨状态
-func (r *Rain) Update() {
-	now := time.Now()
-	dt := now.Sub(r.lastUpdate).Seconds()
-	r.lastUpdate = now
-
+// deltaTime: 距上一帧的模拟时间（秒）
+func (r *Rain) Update(deltaTime float64) {
 	// 更新所有字符流
 	for i := 0; i < len(r.drops); i++ {
 		drop := r.drops[i]
 
 		// 更新位置
-		drop.Y += drop.Speed * dt * float64(r.config.FPS)
+		drop.Y += drop.Speed * deltaTime * float64(r.config.FPS)
 
 		// 随机改变顶部字符
 		if r.rand.Float64() < 0.1 {
@@ -251,34 +253,43 @@ func (r *Rain) renderDrop(drop *RainDrop) {
 func (r *Rain) getCharStyle(index, length int) tcell.Style {
 	// index 0 是最新（顶部），index length-1 是最旧（底部）
 	ratio := float64(index) / float64(length)
-
-	var fg tcell.Color
-
-	if ratio < 0.1 {
-		// 顶部：亮白色
-		fg = tcell.ColorWhite
-	} else if ratio < 0.3 {
-		// 接近顶部：亮绿色
-		fg = tcell.ColorLightGreen
-	} else if ratio < 0.7 {
-		// 中间：标准绿色
-		fg = tcell.ColorGreen
-	} else {
-		// 底部：暗绿色
-		fg = tcell.ColorDarkGreen
-	}
+	fg := r.config.Palette.At(1 - ratio)
 
 	return tcell.StyleDefault.Foreground(fg).Background(tcell.ColorBlack)
 }
 
 // Resize 处理终端大小调整
-func (r *Rain) Resize() {
-	oldWidth := r.width
-	r.updateSize()
+// 保留仍在屏幕内的字符流，再按新宽度补足或减少字符流数量
+func (r *Rain) Resize(width, height int) {
+	r.width, r.height = width, height
 
-	// 如果宽度改变，调整字符流数量
-	if oldWidth != r.width {
-		r.drops = r.drops[:0]
-		r.initDrops()
+	drops := r.drops[:0]
+	for _, drop := range r.drops {
+		if drop.X < width {
+			drops = append(drops, drop)
+		}
+	}
+	r.drops = drops
+
+	count := r.dropCount()
+	if len(r.drops) > count {
+		r.drops = r.drops[:count]
+	}
+	for len(r.drops) < count {
+		r.drops = append(r.drops, r.createRandomDrop())
+	}
+}
+
+// Reconfigure 运行中配置变化后更新字符池和字符流数量
+// 速度和尾迹长度在字符流重新生成时生效
+func (r *Rain) Reconfigure() {
+	r.initCharPool()
+
+	count := r.dropCount()
+	if len(r.drops) > count {
+		r.drops = r.drops[:count]
+	}
+	for len(r.drops) < count {
+		r.drops = append(r.drops, r.createRandomDrop())
 	}
 }
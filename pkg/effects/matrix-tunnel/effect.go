@@ -2,6 +2,7 @@ package matrixtunnel
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
 	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
@@ -9,6 +10,7 @@ import (
 type MatrixTunnelEffect struct {
 	tunnel *MatrixTunnel
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建矩阵隧道特效实例
@@ -35,6 +37,7 @@ func (e *MatrixTunnelEffect) Metadata() effects.Metadata {
 - 流畅的飞行动画
 - 无限循环深度
 - 绿色矩阵主题
+- 可用半格或盲文点阵细分像素（render=half、render=braille），字符变为飞驰的光线拖尾
 
 完美用于：
 - 科幻主题展示
@@ -47,9 +50,40 @@ func (e *MatrixTunnelEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *MatrixTunnelEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *MatrixTunnelEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *MatrixTunnelEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "speed", Label: "飞行速度", LabelEN: "Speed", Min: 0.5, Max: 20, Step: 0.5}, &e.config.Speed)
+	ps.Float(effects.Param{Name: "density", Label: "字符密度", LabelEN: "Density", Min: 0.05, Max: 1, Step: 0.05}, &e.config.Density)
+	effects.Enum(ps, effects.Param{
+		Name: "render", Label: "渲染精度", LabelEN: "Rendering",
+		Options: []string{"cell", "half", "braille"},
+	}, &e.config.Render, canvas.Modes...)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *MatrixTunnelEffect) Resize(width, height int) {
+	if e.tunnel != nil {
+		e.tunnel.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *MatrixTunnelEffect) Init(screen tcell.Screen) error {
 	e.tunnel = New(screen, e.config)
+	e.tunnel.SetClock(e.clock)
 	return e.tunnel.Init()
 }
 
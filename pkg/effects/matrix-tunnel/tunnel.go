@@ -3,9 +3,10 @@ package matrixtunnel
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 矩阵隧道配置
@@ -13,6 +14,8 @@ type Config struct {
 	Speed   float64 // 飞行速度
 	Density float64 // 字符密度
 	FPS     int     // 帧率
+	Seed    int64   // 随机种子（0 表示使用当前时间）
+	Render  canvas.Mode // 渲染精度：每格一个字符，或用半格、盲文点阵细分像素
 }
 
 // DefaultConfig 返回默认配置
@@ -21,6 +24,7 @@ func DefaultConfig() *Config {
 		Speed:   5.0,  // 飞行速度
 		Density: 0.3,  // 字符密度
 		FPS:     30,
+		Render:  canvas.ModeCell,
 	}
 }
 
@@ -28,12 +32,13 @@ func DefaultConfig() *Config {
 type MatrixTunnel struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	depth      float64
 	width      int
 	height     int
 	chars      []rune
-	lastUpdate time.Time
 	rand       *rand.Rand
+	canvas     *canvas.Canvas // 细分像素渲染时使用
 }
 
 // New 创建矩阵隧道特效实例
@@ -52,8 +57,9 @@ func New(screen tcell.Screen, config *Config) *MatrixTunnel {
 	return &MatrixTunnel{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		chars:  chars,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -61,10 +67,17 @@ func New(screen tcell.Screen, config *Config) *MatrixTunnel {
 func (m *MatrixTunnel) Init() error {
 	m.width, m.height = m.screen.Size()
 	m.depth = 0
-	m.lastUpdate = time.Now()
 	return nil
 }
 
+// Resize 调整尺寸，隧道始终以屏幕中心为消失点
+func (m *MatrixTunnel) Resize(width, height int) {
+	m.width, m.height = width, height
+	if m.canvas != nil {
+		m.canvas.Resize(width, height)
+	}
+}
+
 // Update 更新矩阵隧道状态
 func (m *MatrixTunnel) Update(deltaTime float64) {
 	m.depth += m.config.Speed * deltaTime
@@ -90,6 +103,12 @@ func (m *MatrixTunnel) getColorByBrightness(brightness float64) tcell.Color {
 func (m *MatrixTunnel) Render() {
 	m.screen.Clear()
 
+	if m.config.Render != canvas.ModeCell && m.config.Render != "" {
+		m.renderCanvas()
+		m.screen.Show()
+		return
+	}
+
 	centerX := m.width / 2
 	centerY := m.height / 2
 	tunnelRadius := 15.0 // 隧道半径
@@ -141,26 +160,62 @@ func (m *MatrixTunnel) Render() {
 	m.screen.Show()
 }
 
-// Run 运行矩阵隧道特效
-func (m *MatrixTunnel) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(m.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(m.lastUpdate).Seconds()
-			m.lastUpdate = now
-
-			m.Update(deltaTime)
-			m.Render()
+// renderCanvas 以像素精度投影隧道墙壁上的点，每个点画成指向消失点的一小段拖尾
+// 随机数的使用顺序与字符渲染相同
+func (m *MatrixTunnel) renderCanvas() {
+	if m.canvas == nil || m.canvas.Mode() != m.config.Render {
+		m.canvas = canvas.New(m.config.Render, m.width, m.height)
+	}
+	m.canvas.Clear()
+
+	sx, sy := m.config.Render.Scale()
+	centerX := float64(m.width/2*sx) + float64(sx)/2
+	centerY := float64(m.height/2*sy) + float64(sy)/2
+	tunnelRadius := 15.0 // 隧道半径
+
+	for z := 1.0; z < 50.0; z += 0.5 {
+		actualDepth := z + m.depth
+		if actualDepth > 50.0 {
+			actualDepth -= 50.0
+		}
+
+		if m.rand.Float64() > m.config.Density {
+			continue
 		}
+
+		angle := m.rand.Float64() * 2 * math.Pi
+		radius := tunnelRadius * (0.8 + m.rand.Float64()*0.4)
+		x := radius * math.Cos(angle)
+		y := radius * math.Sin(angle)
+
+		// 拖尾从当前位置伸向稍远处
+		near := 30.0 / actualDepth
+		far := 30.0 / (actualDepth + 1)
+		color := m.getColorByBrightness(1.0 / actualDepth)
+		m.canvas.Line(
+			int(centerX+x*near*float64(sx)), int(centerY+y*near*float64(sy)),
+			int(centerX+x*far*float64(sx)), int(centerY+y*far*float64(sy)),
+			color,
+		)
+	}
+	m.canvas.Draw(m.screen)
+}
+
+// SetClock 设置驱动帧循环的时钟
+func (m *MatrixTunnel) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		m.clock = clock
 	}
 }
 
+// Run 运行矩阵隧道特效
+func (m *MatrixTunnel) Run(quit <-chan struct{}) error {
+	return m.clock.RunVar(&m.config.FPS, quit, func(deltaTime float64) {
+		m.Update(deltaTime)
+		m.Render()
+	})
+}
+
 // Cleanup 清理资源
 func (m *MatrixTunnel) Cleanup() error {
 	return nil
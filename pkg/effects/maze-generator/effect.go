@@ -8,6 +8,7 @@ import (
 type MazeGeneratorEffect struct {
 	maze   *MazeGenerator
 	config *Config
+	clock  *effects.Clock
 }
 
 func NewEffect() effects.Effect {
@@ -43,8 +44,38 @@ func (e *MazeGeneratorEffect) Metadata() effects.Metadata {
 	}
 }
 
+func (e *MazeGeneratorEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+func (e *MazeGeneratorEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+func (e *MazeGeneratorEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Int(effects.Param{Name: "cell-size", Label: "单元格大小", LabelEN: "Cell size", Min: 2, Max: 6}, &e.config.CellSize)
+	ps.Int(effects.Param{Name: "speed", Label: "每帧步数", LabelEN: "Steps per frame", Min: 1, Max: 50}, &e.config.Speed)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+func (e *MazeGeneratorEffect) Reconfigure() {
+	if e.maze != nil {
+		e.maze.Reconfigure()
+	}
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *MazeGeneratorEffect) Resize(width, height int) {
+	if e.maze != nil {
+		e.maze.Resize(width, height)
+	}
+}
+
 func (e *MazeGeneratorEffect) Init(screen tcell.Screen) error {
 	e.maze = New(screen, e.config)
+	e.maze.SetClock(e.clock)
 	return e.maze.Init()
 }
 
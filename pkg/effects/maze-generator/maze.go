@@ -2,15 +2,16 @@ package mazegenerator
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 type Config struct {
 	CellSize int
 	Speed    int
 	FPS      int
+	Seed     int64
 }
 
 func DefaultConfig() *Config {
@@ -28,17 +29,20 @@ type Cell struct {
 }
 
 type MazeGenerator struct {
-	screen  tcell.Screen
-	config  *Config
-	cells   [][]*Cell
-	width   int
-	height  int
-	rows    int
-	cols    int
-	stack   []*Cell
-	current *Cell
-	done    bool
-	rand    *rand.Rand
+	screen   tcell.Screen
+	config   *Config
+	clock    *effects.Clock
+	cells    [][]*Cell
+	width    int
+	height   int
+	rows     int
+	cols     int
+	stack    []*Cell
+	current  *Cell
+	done     bool
+	rand     *rand.Rand
+	stepper  effects.Stepper
+	cellSize int // 生成当前迷宫时的单元格大小
 }
 
 func New(screen tcell.Screen, config *Config) *MazeGenerator {
@@ -47,14 +51,23 @@ func New(screen tcell.Screen, config *Config) *MazeGenerator {
 	}
 
 	return &MazeGenerator{
-		screen: screen,
-		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		screen:  screen,
+		config:  config,
+		clock:   effects.NewClock(),
+		rand:    effects.NewRand(config.Seed),
+		stepper: effects.Stepper{Rate: float64(config.FPS)},
 	}
 }
 
 func (m *MazeGenerator) Init() error {
 	m.width, m.height = m.screen.Size()
+	m.reset()
+	return nil
+}
+
+// reset 按当前尺寸重新开始生成迷宫
+func (m *MazeGenerator) reset() {
+	m.cellSize = m.config.CellSize
 	m.cols = m.width / m.config.CellSize
 	m.rows = m.height / m.config.CellSize
 
@@ -72,12 +85,50 @@ func (m *MazeGenerator) Init() error {
 		}
 	}
 
+	m.stack = []*Cell{}
+	if m.rows == 0 || m.cols == 0 {
+		// 屏幕放不下一个单元格，等尺寸变大后再生成
+		m.current = nil
+		m.done = true
+		return
+	}
 	m.current = m.cells[0][0]
 	m.current.visited = true
-	m.stack = []*Cell{}
 	m.done = false
+}
 
-	return nil
+// Resize 调整尺寸
+// 变大时保留已生成的部分，新增的单元在原有迷宫挖掘完后接入；变小时裁剪后的迷宫可能不再连通，因此重新生成
+func (m *MazeGenerator) Resize(width, height int) {
+	m.width, m.height = width, height
+	cols, rows := width/m.cellSize, height/m.cellSize
+	if cols < m.cols || rows < m.rows || m.current == nil {
+		m.reset()
+		return
+	}
+	if cols == m.cols && rows == m.rows {
+		return
+	}
+
+	for y := 0; y < rows; y++ {
+		if y == len(m.cells) {
+			m.cells = append(m.cells, nil)
+		}
+		for x := len(m.cells[y]); x < cols; x++ {
+			m.cells[y] = append(m.cells[y], &Cell{x: x, y: y, walls: [4]bool{true, true, true, true}})
+		}
+	}
+	m.cols, m.rows = cols, rows
+	m.done = false
+}
+
+func (m *MazeGenerator) Reconfigure() {
+	m.stepper.Rate = float64(m.config.FPS)
+
+	// 单元格大小变化时重新生成迷宫
+	if m.cellSize != m.config.CellSize {
+		m.Init()
+	}
 }
 
 func (m *MazeGenerator) getUnvisitedNeighbor(cell *Cell) *Cell {
@@ -106,6 +157,28 @@ func (m *MazeGenerator) getUnvisitedNeighbor(cell *Cell) *Cell {
 	return nil
 }
 
+// hunt 寻找与已访问单元相邻的未访问单元，打通两者之间的墙壁后作为新的起点
+// 一次完整的深度优先搜索会访问所有单元，只有尺寸变大后新增的单元需要由此接入
+func (m *MazeGenerator) hunt() *Cell {
+	for y := 0; y < m.rows; y++ {
+		for x := 0; x < m.cols; x++ {
+			cell := m.cells[y][x]
+			if cell.visited {
+				continue
+			}
+			for _, d := range [][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+				nx, ny := x+d[0], y+d[1]
+				if nx >= 0 && nx < m.cols && ny >= 0 && ny < m.rows && m.cells[ny][nx].visited {
+					m.removeWalls(m.cells[ny][nx], cell)
+					cell.visited = true
+					return cell
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (m *MazeGenerator) removeWalls(current, next *Cell) {
 	dx := next.x - current.x
 	dy := next.y - current.y
@@ -125,7 +198,14 @@ func (m *MazeGenerator) removeWalls(current, next *Cell) {
 	}
 }
 
-func (m *MazeGenerator) Update() {
+func (m *MazeGenerator) Update(deltaTime float64) {
+	// 每秒推进 FPS 步，每步挖掘 Speed 个单元
+	for n := m.stepper.Steps(deltaTime); n > 0; n-- {
+		m.step()
+	}
+}
+
+func (m *MazeGenerator) step() {
 	if m.done {
 		return
 	}
@@ -141,6 +221,8 @@ func (m *MazeGenerator) Update() {
 		} else if len(m.stack) > 0 {
 			m.current = m.stack[len(m.stack)-1]
 			m.stack = m.stack[:len(m.stack)-1]
+		} else if cell := m.hunt(); cell != nil {
+			m.current = cell
 		} else {
 			m.done = true
 		}
@@ -190,21 +272,19 @@ func (m *MazeGenerator) drawCell(cell *Cell) {
 	}
 }
 
-func (m *MazeGenerator) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(m.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			m.Update()
-			m.Render()
-		}
+func (m *MazeGenerator) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		m.clock = clock
 	}
 }
 
+func (m *MazeGenerator) Run(quit <-chan struct{}) error {
+	return m.clock.RunVar(&m.config.FPS, quit, func(deltaTime float64) {
+		m.Update(deltaTime)
+		m.Render()
+	})
+}
+
 func (m *MazeGenerator) Cleanup() error {
 	return nil
 }
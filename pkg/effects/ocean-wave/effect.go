@@ -9,6 +9,7 @@ import (
 type OceanWaveEffect struct {
 	ocean  *OceanWave
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建字符海浪特效实例
@@ -47,9 +48,37 @@ func (e *OceanWaveEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *OceanWaveEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *OceanWaveEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *OceanWaveEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "speed", Label: "波浪速度", LabelEN: "Wave speed", Min: 0.1, Max: 10, Step: 0.1}, &e.config.WaveSpeed)
+	ps.Float(effects.Param{Name: "height", Label: "波浪高度", LabelEN: "Wave height", Min: 1, Max: 10, Step: 0.5}, &e.config.WaveHeight)
+	ps.Int(effects.Param{Name: "layers", Label: "波浪层数", LabelEN: "Layers", Min: 1, Max: 6}, &e.config.NumLayers)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *OceanWaveEffect) Resize(width, height int) {
+	if e.ocean != nil {
+		e.ocean.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *OceanWaveEffect) Init(screen tcell.Screen) error {
 	e.ocean = New(screen, e.config)
+	e.ocean.SetClock(e.clock)
 	return e.ocean.Init()
 }
 
@@ -3,9 +3,9 @@ package oceanwave
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 字符海浪配置
@@ -14,6 +14,7 @@ type Config struct {
 	WaveHeight float64 // 波浪高度
 	NumLayers  int     // 波浪层数
 	FPS        int     // 帧率
+	Seed       int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -30,10 +31,10 @@ func DefaultConfig() *Config {
 type OceanWave struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	phase      float64
 	width      int
 	height     int
-	lastUpdate time.Time
 	rand       *rand.Rand
 }
 
@@ -46,7 +47,8 @@ func New(screen tcell.Screen, config *Config) *OceanWave {
 	return &OceanWave{
 		screen: screen,
 		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:  effects.NewClock(),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -54,10 +56,14 @@ func New(screen tcell.Screen, config *Config) *OceanWave {
 func (o *OceanWave) Init() error {
 	o.width, o.height = o.screen.Size()
 	o.phase = 0
-	o.lastUpdate = time.Now()
 	return nil
 }
 
+// Resize 调整尺寸，海平面保持在屏幕三分之二的高度
+func (o *OceanWave) Resize(width, height int) {
+	o.width, o.height = width, height
+}
+
 // Update 更新字符海浪状态
 func (o *OceanWave) Update(deltaTime float64) {
 	o.phase += o.config.WaveSpeed * deltaTime
@@ -125,24 +131,19 @@ func (o *OceanWave) Render() {
 	o.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (o *OceanWave) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		o.clock = clock
+	}
+}
+
 // Run 运行字符海浪特效
 func (o *OceanWave) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(o.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(o.lastUpdate).Seconds()
-			o.lastUpdate = now
-
-			o.Update(deltaTime)
-			o.Render()
-		}
-	}
+	return o.clock.RunVar(&o.config.FPS, quit, func(deltaTime float64) {
+		o.Update(deltaTime)
+		o.Render()
+	})
 }
 
 // Cleanup 清理资源
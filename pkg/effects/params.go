@@ -0,0 +1,443 @@
+package effects
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ParamType 参数类型
+type ParamType string
+
+const (
+	ParamInt    ParamType = "int"    // 整数，值为 int
+	ParamFloat  ParamType = "float"  // 浮点数，值为 float64
+	ParamBool   ParamType = "bool"   // 布尔值，值为 bool
+	ParamString ParamType = "string" // 文本，值为 string
+	ParamEnum   ParamType = "enum"   // 枚举，值为 Options 中的 string
+	ParamColor  ParamType = "color"  // 颜色，值为颜色名或 #rrggbb 形式的 string
+)
+
+// Param 特效参数描述
+type Param struct {
+	Name    string    // 参数名（kebab-case），用于命令行和配置文件
+	Type    ParamType // 参数类型（由 ParamSet 的绑定方法设置）
+	Label   string    // 显示名称（中文）
+	LabelEN string    // 显示名称（英文）
+	Min     float64   // 最小值（数值类型；Min 与 Max 相等时不限制范围）
+	Max     float64   // 最大值（数值类型）
+	Step    float64   // 界面调节步长（数值类型，0 表示自动）
+	Options []string  // 可选值（枚举类型）
+}
+
+// Ranged 判断数值参数是否有取值范围
+func (p Param) Ranged() bool {
+	return p.Min != p.Max
+}
+
+// StepSize 返回界面调节步长
+// 未指定时整数为 1，浮点数为范围的 1/20（无范围时为 0.1）
+func (p Param) StepSize() float64 {
+	if p.Step > 0 {
+		return p.Step
+	}
+	if p.Type == ParamInt {
+		return 1
+	}
+	if p.Ranged() {
+		return (p.Max - p.Min) / 20
+	}
+	return 0.1
+}
+
+// Normalize 把任意形式的值（如命令行字符串、JSON 数字）转换为参数的标准类型并校验
+func (p Param) Normalize(value any) (any, error) {
+	switch p.Type {
+	case ParamInt:
+		n, err := toFloat(value)
+		if err != nil || n != math.Trunc(n) {
+			return nil, fmt.Errorf("参数 %s 需要整数: %v", p.Name, value)
+		}
+		if err := p.checkRange(n); err != nil {
+			return nil, err
+		}
+		return int(n), nil
+
+	case ParamFloat:
+		n, err := toFloat(value)
+		if err != nil {
+			return nil, fmt.Errorf("参数 %s 需要数字: %v", p.Name, value)
+		}
+		if err := p.checkRange(n); err != nil {
+			return nil, err
+		}
+		return n, nil
+
+	case ParamBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("参数 %s 需要布尔值: %v", p.Name, value)
+
+	case ParamString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("参数 %s 需要文本: %v", p.Name, value)
+		}
+		return s, nil
+
+	case ParamEnum:
+		s, ok := value.(string)
+		if ok {
+			for _, option := range p.Options {
+				if strings.EqualFold(option, strings.TrimSpace(s)) {
+					return option, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("参数 %s 的可选值为 %s: %v", p.Name, strings.Join(p.Options, ", "), value)
+
+	case ParamColor:
+		s, ok := value.(string)
+		if ok {
+			s = strings.ToLower(strings.TrimSpace(s))
+			if tcell.GetColor(s) != tcell.ColorDefault {
+				return s, nil
+			}
+		}
+		return nil, fmt.Errorf("参数 %s 需要颜色名或 #rrggbb: %v", p.Name, value)
+	}
+
+	return nil, fmt.Errorf("参数 %s 的类型未知: %s", p.Name, p.Type)
+}
+
+// checkRange 检查数值是否在范围内
+func (p Param) checkRange(n float64) error {
+	if p.Ranged() && (n < p.Min || n > p.Max) {
+		return fmt.Errorf("参数 %s 超出范围 [%s, %s]: %s",
+			p.Name, FormatParamValue(p.Min), FormatParamValue(p.Max), FormatParamValue(n))
+	}
+	return nil
+}
+
+// toFloat 把数字或数字字符串转换为 float64
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	return 0, fmt.Errorf("不是数字: %v", value)
+}
+
+// FormatParamValue 把参数值格式化为便于显示和在命令行中使用的文本
+func FormatParamValue(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// paramBinding 参数与配置字段之间的读写绑定
+type paramBinding struct {
+	get func() any
+	set func(value any)
+}
+
+// ParamSet 绑定到特效配置的参数集合
+// 特效在 Params 方法中把参数绑定到自身 Config 的字段，宿主通过名称读写参数。
+type ParamSet struct {
+	params   []Param
+	bindings map[string]paramBinding
+}
+
+// NewParamSet 创建空的参数集合
+func NewParamSet() *ParamSet {
+	return &ParamSet{bindings: make(map[string]paramBinding)}
+}
+
+// Bind 使用自定义的读写函数绑定参数
+// get 返回的值与 set 接收的值均为参数类型对应的标准类型
+func (s *ParamSet) Bind(p Param, get func() any, set func(value any)) {
+	if _, exists := s.bindings[p.Name]; exists {
+		panic(fmt.Sprintf("参数 '%s' 重复绑定", p.Name))
+	}
+	s.params = append(s.params, p)
+	s.bindings[p.Name] = paramBinding{get: get, set: set}
+}
+
+// Int 绑定整数参数
+func (s *ParamSet) Int(p Param, ptr *int) {
+	p.Type = ParamInt
+	s.Bind(p, func() any { return *ptr }, func(v any) { *ptr = v.(int) })
+}
+
+// Float 绑定浮点数参数
+func (s *ParamSet) Float(p Param, ptr *float64) {
+	p.Type = ParamFloat
+	s.Bind(p, func() any { return *ptr }, func(v any) { *ptr = v.(float64) })
+}
+
+// Bool 绑定布尔参数
+func (s *ParamSet) Bool(p Param, ptr *bool) {
+	p.Type = ParamBool
+	s.Bind(p, func() any { return *ptr }, func(v any) { *ptr = v.(bool) })
+}
+
+// String 绑定文本参数
+func (s *ParamSet) String(p Param, ptr *string) {
+	p.Type = ParamString
+	s.Bind(p, func() any { return *ptr }, func(v any) { *ptr = v.(string) })
+}
+
+// Color 绑定颜色参数
+func (s *ParamSet) Color(p Param, ptr *tcell.Color) {
+	p.Type = ParamColor
+	s.Bind(p,
+		func() any { return colorName(*ptr) },
+		func(v any) { *ptr = tcell.GetColor(v.(string)) })
+}
+
+// FPS 绑定标准的帧率参数
+func (s *ParamSet) FPS(ptr *int) {
+	s.Int(Param{Name: "fps", Label: "帧率", LabelEN: "FPS", Min: 1, Max: 120}, ptr)
+}
+
+// Enum 绑定枚举参数，p.Options 中的名称与 values 按顺序一一对应
+func Enum[T comparable](s *ParamSet, p Param, ptr *T, values ...T) {
+	if len(p.Options) != len(values) {
+		panic(fmt.Sprintf("参数 '%s' 的可选值与取值数量不一致", p.Name))
+	}
+
+	p.Type = ParamEnum
+	s.Bind(p,
+		func() any {
+			for i, v := range values {
+				if v == *ptr {
+					return p.Options[i]
+				}
+			}
+			return ""
+		},
+		func(v any) {
+			for i, option := range p.Options {
+				if option == v.(string) {
+					*ptr = values[i]
+					return
+				}
+			}
+		})
+}
+
+// colorName 返回颜色的名称（有多个名称时取最短的），没有名称时返回 #rrggbb
+func colorName(c tcell.Color) string {
+	best := ""
+	for name, value := range tcell.ColorNames {
+		if value != c {
+			continue
+		}
+		if best == "" || len(name) < len(best) || (len(name) == len(best) && name < best) {
+			best = name
+		}
+	}
+
+	if best == "" {
+		return strings.ToLower(c.CSS())
+	}
+	return best
+}
+
+// Params 返回所有参数描述（按绑定顺序）
+func (s *ParamSet) Params() []Param {
+	return append([]Param(nil), s.params...)
+}
+
+// Lookup 按名称查找参数描述
+func (s *ParamSet) Lookup(name string) (Param, bool) {
+	for _, p := range s.params {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Param{}, false
+}
+
+// Get 读取参数的当前值
+func (s *ParamSet) Get(name string) (any, error) {
+	binding, ok := s.bindings[name]
+	if !ok {
+		return nil, fmt.Errorf("未知参数: %s", name)
+	}
+	return binding.get(), nil
+}
+
+// Set 校验并设置参数值，value 可以是标准类型，也可以是命令行字符串或 JSON 数字
+func (s *ParamSet) Set(name string, value any) error {
+	p, ok := s.Lookup(name)
+	if !ok {
+		return fmt.Errorf("未知参数: %s", name)
+	}
+
+	normalized, err := p.Normalize(value)
+	if err != nil {
+		return err
+	}
+
+	s.bindings[name].set(normalized)
+	return nil
+}
+
+// Values 返回所有参数的当前值
+func (s *ParamSet) Values() map[string]any {
+	values := make(map[string]any, len(s.params))
+	for _, p := range s.params {
+		values[p.Name] = s.bindings[p.Name].get()
+	}
+	return values
+}
+
+// Configurable 可选接口：通过统一的参数描述暴露特效配置
+// 宿主（命令行、配置文件、设置界面）借此调整任意特效，而无需了解其 Config 结构
+type Configurable interface {
+	// Params 返回绑定到特效配置的参数集合，须在 Init 之前设置参数
+	Params() *ParamSet
+}
+
+// ParamsOf 返回特效的参数集合，特效未实现 Configurable 时返回 nil
+func ParamsOf(effect Effect) *ParamSet {
+	configurable, ok := effect.(Configurable)
+	if !ok {
+		return nil
+	}
+	return configurable.Params()
+}
+
+// ApplyParams 批量设置特效参数
+// 逐个设置所有参数，返回合并后的错误；values 为空时不做任何处理
+func ApplyParams(effect Effect, values map[string]any) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	ps := ParamsOf(effect)
+	if ps == nil {
+		return fmt.Errorf("特效 %s 不支持参数配置", effect.Metadata().ID)
+	}
+
+	var errs []error
+	for _, p := range ps.Params() {
+		if value, ok := values[p.Name]; ok {
+			if err := ps.Set(p.Name, value); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	errs = append(errs, unknownParams(ps, values)...)
+
+	return errors.Join(errs...)
+}
+
+// ApplySavedParams 应用配置文件或设置界面保存的参数，prefix 非空时参数名为 prefix.name（场景中的图层）
+// 特效的参数定义变化后旧值可能失效，无法应用的值被忽略；ps 为 nil 时不做任何处理
+func ApplySavedParams(ps *ParamSet, values map[string]any, prefix string) {
+	if ps == nil {
+		return
+	}
+	for name, value := range values {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		ps.Set(name, value)
+	}
+}
+
+// unknownParams 返回 values 中未知参数的错误（按名称排序）
+func unknownParams(ps *ParamSet, values map[string]any) []error {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		if _, ok := ps.Lookup(name); !ok {
+			errs = append(errs, fmt.Errorf("未知参数: %s", name))
+		}
+	}
+	return errs
+}
+
+// Reconfigurable 可选接口：运行中修改参数后需要重新计算内部状态的特效
+// 只在帧回调中读取配置字段的特效无需实现，参数修改后下一帧即生效
+type Reconfigurable interface {
+	// Reconfigure 在运行中的参数被修改后调用，宿主保证它与帧回调互斥
+	Reconfigure()
+}
+
+// Reconfigure 修改运行中特效的参数
+// 在两帧之间设置与当前值不同的参数，然后通知特效重新计算内部状态（特效实现 Reconfigurable 时）。
+// clock 为 nil 时直接修改。返回被修改的参数名和合并后的错误，合法的参数仍会生效
+func Reconfigure(effect Effect, clock *Clock, values map[string]any) ([]string, error) {
+	ps := ParamsOf(effect)
+	if ps == nil {
+		return nil, fmt.Errorf("特效 %s 不支持参数配置", effect.Metadata().ID)
+	}
+
+	var changed []string
+	var errs []error
+	apply := func() {
+		for _, p := range ps.Params() {
+			value, ok := values[p.Name]
+			if !ok {
+				continue
+			}
+
+			normalized, err := p.Normalize(value)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if current, _ := ps.Get(p.Name); current == normalized {
+				continue
+			}
+
+			ps.Set(p.Name, normalized)
+			changed = append(changed, p.Name)
+		}
+
+		if reconfigurable, ok := effect.(Reconfigurable); ok && len(changed) > 0 {
+			reconfigurable.Reconfigure()
+		}
+	}
+
+	if clock != nil {
+		clock.Do(apply)
+	} else {
+		apply()
+	}
+
+	errs = append(errs, unknownParams(ps, values)...)
+	return changed, errors.Join(errs...)
+}
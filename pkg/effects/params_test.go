@@ -0,0 +1,183 @@
+package effects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type paramsConfig struct {
+	Count int
+	Rate  float64
+	On    bool
+	Color tcell.Color
+	Mode  int
+}
+
+func newTestParamSet(c *paramsConfig) *ParamSet {
+	ps := NewParamSet()
+	ps.Int(Param{Name: "count", Min: 1, Max: 10}, &c.Count)
+	ps.Float(Param{Name: "rate", Min: 0, Max: 2}, &c.Rate)
+	ps.Bool(Param{Name: "on"}, &c.On)
+	ps.Color(Param{Name: "color"}, &c.Color)
+	Enum(ps, Param{Name: "mode", Options: []string{"slow", "fast"}}, &c.Mode, 1, 5)
+	return ps
+}
+
+func TestParamSetSet(t *testing.T) {
+	c := &paramsConfig{Count: 3, Mode: 1, Color: tcell.ColorRed}
+	ps := newTestParamSet(c)
+
+	for name, value := range map[string]any{
+		"count": "7",
+		"rate":  1.5,
+		"on":    "true",
+		"color": "Green",
+		"mode":  "FAST",
+	} {
+		if err := ps.Set(name, value); err != nil {
+			t.Errorf("Set(%s): %v", name, err)
+		}
+	}
+
+	want := paramsConfig{Count: 7, Rate: 1.5, On: true, Color: tcell.ColorGreen, Mode: 5}
+	if *c != want {
+		t.Errorf("Expected %+v, got %+v", want, *c)
+	}
+
+	values := ps.Values()
+	if values["mode"] != "fast" || values["color"] != "green" || values["count"] != 7 {
+		t.Errorf("Unexpected values: %v", values)
+	}
+}
+
+func TestParamSetRejects(t *testing.T) {
+	c := &paramsConfig{Count: 3, Mode: 1}
+	ps := newTestParamSet(c)
+
+	for name, value := range map[string]any{
+		"count": "2.5",
+		"rate":  "3",
+		"on":    "maybe",
+		"color": "no-such-color",
+		"mode":  "warp",
+		"nope":  "1",
+	} {
+		if err := ps.Set(name, value); err == nil {
+			t.Errorf("Set(%s, %v) should fail", name, value)
+		}
+	}
+
+	if c.Count != 3 || c.Mode != 1 {
+		t.Errorf("Rejected values must not change the config: %+v", *c)
+	}
+}
+
+func TestApplyParamsJoinsErrors(t *testing.T) {
+	c := &paramsConfig{}
+	effect := &configurableEffect{ps: newTestParamSet(c)}
+
+	err := ApplyParams(effect, map[string]any{"count": 20, "rate": 0.5, "zzz": 1, "aaa": 1})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	msg := err.Error()
+	for _, part := range []string{"count", "未知参数: aaa", "未知参数: zzz"} {
+		if !strings.Contains(msg, part) {
+			t.Errorf("Expected %q in error: %s", part, msg)
+		}
+	}
+	if c.Rate != 0.5 {
+		t.Errorf("Valid params should still be applied, rate = %v", c.Rate)
+	}
+}
+
+func TestApplySavedParams(t *testing.T) {
+	c := &paramsConfig{Count: 3}
+	ps := newTestParamSet(c)
+
+	// 过时的参数名与越界的值被忽略，其余照常应用
+	ApplySavedParams(ps, map[string]any{"count": 20, "rate": 0.5, "removed": 1}, "")
+	if c.Count != 3 || c.Rate != 0.5 {
+		t.Errorf("Expected count 3 and rate 0.5, got %+v", *c)
+	}
+
+	layer := &paramsConfig{}
+	scene := NewParamSet()
+	scene.Int(Param{Name: "top.count", Min: 1, Max: 10}, &layer.Count)
+	ApplySavedParams(scene, map[string]any{"count": 4}, "top")
+	if layer.Count != 4 {
+		t.Errorf("Expected prefixed count 4, got %d", layer.Count)
+	}
+
+	ApplySavedParams(nil, map[string]any{"count": 4}, "")
+}
+
+func TestReconfigure(t *testing.T) {
+	c := &paramsConfig{Count: 3}
+	effect := &reconfigurableEffect{configurableEffect: configurableEffect{ps: newTestParamSet(c)}}
+
+	changed, err := Reconfigure(effect, NewClock(), map[string]any{"count": "3", "rate": 1.5, "bogus": 1})
+	if err == nil || !strings.Contains(err.Error(), "未知参数: bogus") {
+		t.Errorf("Expected unknown param error, got %v", err)
+	}
+	if len(changed) != 1 || changed[0] != "rate" {
+		t.Errorf("Expected only rate to change, got %v", changed)
+	}
+	if c.Rate != 1.5 || effect.reconfigured != 1 {
+		t.Errorf("rate = %v, reconfigured = %d", c.Rate, effect.reconfigured)
+	}
+
+	// 值未变化时不通知特效
+	if changed, _ := Reconfigure(effect, nil, map[string]any{"rate": 1.5}); len(changed) != 0 {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+	if effect.reconfigured != 1 {
+		t.Errorf("Reconfigure should not be called without changes")
+	}
+}
+
+func TestRunVarFollowsFPS(t *testing.T) {
+	clock := NewClockWithMode(ClockSimulated)
+	fps := 30
+	quit := make(chan struct{})
+
+	var steps []float64
+	clock.RunVar(&fps, quit, func(deltaTime float64) {
+		steps = append(steps, deltaTime)
+		switch len(steps) {
+		case 2:
+			fps = 10
+		case 4:
+			close(quit)
+		}
+	})
+
+	want := []float64{1.0 / 30, 1.0 / 30, 1.0 / 10, 1.0 / 10}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Fatalf("Expected steps %v, got %v", want, steps)
+		}
+	}
+}
+
+// configurableEffect 仅用于测试参数设置的特效
+type configurableEffect struct {
+	ps *ParamSet
+}
+
+func (e *configurableEffect) Metadata() Metadata             { return Metadata{ID: "test"} }
+func (e *configurableEffect) Init(screen tcell.Screen) error { return nil }
+func (e *configurableEffect) Run(quit <-chan struct{}) error { return nil }
+func (e *configurableEffect) Cleanup() error                 { return nil }
+func (e *configurableEffect) Params() *ParamSet              { return e.ps }
+
+// reconfigurableEffect 记录 Reconfigure 调用次数的特效
+type reconfigurableEffect struct {
+	configurableEffect
+	reconfigured int
+}
+
+func (e *reconfigurableEffect) Reconfigure() { e.reconfigured++ }
@@ -6,8 +6,10 @@ import (
 )
 
 type ParticleBurstEffect struct {
-	burst  *ParticleBurst
-	config *Config
+	burst   *ParticleBurst
+	config  *Config
+	clock   *effects.Clock
+	buttons effects.MouseButtons // 鼠标按键状态
 }
 
 func NewEffect() effects.Effect {
@@ -44,11 +46,42 @@ func (e *ParticleBurstEffect) Metadata() effects.Metadata {
 	}
 }
 
+func (e *ParticleBurstEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+func (e *ParticleBurstEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+func (e *ParticleBurstEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "interval", Label: "爆炸间隔（秒）", LabelEN: "Burst interval (s)", Min: 0.1, Max: 10, Step: 0.1}, &e.config.BurstInterval)
+	ps.Int(effects.Param{Name: "particles", Label: "粒子数量", LabelEN: "Particles", Min: 10, Max: 500, Step: 10}, &e.config.ParticleCount)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *ParticleBurstEffect) Resize(width, height int) {
+	if e.burst != nil {
+		e.burst.Resize(width, height)
+	}
+}
+
 func (e *ParticleBurstEffect) Init(screen tcell.Screen) error {
 	e.burst = New(screen, e.config)
+	e.burst.SetClock(e.clock)
 	return e.burst.Init()
 }
 
+// HandleMouse 点击处产生一次爆炸（实现 effects.MouseHandler 接口）
+func (e *ParticleBurstEffect) HandleMouse(event *tcell.EventMouse) {
+	if e.burst != nil && e.buttons.Pressed(event)&tcell.Button1 != 0 {
+		e.burst.BurstAt(event.Position())
+	}
+}
+
 func (e *ParticleBurstEffect) Run(quit <-chan struct{}) error {
 	return e.burst.Run(quit)
 }
@@ -3,15 +3,16 @@ package particleburst
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 type Config struct {
 	BurstInterval float64 // 爆炸间隔（秒）
 	ParticleCount int     // 每次爆炸的粒子数
 	FPS           int
+	Seed          int64
 }
 
 func DefaultConfig() *Config {
@@ -34,6 +35,7 @@ type Particle struct {
 type ParticleBurst struct {
 	screen    tcell.Screen
 	config    *Config
+	clock     *effects.Clock
 	particles []*Particle
 	width     int
 	height    int
@@ -49,8 +51,9 @@ func New(screen tcell.Screen, config *Config) *ParticleBurst {
 	return &ParticleBurst{
 		screen:    screen,
 		config:    config,
+		clock:     effects.NewClock(),
 		particles: make([]*Particle, 0),
-		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:      effects.NewRand(config.Seed),
 	}
 }
 
@@ -62,8 +65,14 @@ func (p *ParticleBurst) Init() error {
 
 func (p *ParticleBurst) createBurst() {
 	// 随机爆炸位置
-	centerX := float64(p.rand.Intn(p.width))
-	centerY := float64(p.rand.Intn(p.height))
+	centerX := p.rand.Intn(p.width)
+	centerY := p.rand.Intn(p.height)
+	p.BurstAt(centerX, centerY)
+}
+
+// BurstAt 在 (x, y) 处产生一次爆炸
+func (p *ParticleBurst) BurstAt(x, y int) {
+	centerX, centerY := float64(x), float64(y)
 
 	colors := []tcell.Color{
 		tcell.ColorRed, tcell.ColorYellow, tcell.ColorOrange,
@@ -90,6 +99,11 @@ func (p *ParticleBurst) createBurst() {
 	}
 }
 
+// Resize 调整尺寸，已有的粒子继续运动，离开屏幕后自然消失
+func (p *ParticleBurst) Resize(width, height int) {
+	p.width, p.height = width, height
+}
+
 func (p *ParticleBurst) Update(deltaTime float64) {
 	p.timeSinceBurst += deltaTime
 
@@ -147,27 +161,19 @@ func (p *ParticleBurst) Render() {
 	p.screen.Show()
 }
 
-func (p *ParticleBurst) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(p.config.FPS))
-	defer ticker.Stop()
-
-	lastUpdate := time.Now()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(lastUpdate).Seconds()
-			lastUpdate = now
-
-			p.Update(deltaTime)
-			p.Render()
-		}
+func (p *ParticleBurst) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		p.clock = clock
 	}
 }
 
+func (p *ParticleBurst) Run(quit <-chan struct{}) error {
+	return p.clock.RunVar(&p.config.FPS, quit, func(deltaTime float64) {
+		p.Update(deltaTime)
+		p.Render()
+	})
+}
+
 func (p *ParticleBurst) Cleanup() error {
 	return nil
 }
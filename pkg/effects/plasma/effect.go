@@ -2,12 +2,15 @@ package plasma
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
 	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 type PlasmaEffect struct {
 	plasma *Plasma
 	config *Config
+	clock  *effects.Clock
 }
 
 func NewEffect() effects.Effect {
@@ -32,6 +35,7 @@ Plasma 等离子特效使用数学函数生成美丽的等离子云图案。
 - 平滑动画
 - 数学艺术
 - 30 FPS 流畅运行
+- 可用半格或盲文点阵细分像素（render=half、render=braille），精度提高 2~8 倍
 
 完美用于：
 - 数学可视化
@@ -44,8 +48,36 @@ Plasma 等离子特效使用数学函数生成美丽的等离子云图案。
 	}
 }
 
+// SetPalette 设置配色（实现 effects.Paletted 接口）
+func (e *PlasmaEffect) SetPalette(p *palette.Palette) {
+	e.config.Palette = p
+}
+
+func (e *PlasmaEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+func (e *PlasmaEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "speed", Label: "变化速度", LabelEN: "Speed", Min: 0.1, Max: 5, Step: 0.1}, &e.config.Speed)
+	effects.Enum(ps, effects.Param{
+		Name: "render", Label: "渲染精度", LabelEN: "Rendering",
+		Options: []string{"cell", "half", "braille"},
+	}, &e.config.Render, canvas.Modes...)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *PlasmaEffect) Resize(width, height int) {
+	if e.plasma != nil {
+		e.plasma.Resize(width, height)
+	}
+}
+
 func (e *PlasmaEffect) Init(screen tcell.Screen) error {
 	e.plasma = New(screen, e.config)
+	e.plasma.SetClock(e.clock)
 	return e.plasma.Init()
 }
 
@@ -2,31 +2,41 @@ package plasma
 
 import (
 	"math"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 type Config struct {
-	Speed float64
-	FPS   int
+	Speed   float64
+	FPS     int
+	Render  canvas.Mode      // 渲染精度：每格一个字符，或用半格、盲文点阵细分像素
+	Palette *palette.Palette // 配色，nil 时使用内置的七种颜色
 }
 
 func DefaultConfig() *Config {
 	return &Config{
-		Speed: 1.0,
-		FPS:   30,
+		Speed:  1.0,
+		FPS:    30,
+		Render: canvas.ModeCell,
 	}
 }
 
+// bayer 盲文点阵的有序抖动阈值，按 [列][行] 索引
+var bayer = [2][4]float64{{0, 4, 2, 6}, {3, 7, 1, 5}}
+
 type Plasma struct {
 	screen tcell.Screen
 	config *Config
+	clock  *effects.Clock
 	width  int
 	height int
 	time   float64
 	chars  []rune
 	colors []tcell.Color
+	canvas *canvas.Canvas // 细分像素渲染时使用
 }
 
 func New(screen tcell.Screen, config *Config) *Plasma {
@@ -34,9 +44,10 @@ func New(screen tcell.Screen, config *Config) *Plasma {
 		config = DefaultConfig()
 	}
 
-	return &Plasma{
+	p := &Plasma{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		chars:  []rune{' ', '░', '▒', '▓', '█'},
 		colors: []tcell.Color{
 			tcell.ColorBlue,
@@ -48,6 +59,10 @@ func New(screen tcell.Screen, config *Config) *Plasma {
 			tcell.ColorPurple,
 		},
 	}
+	if config.Palette != nil {
+		p.colors = config.Palette.Colors(len(p.colors))
+	}
+	return p
 }
 
 func (p *Plasma) Init() error {
@@ -56,14 +71,24 @@ func (p *Plasma) Init() error {
 	return nil
 }
 
+// Resize 调整尺寸，等离子图案按屏幕比例伸缩
+func (p *Plasma) Resize(width, height int) {
+	p.width, p.height = width, height
+	if p.canvas != nil {
+		p.canvas.Resize(width, height)
+	}
+}
+
 func (p *Plasma) Update(deltaTime float64) {
 	p.time += deltaTime * p.config.Speed
 }
 
 func (p *Plasma) plasmaValue(x, y int) float64 {
-	fx := float64(x) / float64(p.width)
-	fy := float64(y) / float64(p.height)
+	return p.value(float64(x)/float64(p.width), float64(y)/float64(p.height))
+}
 
+// value 返回屏幕相对坐标 (fx, fy) 处 0~1 的等离子值
+func (p *Plasma) value(fx, fy float64) float64 {
 	// 多层正弦波叠加
 	v := 0.0
 	v += math.Sin((fx*10 + p.time))
@@ -81,6 +106,12 @@ func (p *Plasma) plasmaValue(x, y int) float64 {
 func (p *Plasma) Render() {
 	p.screen.Clear()
 
+	if p.config.Render != canvas.ModeCell && p.config.Render != "" {
+		p.renderCanvas()
+		p.screen.Show()
+		return
+	}
+
 	for y := 0; y < p.height; y++ {
 		for x := 0; x < p.width; x++ {
 			value := p.plasmaValue(x, y)
@@ -107,25 +138,54 @@ func (p *Plasma) Render() {
 	p.screen.Show()
 }
 
-func (p *Plasma) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(p.config.FPS))
-	defer ticker.Stop()
-
-	lastUpdate := time.Now()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(lastUpdate).Seconds()
-			lastUpdate = now
-
-			p.Update(deltaTime)
-			p.Render()
+// renderCanvas 按像素计算等离子值：半格模式在调色板的颜色之间平滑过渡，
+// 盲文模式用有序抖动让点的疏密表现强弱
+func (p *Plasma) renderCanvas() {
+	if p.canvas == nil || p.canvas.Mode() != p.config.Render {
+		p.canvas = canvas.New(p.config.Render, p.width, p.height)
+	}
+	p.canvas.Clear()
+
+	w, h := p.canvas.Size()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			value := p.value(float64(x)/float64(w), float64(y)/float64(h))
+			if p.config.Render == canvas.ModeBraille {
+				if value*8 > bayer[x%2][y%4]+0.5 {
+					p.canvas.Set(x, y, p.colors[min(int(value*float64(len(p.colors))), len(p.colors)-1)])
+				}
+				continue
+			}
+			p.canvas.Set(x, y, p.gradient(value))
 		}
 	}
+	p.canvas.Draw(p.screen)
+}
+
+// gradient 在调色板相邻的两种颜色之间按 value 插值
+func (p *Plasma) gradient(value float64) tcell.Color {
+	pos := max(0, min(1, value)) * float64(len(p.colors)-1)
+	i := min(int(pos), len(p.colors)-2)
+	t := pos - float64(i)
+	r0, g0, b0 := p.colors[i].RGB()
+	r1, g1, b1 := p.colors[i+1].RGB()
+	lerp := func(a, b int32) int32 {
+		return a + int32(float64(b-a)*t)
+	}
+	return tcell.NewRGBColor(lerp(r0, r1), lerp(g0, g1), lerp(b0, b1))
+}
+
+func (p *Plasma) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		p.clock = clock
+	}
+}
+
+func (p *Plasma) Run(quit <-chan struct{}) error {
+	return p.clock.RunVar(&p.config.FPS, quit, func(deltaTime float64) {
+		p.Update(deltaTime)
+		p.Render()
+	})
 }
 
 func (p *Plasma) Cleanup() error {
@@ -1,6 +1,8 @@
 package qrcodegen
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/symbolmove/symbol_move/pkg/effects"
 )
@@ -9,6 +11,7 @@ import (
 type QRCodeGenEffect struct {
 	qrcode *QRCodeGen
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建二维码动画特效实例
@@ -47,9 +50,40 @@ func (e *QRCodeGenEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *QRCodeGenEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *QRCodeGenEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "interval", Label: "切换间隔（秒）", LabelEN: "Change interval (s)", Min: 0.5, Max: 30, Step: 0.5}, &e.config.ChangeInterval)
+	ps.Bind(effects.Param{Name: "content", Type: effects.ParamString, Label: "内容（用 | 分隔）", LabelEN: "Content (separated by |)"},
+		func() any { return strings.Join(e.config.Content, "|") },
+		func(v any) { e.config.Content = splitContent(v.(string)) })
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Reconfigure 运行中参数变化后更新二维码（实现 effects.Reconfigurable 接口）
+func (e *QRCodeGenEffect) Reconfigure() {
+	if e.qrcode != nil {
+		e.qrcode.Reconfigure()
+	}
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *QRCodeGenEffect) Resize(width, height int) {
+	if e.qrcode != nil {
+		e.qrcode.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *QRCodeGenEffect) Init(screen tcell.Screen) error {
 	e.qrcode = New(screen, e.config)
+	e.qrcode.SetClock(e.clock)
 	return e.qrcode.Init()
 }
 
@@ -65,3 +99,14 @@ func (e *QRCodeGenEffect) Cleanup() error {
 	}
 	return nil
 }
+
+// splitContent 把以 | 分隔的文本拆分为内容列表，忽略空白项
+func splitContent(s string) []string {
+	var content []string
+	for _, item := range strings.Split(s, "|") {
+		if item = strings.TrimSpace(item); item != "" {
+			content = append(content, item)
+		}
+	}
+	return content
+}
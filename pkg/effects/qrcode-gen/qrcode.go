@@ -1,10 +1,12 @@
 package qrcodegen
 
 import (
+	"slices"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/skip2/go-qrcode"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 二维码动画配置
@@ -32,12 +34,13 @@ func DefaultConfig() *Config {
 type QRCodeGen struct {
 	screen      tcell.Screen
 	config      *Config
+	clock       *effects.Clock
 	currentIdx  int
 	timer       float64
 	qrMatrix    [][]bool
 	width       int
 	height      int
-	lastUpdate  time.Time
+	content     []string // 当前轮换的内容列表
 }
 
 // New 创建二维码生成器特效实例
@@ -49,6 +52,7 @@ func New(screen tcell.Screen, config *Config) *QRCodeGen {
 	return &QRCodeGen{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 	}
 }
 
@@ -57,11 +61,29 @@ func (q *QRCodeGen) Init() error {
 	q.width, q.height = q.screen.Size()
 	q.currentIdx = 0
 	q.timer = 0
+	if len(q.config.Content) == 0 {
+		q.config.Content = DefaultConfig().Content
+	}
+	q.content = slices.Clone(q.config.Content)
 	q.generateQR(q.config.Content[0])
-	q.lastUpdate = time.Now()
 	return nil
 }
 
+// Reconfigure 内容列表变化时从第一项重新开始轮换
+func (q *QRCodeGen) Reconfigure() {
+	if len(q.config.Content) == 0 {
+		q.config.Content = DefaultConfig().Content
+	}
+	if slices.Equal(q.content, q.config.Content) {
+		return
+	}
+
+	q.content = slices.Clone(q.config.Content)
+	q.currentIdx = 0
+	q.timer = 0
+	q.generateQR(q.config.Content[0])
+}
+
 // generateQR 生成二维码
 func (q *QRCodeGen) generateQR(content string) error {
 	qr, err := qrcode.New(content, qrcode.Medium)
@@ -74,6 +96,11 @@ func (q *QRCodeGen) generateQR(content string) error {
 	return nil
 }
 
+// Resize 调整尺寸，二维码按新尺寸缩放并居中
+func (q *QRCodeGen) Resize(width, height int) {
+	q.width, q.height = width, height
+}
+
 // Update 更新二维码生成器状态
 func (q *QRCodeGen) Update(deltaTime float64) {
 	q.timer += deltaTime
@@ -183,24 +210,19 @@ func (q *QRCodeGen) Render() {
 	q.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (q *QRCodeGen) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		q.clock = clock
+	}
+}
+
 // Run 运行二维码生成器特效
 func (q *QRCodeGen) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(q.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(q.lastUpdate).Seconds()
-			q.lastUpdate = now
-
-			q.Update(deltaTime)
-			q.Render()
-		}
-	}
+	return q.clock.RunVar(&q.config.FPS, quit, func(deltaTime float64) {
+		q.Update(deltaTime)
+		q.Render()
+	})
 }
 
 // Cleanup 清理资源
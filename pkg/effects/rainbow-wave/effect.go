@@ -9,6 +9,7 @@ import (
 type RainbowWaveEffect struct {
 	wave   *RainbowWave
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建彩虹波浪特效实例
@@ -48,9 +49,32 @@ func (e *RainbowWaveEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *RainbowWaveEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *RainbowWaveEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "speed", Label: "波浪速度", LabelEN: "Wave speed", Min: 0.1, Max: 5, Step: 0.1}, &e.config.WaveSpeed)
+	ps.Float(effects.Param{Name: "height", Label: "波浪高度", LabelEN: "Wave height", Min: 1, Max: 15, Step: 0.5}, &e.config.WaveHeight)
+	ps.Int(effects.Param{Name: "waves", Label: "波浪数量", LabelEN: "Waves", Min: 1, Max: 10}, &e.config.NumWaves)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *RainbowWaveEffect) Resize(width, height int) {
+	if e.wave != nil {
+		e.wave.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *RainbowWaveEffect) Init(screen tcell.Screen) error {
 	e.wave = New(screen, e.config)
+	e.wave.SetClock(e.clock)
 	return e.wave.Init()
 }
 
@@ -2,9 +2,9 @@ package rainbowwave
 
 import (
 	"math"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 彩虹波浪配置
@@ -29,6 +29,7 @@ func DefaultConfig() *Config {
 type RainbowWave struct {
 	screen tcell.Screen
 	config *Config
+	clock  *effects.Clock
 	phase  float64
 	width  int
 	height int
@@ -45,6 +46,7 @@ func New(screen tcell.Screen, config *Config) *RainbowWave {
 	return &RainbowWave{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		colors: []tcell.Color{
 			tcell.ColorRed,
 			tcell.ColorOrange,
@@ -65,6 +67,11 @@ func (r *RainbowWave) Init() error {
 	return nil
 }
 
+// Resize 调整尺寸，波浪始终位于屏幕中线
+func (r *RainbowWave) Resize(width, height int) {
+	r.width, r.height = width, height
+}
+
 // Update 更新彩虹波浪状态
 func (r *RainbowWave) Update(deltaTime float64) {
 	r.phase += deltaTime * r.config.WaveSpeed
@@ -118,26 +125,19 @@ func abs(x int) int {
 	return x
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (r *RainbowWave) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		r.clock = clock
+	}
+}
+
 // Run 运行彩虹波浪特效
 func (r *RainbowWave) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(r.config.FPS))
-	defer ticker.Stop()
-
-	lastUpdate := time.Now()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(lastUpdate).Seconds()
-			lastUpdate = now
-
-			r.Update(deltaTime)
-			r.Render()
-		}
-	}
+	return r.clock.RunVar(&r.config.FPS, quit, func(deltaTime float64) {
+		r.Update(deltaTime)
+		r.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -0,0 +1,39 @@
+package effects
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Seeder 可选接口：支持注入随机种子的特效
+// 相同的种子应当重放完全相同的动画
+type Seeder interface {
+	// SetSeed 设置随机种子，须在 Init 之前调用
+	// seed 为 0 时表示使用当前时间作为种子
+	SetSeed(seed int64)
+}
+
+// NewRand 创建随机数生成器
+// seed 为 0 时使用当前时间作为种子
+func NewRand(seed int64) *rand.Rand {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return rand.New(rand.NewSource(seed))
+}
+
+// ApplySeed 向特效注入随机种子
+// 特效未实现 Seeder 或 seed 为 0 时不做任何处理，返回是否已注入
+func ApplySeed(effect Effect, seed int64) bool {
+	if seed == 0 {
+		return false
+	}
+
+	seeder, ok := effect.(Seeder)
+	if !ok {
+		return false
+	}
+
+	seeder.SetSeed(seed)
+	return true
+}
@@ -3,6 +3,7 @@ package effects
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 )
 
@@ -78,17 +79,76 @@ func (r *Registry) List() []Metadata {
 	return metadataList
 }
 
-// ListSorted 返回排序后的特效元数据列表
-func (r *Registry) ListSorted() []Metadata {
+// SortOrder 特效列表的排序方式
+type SortOrder string
+
+const (
+	SortRegistration SortOrder = "registration" // 注册顺序
+	SortName         SortOrder = "name"         // 英文名称
+	SortAuthor       SortOrder = "author"       // 作者，同一作者按注册顺序
+	SortPlays        SortOrder = "plays"        // 运行次数从多到少
+)
+
+// SortOrders 返回所有排序方式
+func SortOrders() []SortOrder {
+	return []SortOrder{SortRegistration, SortName, SortAuthor, SortPlays}
+}
+
+// ParseSortOrder 解析排序方式名称，空字符串表示注册顺序
+func ParseSortOrder(name string) (SortOrder, error) {
+	if name == "" {
+		return SortRegistration, nil
+	}
+	for _, order := range SortOrders() {
+		if string(order) == name {
+			return order, nil
+		}
+	}
+
+	names := make([]string, 0, len(SortOrders()))
+	for _, order := range SortOrders() {
+		names = append(names, string(order))
+	}
+	return "", fmt.Errorf("未知的排序方式 %q（可选 %s）", name, strings.Join(names, ", "))
+}
+
+// ListSorted 返回按 order 排序后的特效元数据列表，排序依据相同时保持注册顺序
+// plays 为各特效的运行次数，仅在按运行次数排序时使用
+// 按名称排序时使用英文名称（中文名称没有通用的字母顺序），没有英文名称时使用中文名称
+func (r *Registry) ListSorted(order SortOrder, plays map[string]int) []Metadata {
 	list := r.List()
 
-	sort.Slice(list, func(i, j int) bool {
-		return list[i].Name < list[j].Name
-	})
+	switch order {
+	case SortName:
+		sort.SliceStable(list, func(i, j int) bool {
+			return strings.ToLower(sortName(list[i])) < strings.ToLower(sortName(list[j]))
+		})
+	case SortAuthor:
+		// 没有作者信息的特效排在最后
+		sort.SliceStable(list, func(i, j int) bool {
+			a, b := list[i].Author, list[j].Author
+			if a == "" || b == "" {
+				return a != "" && b == ""
+			}
+			return strings.ToLower(a) < strings.ToLower(b)
+		})
+	case SortPlays:
+		sort.SliceStable(list, func(i, j int) bool {
+			return plays[list[i].ID] > plays[list[j].ID]
+		})
+	}
 
 	return list
 }
 
+// sortName 返回按名称排序时使用的名称
+func sortName(metadata Metadata) string {
+	if metadata.NameEN != "" {
+		return metadata.NameEN
+	}
+	return metadata.Name
+}
+
 // Count 返回已注册特效的数量
 func (r *Registry) Count() int {
 	r.mu.RLock()
@@ -123,3 +183,8 @@ func Get(id string) (EffectFactory, error) {
 func List() []Metadata {
 	return GlobalRegistry.List()
 }
+
+// ListSorted 按指定方式排序列出全局注册表中的所有特效（便捷函数）
+func ListSorted(order SortOrder, plays map[string]int) []Metadata {
+	return GlobalRegistry.ListSorted(order, plays)
+}
@@ -0,0 +1,64 @@
+package effects
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type metadataEffect struct {
+	metadata Metadata
+}
+
+func (e *metadataEffect) Metadata() Metadata             { return e.metadata }
+func (e *metadataEffect) Init(tcell.Screen) error        { return nil }
+func (e *metadataEffect) Run(quit <-chan struct{}) error { <-quit; return nil }
+func (e *metadataEffect) Cleanup() error                 { return nil }
+
+func TestListSorted(t *testing.T) {
+	r := NewRegistry()
+	for _, metadata := range []Metadata{
+		{ID: "c", NameEN: "charlie", Author: "Bob"},
+		{ID: "a", NameEN: "Alpha"},
+		{ID: "b", Name: "Bravo", Author: "alice"},
+		{ID: "d", NameEN: "delta", Author: "Bob"},
+	} {
+		if err := r.Register(func() Effect { return &metadataEffect{metadata} }); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ids := func(list []Metadata) []string {
+		var ids []string
+		for _, metadata := range list {
+			ids = append(ids, metadata.ID)
+		}
+		return ids
+	}
+
+	plays := map[string]int{"d": 3, "b": 3, "a": 1}
+	tests := []struct {
+		order SortOrder
+		want  []string
+	}{
+		{SortRegistration, []string{"c", "a", "b", "d"}},
+		{SortName, []string{"a", "b", "c", "d"}},
+		{SortAuthor, []string{"b", "c", "d", "a"}},
+		{SortPlays, []string{"b", "d", "a", "c"}},
+	}
+	for _, tt := range tests {
+		if got := ids(r.ListSorted(tt.order, plays)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.order, tt.want, got)
+		}
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	if order, err := ParseSortOrder(""); err != nil || order != SortRegistration {
+		t.Errorf("Expected empty name to mean registration order, got %q, %v", order, err)
+	}
+	if _, err := ParseSortOrder("random"); err == nil {
+		t.Error("Expected unknown sort order to be rejected")
+	}
+}
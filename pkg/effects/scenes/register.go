@@ -0,0 +1,42 @@
+package scenes
+
+import (
+	"github.com/symbolmove/symbol_move/pkg/compositor"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/big-clock"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/fire-effect"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/snowfall"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/starry-sky"
+)
+
+func init() {
+	// 星空下的大时钟
+	effects.Register(compositor.NewScene(effects.Metadata{
+		ID:            "night-clock",
+		Name:          "星夜时钟",
+		Description:   "闪烁星空背景上的大字时钟",
+		NameEN:        "Night Clock",
+		DescriptionEN: "A big clock over a twinkling starry sky",
+		Author:        "SymbolMove",
+		Version:       "1.0.0",
+		Tags:          []string{"场景", "时钟", "夜晚"},
+	},
+		compositor.LayerSpec{ID: "starry-sky", Z: 0},
+		compositor.LayerSpec{ID: "big-clock", Z: 1},
+	))
+
+	// 篝火与飘雪，雪花以滤色模式叠加在火光上
+	effects.Register(compositor.NewScene(effects.Metadata{
+		ID:            "winter-fire",
+		Name:          "雪夜篝火",
+		Description:   "雪花飘过熊熊燃烧的篝火",
+		NameEN:        "Winter Fire",
+		DescriptionEN: "Snowflakes drifting over a roaring fire",
+		Author:        "SymbolMove",
+		Version:       "1.0.0",
+		Tags:          []string{"场景", "冬日", "火焰"},
+	},
+		compositor.LayerSpec{ID: "fire-effect", Z: 0, Params: map[string]any{"intensity": 0.8}},
+		compositor.LayerSpec{ID: "snowfall", Z: 1, Blend: compositor.BlendScreen},
+	))
+}
@@ -9,6 +9,7 @@ import (
 type SnakeAIEffect struct {
 	snake  *SnakeAI
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建贪吃蛇AI特效实例
@@ -36,6 +37,9 @@ func (e *SnakeAIEffect) Metadata() effects.Metadata {
 - 死亡自动重启
 - 流畅的移动动画
 
+控制：
+- 方向键接管控制，m 键交还给 AI
+
 完美用于：
 - 游戏AI演示
 - 路径规划算法展示
@@ -47,12 +51,64 @@ func (e *SnakeAIEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *SnakeAIEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *SnakeAIEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *SnakeAIEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "speed", Label: "移动速度（步/秒）", LabelEN: "Speed (steps/s)", Min: 1, Max: 30, Step: 1}, &e.config.Speed)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *SnakeAIEffect) Resize(width, height int) {
+	if e.snake != nil {
+		e.snake.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *SnakeAIEffect) Init(screen tcell.Screen) error {
 	e.snake = New(screen, e.config)
+	e.snake.SetClock(e.clock)
 	return e.snake.Init()
 }
 
+// HandleKey 方向键切换为手动控制并转向，m 键在手动与 AI 控制之间切换（实现 effects.KeyHandler 接口）
+func (e *SnakeAIEffect) HandleKey(event *tcell.EventKey) bool {
+	if e.snake == nil {
+		return false
+	}
+
+	switch event.Key() {
+	case tcell.KeyUp:
+		e.snake.Steer(Point{0, -1})
+	case tcell.KeyDown:
+		e.snake.Steer(Point{0, 1})
+	case tcell.KeyLeft:
+		e.snake.Steer(Point{-1, 0})
+	case tcell.KeyRight:
+		e.snake.Steer(Point{1, 0})
+	case tcell.KeyRune:
+		if event.Rune() != 'm' && event.Rune() != 'M' {
+			return false
+		}
+		e.snake.ToggleManual()
+	default:
+		return false
+	}
+	return true
+}
+
 // Run 运行特效
 func (e *SnakeAIEffect) Run(quit <-chan struct{}) error {
 	return e.snake.Run(quit)
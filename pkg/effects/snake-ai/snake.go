@@ -2,15 +2,16 @@ package snakeai
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 贪吃蛇AI配置
 type Config struct {
 	Speed float64 // 移动速度（步/秒）
 	FPS   int     // 帧率
+	Seed  int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -34,16 +35,18 @@ type Snake struct {
 
 // SnakeAI 贪吃蛇AI特效
 type SnakeAI struct {
-	screen     tcell.Screen
-	config     *Config
-	snake      *Snake
-	food       Point
-	boardW     int
-	boardH     int
-	moveTimer  float64
-	lastUpdate time.Time
-	rand       *rand.Rand
-	score      int
+	screen    tcell.Screen
+	config    *Config
+	clock     *effects.Clock
+	snake     *Snake
+	food      Point
+	boardW    int
+	boardH    int
+	moveTimer float64
+	rand      *rand.Rand
+	score     int
+	manual    bool  // 是否由玩家手动控制
+	steer     Point // 手动控制时下一步的方向
 }
 
 // New 创建贪吃蛇AI特效实例
@@ -55,7 +58,8 @@ func New(screen tcell.Screen, config *Config) *SnakeAI {
 	return &SnakeAI{
 		screen: screen,
 		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:  effects.NewClock(),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -66,10 +70,33 @@ func (s *SnakeAI) Init() error {
 	s.boardH = height - 2
 
 	s.reset()
-	s.lastUpdate = time.Now()
 	return nil
 }
 
+// Resize 按新尺寸调整棋盘
+// 蛇仍在新棋盘内时继续游戏（食物越界则重新生成），否则重新开始
+func (s *SnakeAI) Resize(width, height int) {
+	s.boardW = width / 2
+	s.boardH = height - 2
+	if s.tooSmall() {
+		return
+	}
+	for _, p := range s.snake.body {
+		if p.X >= s.boardW || p.Y >= s.boardH {
+			s.reset()
+			return
+		}
+	}
+	if s.food.X >= s.boardW || s.food.Y >= s.boardH {
+		s.spawnFood()
+	}
+}
+
+// tooSmall 判断棋盘是否放不下初始长度的蛇，此时游戏暂停，等尺寸变大后再继续
+func (s *SnakeAI) tooSmall() bool {
+	return s.boardW < 3 || s.boardH < 1
+}
+
 // reset 重置游戏
 func (s *SnakeAI) reset() {
 	// 初始化蛇（中间位置，长度3）
@@ -84,6 +111,7 @@ func (s *SnakeAI) reset() {
 		},
 		direction: Point{1, 0}, // 向右
 	}
+	s.steer = s.snake.direction
 
 	s.spawnFood()
 	s.moveTimer = 0
@@ -213,13 +241,19 @@ func (s *SnakeAI) getRandomSafeDirection() Point {
 
 // Update 更新贪吃蛇AI状态
 func (s *SnakeAI) Update(deltaTime float64) {
+	if s.tooSmall() {
+		return
+	}
 	s.moveTimer += deltaTime
 
 	if s.moveTimer >= 1.0/s.config.Speed {
 		s.moveTimer = 0
 
-		// AI决策
-		nextDir := s.findPath()
+		// AI决策（手动控制时使用玩家选择的方向）
+		nextDir := s.steer
+		if !s.manual {
+			nextDir = s.findPath()
+		}
 		s.snake.direction = nextDir
 
 		// 移动蛇
@@ -246,6 +280,22 @@ func (s *SnakeAI) Update(deltaTime float64) {
 	}
 }
 
+// Steer 切换为手动控制并设置下一步的方向，不能直接掉头
+func (s *SnakeAI) Steer(dir Point) {
+	s.manual = true
+	if dir.X == -s.snake.direction.X && dir.Y == -s.snake.direction.Y {
+		return
+	}
+	s.steer = dir
+}
+
+// ToggleManual 在手动控制与 AI 控制之间切换，返回切换后是否为手动控制
+func (s *SnakeAI) ToggleManual() bool {
+	s.manual = !s.manual
+	s.steer = s.snake.direction
+	return s.manual
+}
+
 // Render 渲染贪吃蛇AI
 func (s *SnakeAI) Render() {
 	s.screen.Clear()
@@ -292,27 +342,30 @@ func (s *SnakeAI) Render() {
 	s.screen.SetContent(offsetX+s.food.X*2, offsetY+s.food.Y, '♥', nil, foodStyle)
 	s.screen.SetContent(offsetX+s.food.X*2+1, offsetY+s.food.Y, ' ', nil, foodStyle)
 
+	// 手动控制时在上边框中显示提示
+	if s.manual {
+		labelStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow)
+		for i, ch := range " MANUAL · m: AI " {
+			s.screen.SetContent(offsetX+1+i, offsetY-1, ch, nil, labelStyle)
+		}
+	}
+
 	s.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (s *SnakeAI) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		s.clock = clock
+	}
+}
+
 // Run 运行贪吃蛇AI特效
 func (s *SnakeAI) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(s.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(s.lastUpdate).Seconds()
-			s.lastUpdate = now
-
-			s.Update(deltaTime)
-			s.Render()
-		}
-	}
+	return s.clock.RunVar(&s.config.FPS, quit, func(deltaTime float64) {
+		s.Update(deltaTime)
+		s.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -9,6 +9,7 @@ import (
 type SnowfallEffect struct {
 	snow   *Snowfall
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建雪花飘落特效实例
@@ -48,9 +49,38 @@ func (e *SnowfallEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *SnowfallEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *SnowfallEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *SnowfallEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	effects.Enum(ps, effects.Param{
+		Name: "density", Label: "密度", LabelEN: "Density",
+		Options: []string{"sparse", "medium", "dense"},
+	}, &e.config.Density, DensitySparse, DensityMedium, DensityDense)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *SnowfallEffect) Resize(width, height int) {
+	if e.snow != nil {
+		e.snow.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *SnowfallEffect) Init(screen tcell.Screen) error {
 	e.snow = New(screen, e.config)
+	e.snow.SetClock(e.clock)
 	return e.snow.Init()
 }
 
@@ -3,9 +3,9 @@ package snowfall
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Density 雪花密度
@@ -21,6 +21,7 @@ const (
 type Config struct {
 	Density Density // 雪花密度
 	FPS     int     // 帧率
+	Seed    int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -46,11 +47,11 @@ type Snowflake struct {
 type Snowfall struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	flakes     []*Snowflake
 	width      int
 	height     int
 	rand       *rand.Rand
-	lastUpdate time.Time
 }
 
 const (
@@ -66,15 +67,15 @@ func New(screen tcell.Screen, config *Config) *Snowfall {
 	return &Snowfall{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		flakes: make([]*Snowflake, 0, 100),
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
 // Init 初始化
 func (s *Snowfall) Init() error {
 	s.width, s.height = s.screen.Size()
-	s.lastUpdate = time.Now()
 
 	// 预生成一些雪花在屏幕各处
 	initialFlakes := s.height / 2
@@ -149,6 +150,19 @@ func (s *Snowfall) getFlakeSpeed(layer int) float64 {
 	return 1.0
 }
 
+// Resize 调整尺寸，已有的雪花继续飘落，宽度变小时超出的雪花环绕回屏幕内
+func (s *Snowfall) Resize(width, height int) {
+	s.width, s.height = width, height
+	if width <= 0 {
+		return
+	}
+	for _, flake := range s.flakes {
+		if flake.x >= float64(width) {
+			flake.x = math.Mod(flake.x, float64(width))
+		}
+	}
+}
+
 // Update 更新雪花状态
 func (s *Snowfall) Update(deltaTime float64) {
 	// 生成新雪花
@@ -231,24 +245,19 @@ func (s *Snowfall) Render() {
 	s.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (s *Snowfall) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		s.clock = clock
+	}
+}
+
 // Run 运行雪花特效
 func (s *Snowfall) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(s.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(s.lastUpdate).Seconds()
-			s.lastUpdate = now
-
-			s.Update(deltaTime)
-			s.Render()
-		}
-	}
+	return s.clock.RunVar(&s.config.FPS, quit, func(deltaTime float64) {
+		s.Update(deltaTime)
+		s.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -3,12 +3,14 @@ package starrysky
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 // StarrySkyEffect 星空闪烁特效
 type StarrySkyEffect struct {
 	sky    *StarrySky
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建星空闪烁特效实例
@@ -31,7 +33,7 @@ func (e *StarrySkyEffect) Metadata() effects.Metadata {
 
 特点：
 - 真实的闪烁动画（基于正弦波）
-- 多种颜色主题（经典、彩色、蓝色）
+- 多种颜色主题（经典、彩色、蓝色），也可以使用调色板
 - 可调节星星密度
 - 流畅的 30 FPS 动画
 - 自动适配终端大小
@@ -47,9 +49,54 @@ func (e *StarrySkyEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *StarrySkyEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetPalette 设置星星的配色（实现 effects.Paletted 接口）
+func (e *StarrySkyEffect) SetPalette(p *palette.Palette) {
+	e.config.Palette = p
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *StarrySkyEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *StarrySkyEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	effects.Enum(ps, effects.Param{
+		Name: "density", Label: "密度", LabelEN: "Density",
+		Options: []string{"sparse", "medium", "dense"},
+	}, &e.config.Density, DensitySparse, DensityMedium, DensityDense)
+	effects.Enum(ps, effects.Param{
+		Name: "theme", Label: "颜色主题", LabelEN: "Theme",
+		Options: []string{"classic", "colorful", "blue"},
+	}, &e.config.Theme, ThemeClassic, ThemeColorful, ThemeBlue)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Reconfigure 运行中参数变化后更新星空（实现 effects.Reconfigurable 接口）
+func (e *StarrySkyEffect) Reconfigure() {
+	if e.sky != nil {
+		e.sky.Reconfigure()
+	}
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *StarrySkyEffect) Resize(width, height int) {
+	if e.sky != nil {
+		e.sky.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *StarrySkyEffect) Init(screen tcell.Screen) error {
 	e.sky = New(screen, e.config)
+	e.sky.SetClock(e.clock)
 	return e.sky.Init()
 }
 
@@ -3,9 +3,10 @@ package starrysky
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 // Density 星星密度
@@ -28,9 +29,11 @@ const (
 
 // Config 星空配置
 type Config struct {
-	Density Density // 星星密度
-	Theme   Theme   // 颜色主题
-	FPS     int     // 帧率
+	Density Density          // 星星密度
+	Theme   Theme            // 颜色主题
+	Palette *palette.Palette // 调色板（设置后星星从渐变的亮端取色，取代颜色主题）
+	FPS     int              // 帧率
+	Seed    int64            // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -56,11 +59,12 @@ type Star struct {
 type StarrySky struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	stars      []*Star
 	width      int
 	height     int
 	rand       *rand.Rand
-	lastUpdate time.Time
+	generated  Config // 生成当前星星时的配置
 }
 
 // New 创建星空特效实例
@@ -72,7 +76,8 @@ func New(screen tcell.Screen, config *Config) *StarrySky {
 	return &StarrySky{
 		screen: screen,
 		config: config,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:  effects.NewClock(),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -80,40 +85,77 @@ func New(screen tcell.Screen, config *Config) *StarrySky {
 func (s *StarrySky) Init() error {
 	s.width, s.height = s.screen.Size()
 	s.generateStars()
-	s.lastUpdate = time.Now()
 	return nil
 }
 
 // generateStars 生成星星
 func (s *StarrySky) generateStars() {
+	s.generated = *s.config
 	totalCells := s.width * s.height
 
-	// 根据密度计算星星数量
-	var coverage float64
-	switch s.config.Density {
+	starCount := int(float64(totalCells) * s.coverage())
+	s.stars = make([]*Star, 0, starCount)
+
+	// 生成星星
+	for i := 0; i < starCount; i++ {
+		s.stars = append(s.stars, s.newStar(s.rand.Intn(s.width), s.rand.Intn(s.height)))
+	}
+}
+
+// coverage 根据密度返回星星占屏幕格子的比例
+func (s *StarrySky) coverage() float64 {
+	switch s.generated.Density {
 	case DensitySparse:
-		coverage = 0.01
+		return 0.01
 	case DensityMedium:
-		coverage = 0.02
+		return 0.02
 	case DensityDense:
-		coverage = 0.03
+		return 0.03
 	}
+	return 0
+}
 
-	starCount := int(float64(totalCells) * coverage)
-	s.stars = make([]*Star, 0, starCount)
+// newStar 在指定位置创建一颗随机的星星
+func (s *StarrySky) newStar(x, y int) *Star {
+	return &Star{
+		x:          x,
+		y:          y,
+		char:       s.randomStarChar(),
+		baseColor:  s.randomStarColor(),
+		brightness: s.rand.Float64(), // 随机初始亮度
+		phase:      s.rand.Float64() * 2 * math.Pi, // 随机初始相位
+		speed:      0.5 + s.rand.Float64()*1.5, // 0.5-2.0 速度倍数
+	}
+}
 
-	// 生成星星
-	for i := 0; i < starCount; i++ {
-		star := &Star{
-			x:          s.rand.Intn(s.width),
-			y:          s.rand.Intn(s.height),
-			char:       s.randomStarChar(),
-			baseColor:  s.randomStarColor(),
-			brightness: s.rand.Float64(), // 随机初始亮度
-			phase:      s.rand.Float64() * 2 * math.Pi, // 随机初始相位
-			speed:      0.5 + s.rand.Float64()*1.5, // 0.5-2.0 速度倍数
+// Resize 调整尺寸
+// 保留仍在屏幕内的星星，新增的区域按相同的密度补充星星
+func (s *StarrySky) Resize(width, height int) {
+	keptW, keptH := min(width, s.width), min(height, s.height)
+	stars := s.stars[:0]
+	for _, star := range s.stars {
+		if star.x < width && star.y < height {
+			stars = append(stars, star)
+		}
+	}
+	s.stars = stars
+	s.width, s.height = width, height
+
+	added := int(float64(width*height-keptW*keptH) * s.coverage())
+	for added > 0 {
+		x, y := s.rand.Intn(width), s.rand.Intn(height)
+		if x < keptW && y < keptH {
+			continue
 		}
-		s.stars = append(s.stars, star)
+		s.stars = append(s.stars, s.newStar(x, y))
+		added--
+	}
+}
+
+// Reconfigure 密度或主题变化时重新生成星星
+func (s *StarrySky) Reconfigure() {
+	if s.generated.Density != s.config.Density || s.generated.Theme != s.config.Theme || s.generated.Palette != s.config.Palette {
+		s.generateStars()
 	}
 }
 
@@ -123,8 +165,12 @@ func (s *StarrySky) randomStarChar() rune {
 	return chars[s.rand.Intn(len(chars))]
 }
 
-// randomStarColor 根据主题随机选择星星颜色
+// randomStarColor 根据调色板或主题随机选择星星颜色
 func (s *StarrySky) randomStarColor() tcell.Color {
+	if s.config.Palette != nil {
+		return s.config.Palette.At(0.6 + 0.4*s.rand.Float64())
+	}
+
 	switch s.config.Theme {
 	case ThemeClassic:
 		return tcell.ColorWhite
@@ -204,24 +250,19 @@ func (s *StarrySky) getStarStyle(star *Star) tcell.Style {
 	return style
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (s *StarrySky) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		s.clock = clock
+	}
+}
+
 // Run 运行星空特效
 func (s *StarrySky) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(s.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(s.lastUpdate).Seconds()
-			s.lastUpdate = now
-
-			s.Update(deltaTime)
-			s.Render()
-		}
-	}
+	return s.clock.RunVar(&s.config.FPS, quit, func(deltaTime float64) {
+		s.Update(deltaTime)
+		s.Render()
+	})
 }
 
 // Cleanup 清理资源
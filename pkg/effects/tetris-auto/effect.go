@@ -9,6 +9,7 @@ import (
 type TetrisAutoEffect struct {
 	tetris *TetrisAuto
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建俄罗斯方块AI特效实例
@@ -47,9 +48,35 @@ func (e *TetrisAutoEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *TetrisAutoEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *TetrisAutoEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *TetrisAutoEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "fall-speed", Label: "下落速度（行/秒）", LabelEN: "Fall speed (rows/s)", Min: 0.5, Max: 20, Step: 0.5}, &e.config.FallSpeed)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *TetrisAutoEffect) Resize(width, height int) {
+	if e.tetris != nil {
+		e.tetris.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *TetrisAutoEffect) Init(screen tcell.Screen) error {
 	e.tetris = New(screen, e.config)
+	e.tetris.SetClock(e.clock)
 	return e.tetris.Init()
 }
 
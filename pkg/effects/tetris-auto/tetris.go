@@ -2,15 +2,16 @@ package tetrisauto
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 俄罗斯方块配置
 type Config struct {
 	FallSpeed float64 // 下落速度（行/秒）
 	FPS       int     // 帧率
+	Seed      int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -53,6 +54,7 @@ var tetrominoColors = []tcell.Color{
 type TetrisAuto struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	board      [][]int // 游戏板（0=空，1-7=不同方块颜色）
 	boardColors [][]tcell.Color
 	current    *Tetromino
@@ -62,7 +64,6 @@ type TetrisAuto struct {
 	boardW     int
 	boardH     int
 	score      int
-	lastUpdate time.Time
 	rand       *rand.Rand
 }
 
@@ -75,9 +76,10 @@ func New(screen tcell.Screen, config *Config) *TetrisAuto {
 	return &TetrisAuto{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		boardW: 10,
 		boardH: 20,
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -93,7 +95,6 @@ func (t *TetrisAuto) Init() error {
 	t.fallTimer = 0
 	t.score = 0
 	t.spawnNew()
-	t.lastUpdate = time.Now()
 	return nil
 }
 
@@ -229,6 +230,11 @@ func (t *TetrisAuto) findBestMove() int {
 	return bestX
 }
 
+// Resize 调整尺寸，棋盘大小不变，始终居中显示
+func (t *TetrisAuto) Resize(width, height int) {
+	t.width, t.height = width, height
+}
+
 // Update 更新俄罗斯方块状态
 func (t *TetrisAuto) Update(deltaTime float64) {
 	t.fallTimer += deltaTime
@@ -315,24 +321,19 @@ func (t *TetrisAuto) Render() {
 	t.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (t *TetrisAuto) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		t.clock = clock
+	}
+}
+
 // Run 运行俄罗斯方块特效
 func (t *TetrisAuto) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(t.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(t.lastUpdate).Seconds()
-			t.lastUpdate = now
-
-			t.Update(deltaTime)
-			t.Render()
-		}
-	}
+	return t.clock.RunVar(&t.config.FPS, quit, func(deltaTime float64) {
+		t.Update(deltaTime)
+		t.Render()
+	})
 }
 
 // Cleanup 清理资源
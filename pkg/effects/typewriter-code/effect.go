@@ -9,6 +9,7 @@ import (
 type TypewriterCodeEffect struct {
 	typewriter *Typewriter
 	config     *Config
+	clock      *effects.Clock
 }
 
 // NewEffect 创建打字机代码雨特效实例
@@ -47,9 +48,36 @@ func (e *TypewriterCodeEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *TypewriterCodeEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *TypewriterCodeEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *TypewriterCodeEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "typing-speed", Label: "打字速度（字符/秒）", LabelEN: "Typing speed (chars/s)", Min: 1, Max: 200, Step: 1}, &e.config.TypingSpeed)
+	ps.Float(effects.Param{Name: "line-interval", Label: "新行间隔（秒）", LabelEN: "Line interval (s)", Min: 0, Max: 5, Step: 0.1}, &e.config.LineInterval)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *TypewriterCodeEffect) Resize(width, height int) {
+	if e.typewriter != nil {
+		e.typewriter.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *TypewriterCodeEffect) Init(screen tcell.Screen) error {
 	e.typewriter = New(screen, e.config)
+	e.typewriter.SetClock(e.clock)
 	return e.typewriter.Init()
 }
 
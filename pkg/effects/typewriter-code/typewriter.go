@@ -2,9 +2,9 @@ package typewritercode
 
 import (
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 打字机代码雨配置
@@ -12,6 +12,7 @@ type Config struct {
 	TypingSpeed  float64 // 字符/秒
 	LineInterval float64 // 新行间隔（秒）
 	FPS          int     // 帧率
+	Seed         int64   // 随机种子（0 表示使用当前时间）
 }
 
 // DefaultConfig 返回默认配置
@@ -65,11 +66,11 @@ var codeTemplates = []string{
 type Typewriter struct {
 	screen            tcell.Screen
 	config            *Config
+	clock             *effects.Clock
 	lines             []*CodeLine
 	width             int
 	height            int
 	timeSinceNewLine  float64
-	lastUpdate        time.Time
 	rand              *rand.Rand
 }
 
@@ -82,8 +83,9 @@ func New(screen tcell.Screen, config *Config) *Typewriter {
 	return &Typewriter{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		lines:  make([]*CodeLine, 0),
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -91,7 +93,6 @@ func New(screen tcell.Screen, config *Config) *Typewriter {
 func (t *Typewriter) Init() error {
 	t.width, t.height = t.screen.Size()
 	t.timeSinceNewLine = 0
-	t.lastUpdate = time.Now()
 	return nil
 }
 
@@ -150,6 +151,11 @@ func (t *Typewriter) getColorForChar(text string, pos int) tcell.Color {
 	}
 }
 
+// Resize 调整尺寸，超出屏幕的代码行在下次换行时移除
+func (t *Typewriter) Resize(width, height int) {
+	t.width, t.height = width, height
+}
+
 // Update 更新打字机代码雨状态
 func (t *Typewriter) Update(deltaTime float64) {
 	// 定期添加新行
@@ -217,24 +223,19 @@ func (t *Typewriter) Render() {
 	t.screen.Show()
 }
 
+// SetClock 设置驱动帧循环的时钟
+func (t *Typewriter) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		t.clock = clock
+	}
+}
+
 // Run 运行打字机代码雨特效
 func (t *Typewriter) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(t.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(t.lastUpdate).Seconds()
-			t.lastUpdate = now
-
-			t.Update(deltaTime)
-			t.Render()
-		}
-	}
+	return t.clock.RunVar(&t.config.FPS, quit, func(deltaTime float64) {
+		t.Update(deltaTime)
+		t.Render()
+	})
 }
 
 // Cleanup 清理资源
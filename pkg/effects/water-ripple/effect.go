@@ -2,13 +2,16 @@ package waterripple
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
 	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // WaterRippleEffect 水波涟漪特效
 type WaterRippleEffect struct {
-	ripple *WaterRipple
-	config *Config
+	ripple  *WaterRipple
+	config  *Config
+	clock   *effects.Clock
+	buttons effects.MouseButtons // 鼠标按键状态
 }
 
 // NewEffect 创建水波涟漪特效实例
@@ -35,6 +38,7 @@ func (e *WaterRippleEffect) Metadata() effects.Metadata {
 - 波动衰减效果
 - 干涉现象可视化
 - 随机水滴位置
+- 可用半格或盲文点阵细分像素（render=half、render=braille），波纹更细腻
 
 完美用于：
 - 放松心情
@@ -47,12 +51,51 @@ func (e *WaterRippleEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetSeed 设置随机种子（实现 effects.Seeder 接口）
+func (e *WaterRippleEffect) SetSeed(seed int64) {
+	e.config.Seed = seed
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *WaterRippleEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *WaterRippleEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.Float(effects.Param{Name: "interval", Label: "水滴间隔（秒）", LabelEN: "Drop interval (s)", Min: 0.1, Max: 10, Step: 0.1}, &e.config.DropInterval)
+	ps.Float(effects.Param{Name: "speed", Label: "波速", LabelEN: "Wave speed", Min: 0.5, Max: 10, Step: 0.5}, &e.config.WaveSpeed)
+	ps.Float(effects.Param{Name: "damping", Label: "衰减系数", LabelEN: "Damping", Min: 0, Max: 1, Step: 0.05}, &e.config.Damping)
+	effects.Enum(ps, effects.Param{
+		Name: "render", Label: "渲染精度", LabelEN: "Rendering",
+		Options: []string{"cell", "half", "braille"},
+	}, &e.config.Render, canvas.Modes...)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *WaterRippleEffect) Resize(width, height int) {
+	if e.ripple != nil {
+		e.ripple.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *WaterRippleEffect) Init(screen tcell.Screen) error {
 	e.ripple = New(screen, e.config)
+	e.ripple.SetClock(e.clock)
 	return e.ripple.Init()
 }
 
+// HandleMouse 点击处落下一滴水（实现 effects.MouseHandler 接口）
+func (e *WaterRippleEffect) HandleMouse(event *tcell.EventMouse) {
+	if e.ripple != nil && e.buttons.Pressed(event)&tcell.Button1 != 0 {
+		e.ripple.AddDropAt(event.Position())
+	}
+}
+
 // Run 运行特效
 func (e *WaterRippleEffect) Run(quit <-chan struct{}) error {
 	return e.ripple.Run(quit)
@@ -3,9 +3,10 @@ package waterripple
 import (
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/canvas"
+	"github.com/symbolmove/symbol_move/pkg/effects"
 )
 
 // Config 水波涟漪配置
@@ -14,6 +15,8 @@ type Config struct {
 	WaveSpeed    float64 // 波速
 	Damping      float64 // 衰减系数
 	FPS          int     // 帧率
+	Seed         int64   // 随机种子（0 表示使用当前时间）
+	Render       canvas.Mode // 渲染精度：每格一个字符，或用半格、盲文点阵细分像素
 }
 
 // DefaultConfig 返回默认配置
@@ -23,6 +26,7 @@ func DefaultConfig() *Config {
 		WaveSpeed:    3.0,  // 波速
 		Damping:      0.3,  // 衰减系数
 		FPS:          30,
+		Render:       canvas.ModeCell,
 	}
 }
 
@@ -37,12 +41,13 @@ type Drop struct {
 type WaterRipple struct {
 	screen           tcell.Screen
 	config           *Config
+	clock            *effects.Clock
 	drops            []*Drop
 	width            int
 	height           int
 	timeSinceNewDrop float64
-	lastUpdate       time.Time
 	rand             *rand.Rand
+	canvas           *canvas.Canvas // 细分像素渲染时使用
 }
 
 // New 创建水波涟漪特效实例
@@ -54,8 +59,9 @@ func New(screen tcell.Screen, config *Config) *WaterRipple {
 	return &WaterRipple{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 		drops:  make([]*Drop, 0),
-		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+		rand:   effects.NewRand(config.Seed),
 	}
 }
 
@@ -63,21 +69,35 @@ func New(screen tcell.Screen, config *Config) *WaterRipple {
 func (w *WaterRipple) Init() error {
 	w.width, w.height = w.screen.Size()
 	w.timeSinceNewDrop = 0
-	w.lastUpdate = time.Now()
 	return nil
 }
 
-// addDrop 添加新水滴
+// addDrop 在随机位置添加新水滴
 func (w *WaterRipple) addDrop() {
+	x := w.rand.Intn(w.width)
+	y := w.rand.Intn(w.height)
+	w.AddDropAt(x, y)
+}
+
+// AddDropAt 在 (x, y) 处添加新水滴
+func (w *WaterRipple) AddDropAt(x, y int) {
 	drop := &Drop{
-		x:         w.rand.Intn(w.width),
-		y:         w.rand.Intn(w.height),
+		x:         x,
+		y:         y,
 		time:      0,
 		maxRadius: 20.0 + w.rand.Float64()*10.0,
 	}
 	w.drops = append(w.drops, drop)
 }
 
+// Resize 调整尺寸，已有的涟漪继续扩散
+func (w *WaterRipple) Resize(width, height int) {
+	w.width, w.height = width, height
+	if w.canvas != nil {
+		w.canvas.Resize(width, height)
+	}
+}
+
 // Update 更新水波涟漪状态
 func (w *WaterRipple) Update(deltaTime float64) {
 	// 添加新水滴
@@ -105,51 +125,19 @@ func (w *WaterRipple) Update(deltaTime float64) {
 func (w *WaterRipple) Render() {
 	w.screen.Clear()
 
+	if w.config.Render != canvas.ModeCell && w.config.Render != "" {
+		w.renderCanvas()
+		w.screen.Show()
+		return
+	}
+
 	for y := 0; y < w.height; y++ {
 		for x := 0; x < w.width; x++ {
-			// 叠加所有水滴的波动
-			totalAmplitude := 0.0
-
-			for _, drop := range w.drops {
-				// 距离水滴中心的距离
-				dx := float64(x - drop.x)
-				dy := float64(y - drop.y)
-				r := math.Sqrt(dx*dx + dy*dy)
-
-				currentRadius := w.config.WaveSpeed * drop.time
-
-				// 只在波前附近有显著振幅
-				if math.Abs(r-currentRadius) < 3.0 && r > 0 {
-					// 波动方程
-					k := 0.5 // 波数
-					omega := w.config.WaveSpeed
-					amplitude := math.Sin(k*r-omega*drop.time) / (1 + r/10)
-
-					// 衰减
-					amplitude *= math.Exp(-w.config.Damping * drop.time)
-					totalAmplitude += amplitude
-				}
-			}
+			totalAmplitude := w.amplitude(float64(x), float64(y))
 
 			// 根据振幅选择字符和颜色
 			if math.Abs(totalAmplitude) > 0.15 {
-				var char rune
-				var color tcell.Color
-
-				if totalAmplitude > 0.5 {
-					char = '○'
-					color = tcell.ColorWhite
-				} else if totalAmplitude > 0.3 {
-					char = '◯'
-					color = tcell.ColorLightCyan
-				} else if totalAmplitude < -0.3 {
-					char = '·'
-					color = tcell.ColorBlue
-				} else {
-					char = '~'
-					color = tcell.ColorLightBlue
-				}
-
+				char, color := rippleGlyph(totalAmplitude)
 				style := tcell.StyleDefault.Foreground(color)
 				w.screen.SetContent(x, y, char, nil, style)
 			}
@@ -159,24 +147,82 @@ func (w *WaterRipple) Render() {
 	w.screen.Show()
 }
 
-// Run 运行水波涟漪特效
-func (w *WaterRipple) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(w.config.FPS))
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(w.lastUpdate).Seconds()
-			w.lastUpdate = now
-
-			w.Update(deltaTime)
-			w.Render()
+// renderCanvas 按像素计算振幅，像素坐标换算为单元格坐标，涟漪的大小与字符渲染时相同
+func (w *WaterRipple) renderCanvas() {
+	if w.canvas == nil || w.canvas.Mode() != w.config.Render {
+		w.canvas = canvas.New(w.config.Render, w.width, w.height)
+	}
+	w.canvas.Clear()
+
+	sx, sy := w.config.Render.Scale()
+	pw, ph := w.canvas.Size()
+	for py := 0; py < ph; py++ {
+		for px := 0; px < pw; px++ {
+			// 像素中心相对所在单元格中心的偏移
+			x := (float64(px)+0.5)/float64(sx) - 0.5
+			y := (float64(py)+0.5)/float64(sy) - 0.5
+			if amplitude := w.amplitude(x, y); math.Abs(amplitude) > 0.15 {
+				_, color := rippleGlyph(amplitude)
+				w.canvas.Set(px, py, color)
+			}
 		}
 	}
+	w.canvas.Draw(w.screen)
+}
+
+// amplitude 叠加所有水滴在 (x, y) 处的波动，坐标单位为单元格
+func (w *WaterRipple) amplitude(x, y float64) float64 {
+	totalAmplitude := 0.0
+
+	for _, drop := range w.drops {
+		// 距离水滴中心的距离
+		dx := x - float64(drop.x)
+		dy := y - float64(drop.y)
+		r := math.Sqrt(dx*dx + dy*dy)
+
+		currentRadius := w.config.WaveSpeed * drop.time
+
+		// 只在波前附近有显著振幅
+		if math.Abs(r-currentRadius) < 3.0 && r > 0 {
+			// 波动方程
+			k := 0.5 // 波数
+			omega := w.config.WaveSpeed
+			amplitude := math.Sin(k*r-omega*drop.time) / (1 + r/10)
+
+			// 衰减
+			amplitude *= math.Exp(-w.config.Damping * drop.time)
+			totalAmplitude += amplitude
+		}
+	}
+	return totalAmplitude
+}
+
+// rippleGlyph 按振幅选择字符和颜色
+func rippleGlyph(amplitude float64) (rune, tcell.Color) {
+	switch {
+	case amplitude > 0.5:
+		return '○', tcell.ColorWhite
+	case amplitude > 0.3:
+		return '◯', tcell.ColorLightCyan
+	case amplitude < -0.3:
+		return '·', tcell.ColorBlue
+	}
+	return '~', tcell.ColorLightBlue
+}
+
+// SetClock 设置驱动帧循环的时钟
+func (w *WaterRipple) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		w.clock = clock
+	}
+}
+
+// Run 运行水波涟漪特效
+func (w *WaterRipple) Run(quit <-chan struct{}) error {
+	return w.clock.RunVar(&w.config.FPS, quit, func(deltaTime float64) {
+		w.Update(deltaTime)
+		w.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -3,12 +3,14 @@ package wavetext
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 // WaveTextEffect 波浪文字特效
 type WaveTextEffect struct {
 	wave   *WaveText
 	config *Config
+	clock  *effects.Clock
 }
 
 // NewEffect 创建波浪文字特效实例
@@ -48,9 +50,38 @@ func (e *WaveTextEffect) Metadata() effects.Metadata {
 	}
 }
 
+// SetPalette 设置文字的配色（实现 effects.Paletted 接口）
+func (e *WaveTextEffect) SetPalette(p *palette.Palette) {
+	e.config.Palette = p
+}
+
+// SetClock 设置驱动帧循环的时钟（实现 effects.Clocked 接口）
+func (e *WaveTextEffect) SetClock(clock *effects.Clock) {
+	e.clock = clock
+}
+
+// Params 返回可调参数（实现 effects.Configurable 接口）
+func (e *WaveTextEffect) Params() *effects.ParamSet {
+	ps := effects.NewParamSet()
+	ps.String(effects.Param{Name: "text", Label: "显示文本", LabelEN: "Text"}, &e.config.Text)
+	ps.Float(effects.Param{Name: "amplitude", Label: "波浪振幅", LabelEN: "Amplitude", Min: 0, Max: 10, Step: 0.5}, &e.config.Amplitude)
+	ps.Float(effects.Param{Name: "speed", Label: "波浪速度", LabelEN: "Wave speed", Min: 0.1, Max: 10, Step: 0.1}, &e.config.WaveSpeed)
+	ps.Float(effects.Param{Name: "color-speed", Label: "颜色变化速度", LabelEN: "Color speed", Min: 0, Max: 5, Step: 0.1}, &e.config.ColorSpeed)
+	ps.FPS(&e.config.FPS)
+	return ps
+}
+
+// Resize 屏幕尺寸变化后调整特效（实现 effects.Resizable 接口）
+func (e *WaveTextEffect) Resize(width, height int) {
+	if e.wave != nil {
+		e.wave.Resize(width, height)
+	}
+}
+
 // Init 初始化特效
 func (e *WaveTextEffect) Init(screen tcell.Screen) error {
 	e.wave = New(screen, e.config)
+	e.wave.SetClock(e.clock)
 	return e.wave.Init()
 }
 
@@ -2,18 +2,20 @@ package wavetext
 
 import (
 	"math"
-	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/palette"
 )
 
 // Config 波浪文字配置
 type Config struct {
-	Text       string  // 显示文本
-	Amplitude  float64 // 波浪振幅（字符高度）
-	WaveSpeed  float64 // 波浪速度
-	ColorSpeed float64 // 颜色变化速度
-	FPS        int     // 帧率
+	Text       string           // 显示文本
+	Amplitude  float64          // 波浪振幅（字符高度）
+	WaveSpeed  float64          // 波浪速度
+	ColorSpeed float64          // 颜色变化速度
+	FPS        int              // 帧率
+	Palette    *palette.Palette // 文字的配色，颜色相位在渐变上循环
 }
 
 // DefaultConfig 返回默认配置
@@ -24,6 +26,7 @@ func DefaultConfig() *Config {
 		WaveSpeed:  2.0,
 		ColorSpeed: 1.0,
 		FPS:        30,
+		Palette:    palette.Rainbow,
 	}
 }
 
@@ -31,6 +34,7 @@ func DefaultConfig() *Config {
 type WaveText struct {
 	screen     tcell.Screen
 	config     *Config
+	clock      *effects.Clock
 	phase      float64 // 波浪相位
 	colorPhase float64 // 颜色相位
 	width      int
@@ -46,6 +50,7 @@ func New(screen tcell.Screen, config *Config) *WaveText {
 	return &WaveText{
 		screen: screen,
 		config: config,
+		clock:  effects.NewClock(),
 	}
 }
 
@@ -57,6 +62,11 @@ func (w *WaveText) Init() error {
 	return nil
 }
 
+// Resize 调整尺寸，文字始终居中显示
+func (w *WaveText) Resize(width, height int) {
+	w.width, w.height = width, height
+}
+
 // Update 更新波浪文字状态
 func (w *WaveText) Update(deltaTime float64) {
 	// 更新波浪相位
@@ -119,46 +129,24 @@ func (w *WaveText) Render() {
 	w.screen.Show()
 }
 
-// hueToColor 将色相转换为 tcell 颜色
+// hueToColor 将色相（0~360）映射为配色渐变上的颜色
 func (w *WaveText) hueToColor(hue float64) tcell.Color {
-	// 简化的彩虹颜色映射
-	h := int(hue)
-	switch {
-	case h < 60:
-		return tcell.ColorRed
-	case h < 120:
-		return tcell.ColorYellow
-	case h < 180:
-		return tcell.ColorGreen
-	case h < 240:
-		return tcell.ColorLightBlue
-	case h < 300:
-		return tcell.ColorBlue
-	default:
-		return tcell.ColorPurple
+	return w.config.Palette.At(hue / 360)
+}
+
+// SetClock 设置驱动帧循环的时钟
+func (w *WaveText) SetClock(clock *effects.Clock) {
+	if clock != nil {
+		w.clock = clock
 	}
 }
 
 // Run 运行波浪文字特效
 func (w *WaveText) Run(quit <-chan struct{}) error {
-	ticker := time.NewTicker(time.Second / time.Duration(w.config.FPS))
-	defer ticker.Stop()
-
-	lastUpdate := time.Now()
-
-	for {
-		select {
-		case <-quit:
-			return nil
-		case <-ticker.C:
-			now := time.Now()
-			deltaTime := now.Sub(lastUpdate).Seconds()
-			lastUpdate = now
-
-			w.Update(deltaTime)
-			w.Render()
-		}
-	}
+	return w.clock.RunVar(&w.config.FPS, quit, func(deltaTime float64) {
+		w.Update(deltaTime)
+		w.Render()
+	})
 }
 
 // Cleanup 清理资源
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/matrix-rain"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+func TestWriteGIF(t *testing.T) {
+	factory, err := effects.Get("matrix-rain")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{Width: 20, Height: 6, Duration: time.Second, FPS: 10, Seed: 7}
+
+	var buf bytes.Buffer
+	if err := WriteGIF(&buf, factory(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("GIF 解码失败: %v", err)
+	}
+
+	if anim.Config.Width != 20*CellWidth || anim.Config.Height != 6*CellHeight {
+		t.Errorf("图像尺寸不正确: %dx%d", anim.Config.Width, anim.Config.Height)
+	}
+	if n := len(anim.Image); n < 5 || n > 11 {
+		t.Errorf("1 秒 10 帧应导出约 10 帧，实际 %d", n)
+	}
+
+	total := 0
+	for _, delay := range anim.Delay {
+		if delay < 2 {
+			t.Errorf("帧间隔过短: %d", delay)
+		}
+		total += delay
+	}
+	if total < 90 || total > 120 {
+		t.Errorf("总时长应约为 100 (1/100 秒)，实际 %d", total)
+	}
+}
+
+func TestEncodeMergesIdenticalFrames(t *testing.T) {
+	var frames []*frame.Frame
+	for i := 0; i < 10; i++ {
+		f := frame.New(2, 1)
+		if i >= 5 {
+			f.Set(0, 0, frame.Cell{Rune: 'x', Style: tcell.StyleDefault.Foreground(tcell.ColorRed)})
+		}
+		f.Time = time.Duration(i+1) * 100 * time.Millisecond
+		frames = append(frames, f)
+	}
+
+	anim := Encode(frames, 10)
+	if len(anim.Image) != 2 {
+		t.Fatalf("相同的相邻帧应合并为 2 帧，实际 %d", len(anim.Image))
+	}
+	if anim.Delay[0] != 50 {
+		t.Errorf("第一帧应持续 50 (1/100 秒)，实际 %d", anim.Delay[0])
+	}
+}
+
+func TestGlyphs(t *testing.T) {
+	count := func(r rune) int {
+		n := 0
+		for _, on := range glyphFor(r) {
+			if on {
+				n++
+			}
+		}
+		return n
+	}
+
+	if n := count(' '); n != 0 {
+		t.Errorf("空格不应有前景像素，实际 %d", n)
+	}
+	if n := count('█'); n != CellWidth*CellHeight {
+		t.Errorf("█ 应填满单元格，实际 %d", n)
+	}
+	for _, r := range "A0▄░─┼⣿●ｱ字" {
+		if count(r) == 0 {
+			t.Errorf("%q 应有前景像素", r)
+		}
+	}
+	if *glyphFor('字') == *glyphFor('ア') {
+		t.Error("不同字符的伪字形应不同")
+	}
+}
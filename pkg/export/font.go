@@ -0,0 +1,304 @@
+package export
+
+import (
+	"image"
+	"sync"
+
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// 单元格像素尺寸（与 basicfont.Face7x13 一致）
+const (
+	CellWidth  = 7
+	CellHeight = 13
+)
+
+// glyph 单元格位图，按行优先存储，true 表示前景像素
+type glyph [CellWidth * CellHeight]bool
+
+// set 设置位图中的像素，越界时忽略
+func (g *glyph) set(x, y int) {
+	if x >= 0 && x < CellWidth && y >= 0 && y < CellHeight {
+		g[y*CellWidth+x] = true
+	}
+}
+
+// fill 填充矩形区域 [x0, x1) × [y0, y1)
+func (g *glyph) fill(x0, y0, x1, y1 int) {
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			g.set(x, y)
+		}
+	}
+}
+
+// glyphCache 已生成的字形缓存
+var glyphCache sync.Map // rune -> *glyph
+
+// glyphFor 返回字符对应的位图
+// ASCII 使用 basicfont 7x13 点阵；方块、阴影、制表符和盲文按规则绘制；
+// 特效中常用的符号使用内置的 5x7 点阵；其余字符生成稳定的伪字形。
+func glyphFor(r rune) *glyph {
+	if g, ok := glyphCache.Load(r); ok {
+		return g.(*glyph)
+	}
+
+	g := new(glyph)
+	switch {
+	case r == ' ' || r == 0 || r == '　' || r == '⠀':
+		// 空白字符
+	case r >= 0x2580 && r <= 0x259F:
+		drawBlock(g, r)
+	case r >= 0x2500 && r <= 0x257F:
+		if !drawBox(g, r) {
+			drawHashed(g, r)
+		}
+	case r >= 0x2800 && r <= 0x28FF:
+		drawBraille(g, r)
+	default:
+		if !drawSymbol(g, r) && !drawBasic(g, r) {
+			drawHashed(g, r)
+		}
+	}
+
+	glyphCache.Store(r, g)
+	return g
+}
+
+// drawBasic 使用 basicfont 绘制字符，字体中没有该字符时返回 false
+func drawBasic(g *glyph, r rune) bool {
+	face := basicfont.Face7x13
+	if r == 0xFFFD {
+		return false
+	}
+
+	dot := fixed.P(0, face.Ascent)
+	dr, mask, maskp, _, ok := face.Glyph(dot, r)
+	if !ok {
+		return false
+	}
+
+	for y := dr.Min.Y; y < dr.Max.Y; y++ {
+		for x := dr.Min.X; x < dr.Max.X; x++ {
+			p := maskp.Add(image.Pt(x-dr.Min.X, y-dr.Min.Y))
+			if _, _, _, a := mask.At(p.X, p.Y).RGBA(); a >= 0x8000 {
+				g.set(x, y)
+			}
+		}
+	}
+
+	return true
+}
+
+// drawBlock 绘制方块元素（U+2580 - U+259F）
+func drawBlock(g *glyph, r rune) {
+	const w, h = CellWidth, CellHeight
+	halfW, halfH := (w+1)/2, (h+1)/2
+
+	switch {
+	case r == 0x2580: // ▀
+		g.fill(0, 0, w, halfH)
+	case r >= 0x2581 && r <= 0x2588: // ▁ - █ 下方 n/8
+		n := int(r - 0x2580)
+		g.fill(0, h-(h*n+4)/8, w, h)
+	case r >= 0x2589 && r <= 0x258F: // ▉ - ▏ 左侧 n/8
+		n := int(0x2590 - r)
+		g.fill(0, 0, (w*n+4)/8, h)
+	case r == 0x2590: // ▐
+		g.fill(w/2, 0, w, h)
+	case r >= 0x2591 && r <= 0x2593: // ░ ▒ ▓
+		drawShade(g, int(r-0x2590))
+	case r == 0x2594: // ▔
+		g.fill(0, 0, w, (h+7)/8)
+	case r == 0x2595: // ▕
+		g.fill(w-1, 0, w, h)
+	default: // ▖ - ▟ 象限
+		quads := map[rune]int{
+			0x2596: 0b0100, 0x2597: 0b1000, 0x2598: 0b0001, 0x2599: 0b1101,
+			0x259A: 0b1001, 0x259B: 0b0111, 0x259C: 0b1011, 0x259D: 0b0010,
+			0x259E: 0b0110, 0x259F: 0b1110,
+		}
+		q := quads[r]
+		if q&0b0001 != 0 {
+			g.fill(0, 0, halfW, halfH)
+		}
+		if q&0b0010 != 0 {
+			g.fill(halfW, 0, w, halfH)
+		}
+		if q&0b0100 != 0 {
+			g.fill(0, halfH, halfW, h)
+		}
+		if q&0b1000 != 0 {
+			g.fill(halfW, halfH, w, h)
+		}
+	}
+}
+
+// drawShade 绘制阴影字符，level 为 1（░）到 3（▓）
+func drawShade(g *glyph, level int) {
+	for y := 0; y < CellHeight; y++ {
+		for x := 0; x < CellWidth; x++ {
+			var on bool
+			switch level {
+			case 1:
+				on = x%2 == 0 && y%2 == 0
+			case 2:
+				on = (x+y)%2 == 0
+			default:
+				on = !(x%2 == 0 && y%2 == 0)
+			}
+			if on {
+				g.set(x, y)
+			}
+		}
+	}
+}
+
+// 制表符线段方向
+const (
+	boxLeft = 1 << iota
+	boxRight
+	boxUp
+	boxDown
+	boxDouble // 双线
+)
+
+// boxRunes 常用制表符的线段组成（粗线按细线绘制）
+var boxRunes = map[rune]int{
+	'─': boxLeft | boxRight, '━': boxLeft | boxRight,
+	'│': boxUp | boxDown, '┃': boxUp | boxDown,
+	'┌': boxRight | boxDown, '┏': boxRight | boxDown, '╭': boxRight | boxDown,
+	'┐': boxLeft | boxDown, '┓': boxLeft | boxDown, '╮': boxLeft | boxDown,
+	'└': boxRight | boxUp, '┗': boxRight | boxUp, '╰': boxRight | boxUp,
+	'┘': boxLeft | boxUp, '┛': boxLeft | boxUp, '╯': boxLeft | boxUp,
+	'├': boxUp | boxDown | boxRight, '┣': boxUp | boxDown | boxRight,
+	'┤': boxUp | boxDown | boxLeft, '┫': boxUp | boxDown | boxLeft,
+	'┬': boxLeft | boxRight | boxDown, '┳': boxLeft | boxRight | boxDown,
+	'┴': boxLeft | boxRight | boxUp, '┻': boxLeft | boxRight | boxUp,
+	'┼': boxLeft | boxRight | boxUp | boxDown, '╋': boxLeft | boxRight | boxUp | boxDown,
+	'═': boxDouble | boxLeft | boxRight,
+	'║': boxDouble | boxUp | boxDown,
+	'╔': boxDouble | boxRight | boxDown,
+	'╗': boxDouble | boxLeft | boxDown,
+	'╚': boxDouble | boxRight | boxUp,
+	'╝': boxDouble | boxLeft | boxUp,
+	'╠': boxDouble | boxUp | boxDown | boxRight,
+	'╣': boxDouble | boxUp | boxDown | boxLeft,
+	'╦': boxDouble | boxLeft | boxRight | boxDown,
+	'╩': boxDouble | boxLeft | boxRight | boxUp,
+	'╬': boxDouble | boxLeft | boxRight | boxUp | boxDown,
+}
+
+// drawBox 绘制制表符，不支持的字符返回 false
+func drawBox(g *glyph, r rune) bool {
+	segments, ok := boxRunes[r]
+	if !ok {
+		return false
+	}
+
+	cx, cy := CellWidth/2, CellHeight/2
+	offsets := []int{0}
+	if segments&boxDouble != 0 {
+		offsets = []int{-1, 1}
+	}
+
+	for _, d := range offsets {
+		if segments&boxLeft != 0 {
+			g.fill(0, cy+d, cx+1, cy+d+1)
+		}
+		if segments&boxRight != 0 {
+			g.fill(cx, cy+d, CellWidth, cy+d+1)
+		}
+		if segments&boxUp != 0 {
+			g.fill(cx+d, 0, cx+d+1, cy+1)
+		}
+		if segments&boxDown != 0 {
+			g.fill(cx+d, cy, cx+d+1, CellHeight)
+		}
+	}
+
+	return true
+}
+
+// drawBraille 绘制盲文点阵（U+2800 - U+28FF，2x4 点）
+func drawBraille(g *glyph, r rune) {
+	// 盲文位序：每一位对应的 (列, 行)
+	dots := [8][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}, {0, 3}, {1, 3}}
+	bits := int(r - 0x2800)
+
+	for i, dot := range dots {
+		if bits&(1<<i) == 0 {
+			continue
+		}
+		x := 1 + dot[0]*3
+		y := 1 + dot[1]*3
+		g.fill(x, y, x+2, y+2)
+	}
+}
+
+// symbols 特效中常用的非 ASCII 符号（5x7 点阵）
+var symbols = map[rune][7]string{
+	'·': {".....", ".....", ".....", "..#..", ".....", ".....", "....."},
+	'•': {".....", ".....", ".###.", ".###.", ".###.", ".....", "....."},
+	'×': {".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "....."},
+	'π': {".....", "#####", ".#.#.", ".#.#.", ".#.#.", ".#..#", "....."},
+	'→': {".....", "..#..", "...#.", "#####", "...#.", "..#..", "....."},
+	'∼': {".....", ".....", ".#...", "#.#.#", "...#.", ".....", "....."},
+	'∿': {".....", ".#...", "#.#..", "..#.#", "...#.", ".....", "....."},
+	'≈': {".....", ".#...", "#.#.#", "...#.", ".#...", "#.#.#", "...#."},
+	'≋': {".#...", "#.#.#", "...#.", ".#...", "#.#.#", "...#.", "....."},
+	'●': {".....", ".###.", "#####", "#####", "#####", ".###.", "....."},
+	'○': {".....", ".###.", "#...#", "#...#", "#...#", ".###.", "....."},
+	'◯': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'◉': {".....", ".###.", "#...#", "#.#.#", "#...#", ".###.", "....."},
+	'♥': {".....", ".#.#.", "#####", "#####", ".###.", "..#..", "....."},
+	'✦': {"..#..", "..#..", ".###.", "#####", ".###.", "..#..", "..#.."},
+	'✧': {"..#..", "..#..", ".#.#.", "#...#", ".#.#.", "..#..", "..#.."},
+	'✻': {"..#..", "#.#.#", ".###.", "#####", ".###.", "#.#.#", "..#.."},
+	'❅': {"..#..", "#.#.#", ".#.#.", "#.#.#", ".#.#.", "#.#.#", "..#.."},
+	'❆': {"#.#.#", ".###.", "##.##", ".###.", "##.##", ".###.", "#.#.#"},
+	'★': {"..#..", "..#..", "#####", ".###.", ".###.", "##.##", "#...#"},
+	'☆': {"..#..", "..#..", "##.##", ".#.#.", ".#.#.", "##.##", "#...#"},
+	'■': {".....", "#####", "#####", "#####", "#####", "#####", "....."},
+	'□': {".....", "#####", "#...#", "#...#", "#...#", "#####", "....."},
+	'◆': {"..#..", ".###.", "#####", "#####", "#####", ".###.", "..#.."},
+	'◇': {"..#..", ".#.#.", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+}
+
+// drawSymbol 绘制内置 5x7 点阵符号（居中于单元格），不支持的字符返回 false
+func drawSymbol(g *glyph, r rune) bool {
+	rows, ok := symbols[r]
+	if !ok {
+		return false
+	}
+
+	x0, y0 := 1, 3
+	for y, row := range rows {
+		for x, c := range row {
+			if c == '#' {
+				g.set(x0+x, y0+y)
+			}
+		}
+	}
+
+	return true
+}
+
+// drawHashed 为字体中没有的字符（如片假名、汉字）生成稳定的伪字形
+// 同一字符总是得到相同的 5x7 点阵，画面上仍能分辨字符的变化
+func drawHashed(g *glyph, r rune) {
+	h := uint32(r)*2654435761 ^ 0x9E3779B9
+	x0, y0 := 1, 3
+
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 5; x++ {
+			h ^= h << 13
+			h ^= h >> 17
+			h ^= h << 5
+			if h%5 < 2 {
+				g.set(x0+x, y0+y)
+			}
+		}
+	}
+}
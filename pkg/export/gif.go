@@ -0,0 +1,250 @@
+package export
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/headless"
+	gradient "github.com/symbolmove/symbol_move/pkg/palette"
+)
+
+// GIF 帧率范围（GIF 帧间隔以 1/100 秒为单位，浏览器会把小于 2 的间隔当作 10 处理）
+const (
+	MinFPS = 1
+	MaxFPS = 50
+)
+
+// 终端默认颜色
+var (
+	defaultForeground = color.RGBA{0xcc, 0xcc, 0xcc, 0xff}
+	defaultBackground = color.RGBA{0x00, 0x00, 0x00, 0xff}
+)
+
+// Options GIF 导出选项
+type Options struct {
+	Width    int               // 字符网格列数
+	Height   int               // 字符网格行数
+	Duration time.Duration     // 导出时长（特效的模拟时间）
+	FPS      int               // GIF 帧率，特效帧按此帧率抽样
+	Seed     int64             // 随机种子（0 表示随机）
+	Palette  *gradient.Palette // 调色板（nil 表示使用特效自己的配色）
+}
+
+// DefaultOptions 返回默认导出选项
+func DefaultOptions() Options {
+	return Options{
+		Width:    80,
+		Height:   24,
+		Duration: 5 * time.Second,
+		FPS:      20,
+	}
+}
+
+// validate 校验导出选项
+func (o Options) validate() error {
+	if o.Width <= 0 || o.Height <= 0 {
+		return fmt.Errorf("网格尺寸必须大于 0: %dx%d", o.Width, o.Height)
+	}
+	if o.Duration <= 0 {
+		return fmt.Errorf("导出时长必须大于 0: %v", o.Duration)
+	}
+	if o.FPS < MinFPS || o.FPS > MaxFPS {
+		return fmt.Errorf("GIF 帧率必须在 %d 到 %d 之间: %d", MinFPS, MaxFPS, o.FPS)
+	}
+	return nil
+}
+
+// GIF 以无头方式运行特效，并把画面编码为动画 GIF
+func GIF(effect effects.Effect, opts Options) (*gif.GIF, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	runner := headless.New(opts.Width, opts.Height)
+	runner.Seed = opts.Seed
+	runner.Palette = opts.Palette
+
+	frames, err := runner.RunFor(effect, opts.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	return Encode(frames, opts.FPS), nil
+}
+
+// WriteGIF 导出特效动画并写入 w
+func WriteGIF(w io.Writer, effect effects.Effect, opts Options) error {
+	anim, err := GIF(effect, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := gif.EncodeAll(w, anim); err != nil {
+		return fmt.Errorf("编码 GIF 失败: %w", err)
+	}
+	return nil
+}
+
+// Encode 把帧快照编码为循环播放的动画 GIF
+// 按帧的模拟时间以 fps 抽样，并合并内容相同的相邻帧
+func Encode(frames []*frame.Frame, fps int) *gif.GIF {
+	if fps < MinFPS {
+		fps = MinFPS
+	}
+	if fps > MaxFPS {
+		fps = MaxFPS
+	}
+	interval := time.Second / time.Duration(fps)
+
+	// 按固定的时间网格抽样，使平均帧率与 fps 一致
+	var kept []*frame.Frame
+	var next time.Duration
+	for _, f := range frames {
+		if len(kept) > 0 && (f.Time < next || f.Equal(kept[len(kept)-1])) {
+			continue
+		}
+		kept = append(kept, f)
+		for next <= f.Time {
+			next += interval
+		}
+	}
+
+	anim := &gif.GIF{}
+	for i, f := range kept {
+		// 按绝对时间换算为 1/100 秒后再求差，避免舍入误差累积
+		delay := centis(interval)
+		if i+1 < len(kept) {
+			delay = centis(kept[i+1].Time) - centis(f.Time)
+		}
+
+		anim.Image = append(anim.Image, Render(f))
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	return anim
+}
+
+// centis 把时长换算为 1/100 秒
+func centis(d time.Duration) int {
+	return int((d + 5*time.Millisecond) / (10 * time.Millisecond))
+}
+
+// Render 使用内置点阵字体把一帧渲染为调色板图像
+// 颜色不超过 256 种时使用精确调色板，否则近似到 Plan9 调色板
+func Render(f *frame.Frame) *image.Paletted {
+	type cellColors struct {
+		fg, bg    color.RGBA
+		underline bool
+	}
+
+	cells := make([]cellColors, len(f.Cells))
+	used := make(map[color.RGBA]bool)
+	for i, cell := range f.Cells {
+		fg, bg, underline := resolveStyle(cell.Style)
+		cells[i] = cellColors{fg, bg, underline}
+		used[fg] = true
+		used[bg] = true
+	}
+
+	var pal color.Palette
+	if len(used) <= 256 {
+		colors := make([]color.RGBA, 0, len(used))
+		for c := range used {
+			colors = append(colors, c)
+		}
+		// 排序使相同画面得到相同的调色板
+		sort.Slice(colors, func(i, j int) bool {
+			return rgbKey(colors[i]) < rgbKey(colors[j])
+		})
+
+		pal = make(color.Palette, len(colors))
+		for i, c := range colors {
+			pal[i] = c
+		}
+	} else {
+		pal = palette.Plan9
+	}
+
+	indexes := make(map[color.RGBA]uint8, len(used))
+	index := func(c color.RGBA) uint8 {
+		i, ok := indexes[c]
+		if !ok {
+			i = uint8(pal.Index(c))
+			indexes[c] = i
+		}
+		return i
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, f.Width*CellWidth, f.Height*CellHeight), pal)
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			i := y*f.Width + x
+			g := glyphFor(f.Cells[i].Rune)
+			fg, bg := index(cells[i].fg), index(cells[i].bg)
+
+			for py := 0; py < CellHeight; py++ {
+				row := img.Pix[(y*CellHeight+py)*img.Stride+x*CellWidth:]
+				for px := 0; px < CellWidth; px++ {
+					on := g[py*CellWidth+px] || (cells[i].underline && py == CellHeight-2)
+					if on {
+						row[px] = fg
+					} else {
+						row[px] = bg
+					}
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// rgbKey 返回颜色的 24 位 RGB 值
+func rgbKey(c color.RGBA) uint32 {
+	return uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+}
+
+// resolveStyle 把 tcell 样式解析为前景色、背景色和是否带下划线
+func resolveStyle(style tcell.Style) (fg, bg color.RGBA, underline bool) {
+	fgColor, bgColor, attrs := style.Decompose()
+
+	fg = toRGBA(fgColor, defaultForeground)
+	bg = toRGBA(bgColor, defaultBackground)
+
+	if attrs&tcell.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+	if attrs&tcell.AttrDim != 0 {
+		fg = color.RGBA{
+			uint8((uint16(fg.R) + uint16(bg.R)) / 2),
+			uint8((uint16(fg.G) + uint16(bg.G)) / 2),
+			uint8((uint16(fg.B) + uint16(bg.B)) / 2),
+			0xff,
+		}
+	}
+
+	return fg, bg, style.GetUnderlineStyle() != tcell.UnderlineStyleNone
+}
+
+// toRGBA 把 tcell 颜色转换为 RGBA，默认颜色使用 def
+func toRGBA(c tcell.Color, def color.RGBA) color.RGBA {
+	if !c.Valid() || c&tcell.ColorSpecial != 0 {
+		return def
+	}
+
+	r, g, b := c.RGB()
+	if r < 0 {
+		return def
+	}
+
+	return color.RGBA{uint8(r), uint8(g), uint8(b), 0xff}
+}
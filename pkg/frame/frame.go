@@ -0,0 +1,117 @@
+package frame
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Cell 单个单元格的内容快照
+type Cell struct {
+	Rune  rune
+	Style tcell.Style
+}
+
+// Frame 屏幕单元格缓冲区快照
+type Frame struct {
+	Width  int
+	Height int
+	Cells  []Cell        // 按行优先顺序存储
+	Time   time.Duration // 采集时特效已运行的模拟时间（无头渲染时设置）
+}
+
+// New 创建指定尺寸的空白帧（全部为空格）
+func New(width, height int) *Frame {
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+
+	cells := make([]Cell, width*height)
+	for i := range cells {
+		cells[i] = Cell{Rune: ' ', Style: tcell.StyleDefault}
+	}
+
+	return &Frame{
+		Width:  width,
+		Height: height,
+		Cells:  cells,
+	}
+}
+
+// Capture 读取屏幕当前的单元格缓冲区并生成快照
+func Capture(screen tcell.Screen) *Frame {
+	width, height := screen.Size()
+	f := New(width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, _, style, _ := screen.GetContent(x, y)
+			if r == 0 {
+				r = ' '
+			}
+			f.Cells[y*width+x] = Cell{Rune: r, Style: style}
+		}
+	}
+
+	return f
+}
+
+// At 返回指定位置的单元格，越界时返回空白单元格
+func (f *Frame) At(x, y int) Cell {
+	if x < 0 || x >= f.Width || y < 0 || y >= f.Height {
+		return Cell{Rune: ' ', Style: tcell.StyleDefault}
+	}
+	return f.Cells[y*f.Width+x]
+}
+
+// Set 设置指定位置的单元格，越界时忽略
+func (f *Frame) Set(x, y int, cell Cell) {
+	if x < 0 || x >= f.Width || y < 0 || y >= f.Height {
+		return
+	}
+	f.Cells[y*f.Width+x] = cell
+}
+
+// Text 返回帧的纯文本内容（不含样式），每行以换行符结尾
+func (f *Frame) Text() string {
+	var b strings.Builder
+	b.Grow((f.Width + 1) * f.Height)
+
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			b.WriteRune(f.Cells[y*f.Width+x].Rune)
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// Equal 判断两帧的尺寸、字符和样式是否完全一致
+func (f *Frame) Equal(other *Frame) bool {
+	if other == nil || f.Width != other.Width || f.Height != other.Height {
+		return false
+	}
+
+	for i := range f.Cells {
+		if f.Cells[i] != other.Cells[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Blank 判断帧是否全部为空白（仅含空格）
+func (f *Frame) Blank() bool {
+	for _, cell := range f.Cells {
+		if cell.Rune != ' ' {
+			return false
+		}
+	}
+	return true
+}
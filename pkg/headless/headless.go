@@ -0,0 +1,196 @@
+package headless
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+)
+
+// DefaultTimeout 默认的运行超时时间
+const DefaultTimeout = 30 * time.Second
+
+// Runner 无头渲染器
+// 在 tcell 模拟屏幕上驱动特效，并在每次 Show 时采集单元格快照
+type Runner struct {
+	Width   int              // 模拟屏幕宽度
+	Height  int              // 模拟屏幕高度
+	Timeout time.Duration    // 运行超时时间（0 表示使用 DefaultTimeout）
+	Seed    int64            // 随机种子（0 表示不注入，特效自行使用当前时间）
+	Clock   *effects.Clock   // 驱动特效的时钟（nil 表示每次运行使用新的模拟时钟）
+	Palette *palette.Palette // 调色板（nil 表示使用特效自己的配色）
+}
+
+// New 创建指定尺寸的无头渲染器
+func New(width, height int) *Runner {
+	return &Runner{
+		Width:   width,
+		Height:  height,
+		Timeout: DefaultTimeout,
+	}
+}
+
+// NewScreen 创建并初始化指定尺寸的模拟屏幕
+func NewScreen(width, height int) (tcell.SimulationScreen, error) {
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		return nil, fmt.Errorf("初始化模拟屏幕失败: %w", err)
+	}
+	screen.SetSize(width, height)
+	return screen, nil
+}
+
+// RunID 从全局注册表创建指定 ID 的特效并运行
+func (r *Runner) RunID(id string, frames int) ([]*frame.Frame, error) {
+	factory, err := effects.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return r.Run(factory(), frames)
+}
+
+// Run 初始化并运行特效，采集到 frames 帧后发送退出信号
+// 返回按顺序采集的帧快照
+func (r *Runner) Run(effect effects.Effect, frames int) ([]*frame.Frame, error) {
+	if frames <= 0 {
+		return nil, fmt.Errorf("帧数必须大于 0: %d", frames)
+	}
+	return r.run(effect, frames, 0)
+}
+
+// RunFor 初始化并运行特效，直到模拟时间达到 duration 后发送退出信号
+// 返回期间采集的帧快照，每帧的 Time 记录了采集时的模拟时间
+func (r *Runner) RunFor(effect effects.Effect, duration time.Duration) ([]*frame.Frame, error) {
+	if duration <= 0 {
+		return nil, fmt.Errorf("运行时长必须大于 0: %v", duration)
+	}
+	return r.run(effect, 0, duration)
+}
+
+// run 运行特效，采集到 limit 帧（limit > 0）或模拟时间达到 until（until > 0）时结束
+func (r *Runner) run(effect effects.Effect, limit int, until time.Duration) ([]*frame.Frame, error) {
+	sim, err := NewScreen(r.Width, r.Height)
+	if err != nil {
+		return nil, err
+	}
+	defer sim.Fini()
+
+	clock := r.Clock
+	if clock == nil {
+		// 模拟时钟按固定步长尽快出帧，渲染结果与真实时间无关
+		clock = effects.NewClockWithMode(effects.ClockSimulated)
+	}
+
+	screen := newCaptureScreen(sim, clock, limit, until)
+
+	effects.ApplySeed(effect, r.Seed)
+	effects.ApplyClock(effect, clock)
+
+	// 模拟屏幕支持真彩色，只需换用调色板
+	if err := effect.Init(effects.PaletteScreen(effect, screen, r.Palette, palette.DepthTrueColor)); err != nil {
+		return nil, fmt.Errorf("初始化失败: %w", err)
+	}
+	defer effect.Cleanup()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- effect.Run(screen.quit)
+	}()
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return nil, err
+		}
+	case <-time.After(timeout):
+		screen.stop()
+		<-errCh
+		if limit > 0 {
+			return nil, fmt.Errorf("等待特效帧超时（已采集 %d/%d 帧）", screen.count(), limit)
+		}
+		return nil, fmt.Errorf("等待特效运行 %v 超时（已采集 %d 帧）", until, screen.count())
+	}
+
+	return screen.frames, nil
+}
+
+// captureScreen 包装模拟屏幕，在每次 Show 时采集快照
+type captureScreen struct {
+	tcell.SimulationScreen
+
+	mu     sync.Mutex
+	frames []*frame.Frame
+	clock  *effects.Clock
+	start  float64       // 开始运行时时钟已推进的时间
+	limit  int           // 帧数上限（0 表示不限）
+	until  time.Duration // 模拟时间上限（0 表示不限）
+	quit   chan struct{}
+	once   sync.Once
+}
+
+// newCaptureScreen 创建采集屏幕
+func newCaptureScreen(sim tcell.SimulationScreen, clock *effects.Clock, limit int, until time.Duration) *captureScreen {
+	return &captureScreen{
+		SimulationScreen: sim,
+		frames:           make([]*frame.Frame, 0, limit),
+		clock:            clock,
+		start:            clock.Elapsed(),
+		limit:            limit,
+		until:            until,
+		quit:             make(chan struct{}),
+	}
+}
+
+// Show 刷新模拟屏幕并采集一帧
+func (c *captureScreen) Show() {
+	c.SimulationScreen.Show()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done() {
+		return
+	}
+
+	f := frame.Capture(c.SimulationScreen)
+	f.Time = time.Duration((c.clock.Elapsed() - c.start) * float64(time.Second))
+	c.frames = append(c.frames, f)
+
+	if c.done() {
+		c.stop()
+	}
+}
+
+// done 判断是否已采集足够的帧（调用方须持有 mu）
+func (c *captureScreen) done() bool {
+	if c.limit > 0 && len(c.frames) >= c.limit {
+		return true
+	}
+	if c.until > 0 && len(c.frames) > 0 && c.frames[len(c.frames)-1].Time >= c.until {
+		return true
+	}
+	return false
+}
+
+// count 返回已采集的帧数
+func (c *captureScreen) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.frames)
+}
+
+// stop 发送退出信号（可重复调用）
+func (c *captureScreen) stop() {
+	c.once.Do(func() {
+		close(c.quit)
+	})
+}
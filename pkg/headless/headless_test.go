@@ -0,0 +1,232 @@
+package headless_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/audio-visualizer"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/big-clock"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/digital-waterfall"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/dna-helix"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/fire-effect"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/fireworks"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/game-of-life"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/heartbeat"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/matrix-rain"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/matrix-tunnel"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/maze-generator"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/ocean-wave"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/particle-burst"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/plasma"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/qrcode-gen"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/rainbow-wave"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/scenes"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/snake-ai"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/snowfall"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/starry-sky"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/tetris-auto"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/typewriter-code"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/water-ripple"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/wave-text"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/headless"
+)
+
+func TestRunAllEffects(t *testing.T) {
+	const width, height, frames = 60, 20, 2
+
+	for _, metadata := range effects.List() {
+		metadata := metadata
+		t.Run(metadata.ID, func(t *testing.T) {
+			t.Parallel()
+
+			runner := headless.New(width, height)
+			snapshots, err := runner.RunID(metadata.ID, frames)
+			if err != nil {
+				t.Fatalf("run %s: %v", metadata.ID, err)
+			}
+
+			if len(snapshots) != frames {
+				t.Fatalf("Expected %d frames, got %d", frames, len(snapshots))
+			}
+			for i, f := range snapshots {
+				if f.Width != width || f.Height != height {
+					t.Errorf("Frame %d: expected %dx%d, got %dx%d", i, width, height, f.Width, f.Height)
+				}
+			}
+		})
+	}
+}
+
+func TestRunUnknownEffect(t *testing.T) {
+	runner := headless.New(10, 5)
+	if _, err := runner.RunID("no-such-effect", 1); err == nil {
+		t.Error("Expected error for unknown effect")
+	}
+}
+
+func TestCaptureContent(t *testing.T) {
+	screen, err := headless.NewScreen(5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+
+	screen.SetContent(1, 0, 'A', nil, tcell.StyleDefault)
+	screen.SetContent(4, 1, 'B', nil, tcell.StyleDefault)
+
+	f := frame.Capture(screen)
+	if got, want := f.Text(), " A   \n    B\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if f.Blank() {
+		t.Error("Expected frame to be non-blank")
+	}
+}
+
+// update 重新生成 testdata 下的黄金帧文件：go test ./pkg/headless -update
+var update = flag.Bool("update", false, "update golden frame files")
+
+// timeDependent 画面依赖当前时间的特效，无法使用黄金帧比对
+var timeDependent = map[string]bool{
+	"big-clock":   true,
+	"night-clock": true,
+	"qrcode-gen":  true,
+}
+
+func TestGoldenFrames(t *testing.T) {
+	const width, height, frames = 48, 16, 60
+
+	for _, metadata := range effects.List() {
+		if timeDependent[metadata.ID] {
+			continue
+		}
+
+		metadata := metadata
+		t.Run(metadata.ID, func(t *testing.T) {
+			t.Parallel()
+
+			runner := headless.New(width, height)
+			runner.Seed = 20240101
+
+			snapshots, err := runner.RunID(metadata.ID, frames)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := snapshots[len(snapshots)-1].Text()
+
+			golden := filepath.Join("testdata", metadata.ID+".golden")
+			if *update {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("Frame %d does not match %s\ngot:\n%s\nwant:\n%s", frames, golden, got, want)
+			}
+		})
+	}
+}
+
+func TestSeedReplay(t *testing.T) {
+	// 模拟时钟下帧步长固定，相同种子应产生完全相同的帧
+	for _, id := range []string{"game-of-life", "matrix-rain", "snowfall", "fireworks", "snake-ai", "winter-fire"} {
+		id := id
+		t.Run(id, func(t *testing.T) {
+			t.Parallel()
+
+			runner := headless.New(40, 12)
+			runner.Seed = 42
+
+			first, err := runner.RunID(id, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := runner.RunID(id, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := range first {
+				if !first[i].Equal(second[i]) {
+					t.Errorf("Frame %d differs between runs with the same seed", i)
+				}
+			}
+		})
+	}
+}
+
+func TestParams(t *testing.T) {
+	for _, metadata := range effects.List() {
+		metadata := metadata
+		t.Run(metadata.ID, func(t *testing.T) {
+			t.Parallel()
+
+			factory, err := effects.Get(metadata.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ps := effects.ParamsOf(factory())
+			if ps == nil {
+				t.Fatal("Expected effect to implement effects.Configurable")
+			}
+
+			for _, p := range ps.Params() {
+				value, err := ps.Get(p.Name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := ps.Set(p.Name, effects.FormatParamValue(value)); err != nil {
+					t.Errorf("Default value of %s does not round-trip: %v", p.Name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParamExtremes(t *testing.T) {
+	// 数值参数取最小值和最大值时特效也应正常运行
+	for _, metadata := range effects.List() {
+		metadata := metadata
+		t.Run(metadata.ID, func(t *testing.T) {
+			t.Parallel()
+
+			factory, err := effects.Get(metadata.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, extreme := range []func(effects.Param) float64{
+				func(p effects.Param) float64 { return p.Min },
+				func(p effects.Param) float64 { return p.Max },
+			} {
+				values := make(map[string]any)
+				for _, p := range effects.ParamsOf(factory()).Params() {
+					if p.Ranged() {
+						values[p.Name] = effects.FormatParamValue(extreme(p))
+					}
+				}
+
+				effect := factory()
+				if err := effects.ApplyParams(effect, values); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := headless.New(30, 10).Run(effect, 3); err != nil {
+					t.Fatalf("run with %v: %v", values, err)
+				}
+			}
+		})
+	}
+}
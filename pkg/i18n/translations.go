@@ -7,6 +7,26 @@ const (
 	KeyDescLabel        = "desc_label"
 	KeyHints            = "hints"
 	KeyLanguageIndicator = "lang_indicator"
+	KeyPreview          = "preview"
+	KeySearchLabel      = "search_label"
+	KeyTagsLabel        = "tags_label"
+	KeyTagAll           = "tag_all"
+	KeyNoMatch          = "no_match"
+	KeyFavorites        = "favorites"
+	KeyRecent           = "recent"
+	KeySortLabel        = "sort_label"
+	KeySortRegistration = "sort_registration"
+	KeySortName         = "sort_name"
+	KeySortAuthor       = "sort_author"
+	KeySortPlays        = "sort_plays"
+
+	// 参数设置面板
+	KeySettingsTitle     = "settings_title"
+	KeySettingsHints     = "settings_hints"
+	KeySettingsEditHints = "settings_edit_hints"
+	KeySettingsEmpty     = "settings_empty"
+	KeySettingsModified  = "settings_modified"
+	KeySettingsSaveError = "settings_save_error"
 )
 
 // uiTexts 界面文本翻译映射
@@ -15,15 +35,51 @@ var uiTexts = map[Language]map[string]string{
 		KeyTitle:             "符动世界(SymbolMove)",
 		KeySubtitle:          "字符符号在动，创造世界",
 		KeyDescLabel:         "描述:",
-		KeyHints:             "↑↓←→:选择 | Enter:确认 | 1-9/0:快捷键 | T:切换语言 | q/Ctrl+C:退出",
+		KeyHints:             "↑↓←→:选择 | Enter:确认 | /:搜索 | Tab:标签 | F:收藏 | 1-9/0:快捷键 | S:设置 | T:切换语言 | q/Ctrl+C:退出",
 		KeyLanguageIndicator: "中文",
+		KeyPreview:           "预览",
+		KeySearchLabel:       "搜索",
+		KeyTagsLabel:         "标签:",
+		KeyTagAll:            "全部",
+		KeyNoMatch:           "没有匹配的特效（Esc 清除筛选）",
+		KeyFavorites:         "★ 收藏",
+		KeyRecent:            "最近",
+		KeySortLabel:         "排序(O):",
+		KeySortRegistration:  "默认",
+		KeySortName:          "名称",
+		KeySortAuthor:        "作者",
+		KeySortPlays:         "最常运行",
+		KeySettingsTitle:     "参数设置",
+		KeySettingsHints:     "↑↓:选择 | ←→:调整 | Enter:编辑 | r:恢复默认 | s:保存 | Esc:取消",
+		KeySettingsEditHints: "输入新值 | Enter:确认 | Esc:取消编辑",
+		KeySettingsEmpty:     "该特效没有可调参数",
+		KeySettingsModified:  "(已自定义)",
+		KeySettingsSaveError: "保存失败: ",
 	},
 	LanguageEnglish: {
 		KeyTitle:             "SymbolMove",
 		KeySubtitle:          "Characters in Motion, Creating Worlds",
 		KeyDescLabel:         "Description:",
-		KeyHints:             "↑↓←→:Select | Enter:Confirm | 1-9/0:Shortcut | T:Switch Lang | q/Ctrl+C:Quit",
+		KeyHints:             "↑↓←→:Select | Enter:Confirm | /:Search | Tab:Tags | F:Favorite | 1-9/0:Shortcut | S:Settings | T:Switch Lang | q/Ctrl+C:Quit",
 		KeyLanguageIndicator: "English",
+		KeyPreview:           "Preview",
+		KeySearchLabel:       "Search",
+		KeyTagsLabel:         "Tags:",
+		KeyTagAll:            "All",
+		KeyNoMatch:           "No matching effects (Esc to clear filters)",
+		KeyFavorites:         "★ Favorites",
+		KeyRecent:            "Recent",
+		KeySortLabel:         "Sort (O):",
+		KeySortRegistration:  "Default",
+		KeySortName:          "Name",
+		KeySortAuthor:        "Author",
+		KeySortPlays:         "Most played",
+		KeySettingsTitle:     "Settings",
+		KeySettingsHints:     "↑↓:Select | ←→:Adjust | Enter:Edit | r:Reset | s:Save | Esc:Cancel",
+		KeySettingsEditHints: "Type a new value | Enter:Confirm | Esc:Cancel edit",
+		KeySettingsEmpty:     "This effect has no adjustable parameters",
+		KeySettingsModified:  "(customized)",
+		KeySettingsSaveError: "Save failed: ",
 	},
 }
 
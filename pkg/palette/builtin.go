@@ -0,0 +1,57 @@
+package palette
+
+import "github.com/gdamore/tcell/v2"
+
+// 内置的命名渐变
+var (
+	// Fire 火焰：暗红、红、橙、黄到接近白色的高温
+	Fire = New("fire",
+		tcell.NewRGBColor(60, 0, 0),
+		tcell.NewRGBColor(139, 0, 0),
+		tcell.NewRGBColor(230, 20, 0),
+		tcell.NewRGBColor(255, 140, 0),
+		tcell.NewRGBColor(255, 230, 0),
+		tcell.NewRGBColor(255, 255, 200),
+	)
+
+	// Ocean 海洋：深海蓝到浪花白
+	Ocean = New("ocean",
+		tcell.NewRGBColor(0, 10, 40),
+		tcell.NewRGBColor(0, 45, 110),
+		tcell.NewRGBColor(0, 100, 170),
+		tcell.NewRGBColor(0, 170, 200),
+		tcell.NewRGBColor(120, 220, 235),
+		tcell.NewRGBColor(240, 255, 255),
+	)
+
+	// Matrix 矩阵：暗绿到亮绿，最亮处为白色
+	Matrix = New("matrix",
+		tcell.NewRGBColor(0, 50, 0),
+		tcell.NewRGBColor(0, 100, 0),
+		tcell.NewRGBColor(0, 170, 0),
+		tcell.NewRGBColor(144, 238, 144),
+		tcell.NewRGBColor(255, 255, 255),
+	)
+
+	// Rainbow 彩虹：红、黄、绿、蓝到紫
+	Rainbow = New("rainbow",
+		tcell.NewRGBColor(255, 0, 0),
+		tcell.NewRGBColor(255, 255, 0),
+		tcell.NewRGBColor(0, 255, 0),
+		tcell.NewRGBColor(0, 190, 255),
+		tcell.NewRGBColor(0, 0, 255),
+		tcell.NewRGBColor(160, 0, 200),
+	)
+
+	// Grayscale 灰度：黑到白
+	Grayscale = New("grayscale",
+		tcell.NewRGBColor(0, 0, 0),
+		tcell.NewRGBColor(255, 255, 255),
+	)
+)
+
+func init() {
+	for _, p := range []*Palette{Fire, Ocean, Matrix, Rainbow, Grayscale} {
+		Register(p)
+	}
+}
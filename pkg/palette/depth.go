@@ -0,0 +1,95 @@
+package palette
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Depth 终端能显示的颜色数
+type Depth int
+
+const (
+	Depth16        Depth = 16
+	Depth256       Depth = 256
+	DepthTrueColor Depth = 1 << 24
+)
+
+// Detect 按 tcell 报告的颜色数判断终端的颜色深度，少于 16 色的终端按 16 色处理
+func Detect(screen tcell.Screen) Depth {
+	switch colors := screen.Colors(); {
+	case colors >= int(DepthTrueColor):
+		return DepthTrueColor
+	case colors >= int(Depth256):
+		return Depth256
+	}
+	return Depth16
+}
+
+// cubeLevels xterm 256 色中 6×6×6 色立方每一级的分量值
+var cubeLevels = [6]int32{0, 95, 135, 175, 215, 255}
+
+// Quantize 把颜色量化为终端能显示的颜色
+// 终端默认颜色和终端本身支持的调色板颜色保持不变；256 色取色立方或灰阶中最接近的颜色，16 色取基本颜色中最接近的
+func (d Depth) Quantize(c tcell.Color) tcell.Color {
+	if d >= DepthTrueColor || !c.Valid() || c == tcell.ColorDefault {
+		return c
+	}
+	if c&tcell.ColorIsRGB == 0 && int(c-tcell.ColorValid) < int(d) {
+		return c
+	}
+
+	r, g, b := c.RGB()
+	if d >= Depth256 {
+		return nearest256(r, g, b)
+	}
+	return nearest(r, g, b, 0, 16)
+}
+
+// nearest256 在 xterm 256 色的色立方与灰阶中取最接近的颜色
+// 前 16 个基本颜色的实际 RGB 值由终端主题决定，不参与比较
+func nearest256(r, g, b int32) tcell.Color {
+	level := func(v int32) int {
+		best := 0
+		for i, l := range cubeLevels {
+			if abs(l-v) < abs(cubeLevels[best]-v) {
+				best = i
+			}
+		}
+		return best
+	}
+	ri, gi, bi := level(r), level(g), level(b)
+	cube := tcell.PaletteColor(16 + 36*ri + 6*gi + bi)
+
+	// 灰阶 232~255 的分量为 8 + 10i
+	gray := min(23, max(0, int((r+g+b)/3-3)/10))
+	grey := tcell.PaletteColor(232 + gray)
+
+	if distance(cube, r, g, b) <= distance(grey, r, g, b) {
+		return cube
+	}
+	return grey
+}
+
+// nearest 在调色板颜色 [from, to) 中取最接近的颜色
+func nearest(r, g, b int32, from, to int) tcell.Color {
+	best := tcell.PaletteColor(from)
+	for i := from + 1; i < to; i++ {
+		if c := tcell.PaletteColor(i); distance(c, r, g, b) < distance(best, r, g, b) {
+			best = c
+		}
+	}
+	return best
+}
+
+// distance 颜色之间按人眼敏感度加权的距离平方
+func distance(c tcell.Color, r, g, b int32) int32 {
+	cr, cg, cb := c.RGB()
+	dr, dg, db := cr-r, cg-g, cb-b
+	return 2*dr*dr + 4*dg*dg + 3*db*db
+}
+
+func abs(v int32) int32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,122 @@
+package palette
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Palette 由等间距的颜色节点组成的渐变，从暗到亮排列
+type Palette struct {
+	Name  string
+	Stops []tcell.Color
+}
+
+// New 创建渐变，stops 至少一个颜色
+func New(name string, stops ...tcell.Color) *Palette {
+	return &Palette{Name: name, Stops: stops}
+}
+
+// At 返回渐变在 t（0 到 1）处的颜色，在相邻两个节点之间按 RGB 线性插值
+func (p *Palette) At(t float64) tcell.Color {
+	if len(p.Stops) == 1 {
+		return p.Stops[0]
+	}
+
+	pos := max(0, min(1, t)) * float64(len(p.Stops)-1)
+	i := min(int(pos), len(p.Stops)-2)
+	frac := pos - float64(i)
+
+	ar, ag, ab := p.Stops[i].RGB()
+	br, bg, bb := p.Stops[i+1].RGB()
+	return tcell.NewRGBColor(lerp(ar, br, frac), lerp(ag, bg, frac), lerp(ab, bb, frac))
+}
+
+// Colors 在渐变上等间距取 n 个颜色，第一个为最暗端，最后一个为最亮端
+func (p *Palette) Colors(n int) []tcell.Color {
+	colors := make([]tcell.Color, n)
+	for i := range colors {
+		if n == 1 {
+			colors[i] = p.At(1)
+			continue
+		}
+		colors[i] = p.At(float64(i) / float64(n-1))
+	}
+	return colors
+}
+
+// lerp 在 a 与 b 之间线性插值
+func lerp(a, b int32, t float64) int32 {
+	return a + int32(math.Round(float64(b-a)*t))
+}
+
+var (
+	mu       sync.RWMutex
+	palettes = make(map[string]*Palette)
+	order    []string // 注册顺序
+)
+
+// Register 注册命名渐变，同名的渐变被替换
+func Register(p *Palette) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name := strings.ToLower(p.Name)
+	if _, ok := palettes[name]; !ok {
+		order = append(order, name)
+	}
+	palettes[name] = p
+}
+
+// Get 按名称获取渐变（忽略大小写）
+func Get(name string) (*Palette, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	p, ok := palettes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("未知的调色板 %q（可选 %s，或用逗号分隔的颜色自定义）", name, strings.Join(order, ", "))
+	}
+	return p, nil
+}
+
+// Names 返回所有命名渐变的名称（按注册顺序）
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), order...)
+}
+
+// Parse 解析调色板：命名渐变的名称，或用逗号分隔的颜色（#rrggbb、#rgb 或颜色名称）组成的自定义渐变
+func Parse(spec string) (*Palette, error) {
+	spec = strings.TrimSpace(spec)
+	if !strings.Contains(spec, ",") {
+		return Get(spec)
+	}
+
+	var stops []tcell.Color
+	for _, s := range strings.Split(spec, ",") {
+		color, err := ParseColor(s)
+		if err != nil {
+			return nil, err
+		}
+		stops = append(stops, color)
+	}
+	return New("custom", stops...), nil
+}
+
+// ParseColor 解析 #rrggbb、#rgb 或 tcell 支持的颜色名称
+func ParseColor(s string) (tcell.Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) == 4 && s[0] == '#' {
+		s = string([]byte{'#', s[1], s[1], s[2], s[2], s[3], s[3]})
+	}
+	color := tcell.GetColor(s)
+	if color == tcell.ColorDefault || !color.Valid() {
+		return tcell.ColorDefault, fmt.Errorf("无法识别的颜色 %q（可用 #rrggbb、#rgb 或颜色名称）", s)
+	}
+	return color, nil
+}
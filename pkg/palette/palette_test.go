@@ -0,0 +1,105 @@
+package palette
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestAt(t *testing.T) {
+	p := New("test", tcell.NewRGBColor(0, 0, 0), tcell.NewRGBColor(200, 100, 0), tcell.NewRGBColor(200, 200, 200))
+
+	for _, tc := range []struct {
+		t    float64
+		want tcell.Color
+	}{
+		{-1, tcell.NewRGBColor(0, 0, 0)},
+		{0.25, tcell.NewRGBColor(100, 50, 0)},
+		{0.5, tcell.NewRGBColor(200, 100, 0)},
+		{1, tcell.NewRGBColor(200, 200, 200)},
+		{2, tcell.NewRGBColor(200, 200, 200)},
+	} {
+		if got := p.At(tc.t); got != tc.want {
+			t.Errorf("At(%v) = %06x, want %06x", tc.t, got.Hex(), tc.want.Hex())
+		}
+	}
+
+	if colors := p.Colors(3); colors[0] != p.Stops[0] || colors[2] != p.Stops[2] {
+		t.Errorf("Expected Colors to include both ends, got %v", colors)
+	}
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse(" Fire ")
+	if err != nil || p != Fire {
+		t.Fatalf("Expected the fire palette, got %v, %v", p, err)
+	}
+
+	p, err = Parse("#000, #f80,white")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "custom" || len(p.Stops) != 3 || p.Stops[1] != tcell.NewRGBColor(0xff, 0x88, 0x00) {
+		t.Errorf("Unexpected custom palette %+v", p)
+	}
+
+	for _, spec := range []string{"nope", "#000,#zzzzzz"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	orange := tcell.NewRGBColor(255, 135, 0)
+	if got := DepthTrueColor.Quantize(orange); got != orange {
+		t.Errorf("Expected truecolor to keep %06x, got %06x", orange.Hex(), got.Hex())
+	}
+	// 256 色中 (255, 135, 0) 正好是色立方的 208 号
+	if got := Depth256.Quantize(orange); got != tcell.PaletteColor(208) {
+		t.Errorf("Expected colour 208, got %v", got)
+	}
+	if got := Depth256.Quantize(tcell.NewRGBColor(128, 128, 128)); got != tcell.PaletteColor(244) {
+		t.Errorf("Expected grey 244, got %v", got)
+	}
+	if got := Depth16.Quantize(tcell.NewRGBColor(250, 10, 10)); got != tcell.ColorRed {
+		t.Errorf("Expected red, got %v", got)
+	}
+
+	// 终端默认颜色与终端支持的调色板颜色保持不变
+	for _, c := range []tcell.Color{tcell.ColorDefault, tcell.ColorGreen} {
+		if got := Depth16.Quantize(c); got != c {
+			t.Errorf("Expected %v to be kept, got %v", c, got)
+		}
+	}
+	if got := Depth16.Quantize(tcell.ColorOrange); got == tcell.ColorOrange || int(got-tcell.ColorValid) >= 16 {
+		t.Errorf("Expected orange to become a basic colour, got %v", got)
+	}
+}
+
+func TestScreen(t *testing.T) {
+	sim := tcell.NewSimulationScreen("")
+	if err := sim.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Fini()
+	sim.SetSize(4, 1)
+
+	s := NewScreen(sim, Grayscale, DepthTrueColor)
+	s.SetContent(0, 0, 'a', nil, tcell.StyleDefault.Foreground(tcell.ColorWhite).Bold(true))
+	s.PutStrStyled(1, 0, "bc", tcell.StyleDefault.Foreground(tcell.ColorBlack))
+	s.SetContent(3, 0, 'd', nil, tcell.StyleDefault)
+
+	_, _, style, _ := sim.GetContent(0, 0)
+	if fg, _, attr := style.Decompose(); fg != tcell.NewRGBColor(255, 255, 255) || attr&tcell.AttrBold == 0 {
+		t.Errorf("Expected bold white, got %v %v", fg, attr)
+	}
+	_, _, style, _ = sim.GetContent(2, 0)
+	if fg, _, _ := style.Decompose(); fg != tcell.NewRGBColor(0, 0, 0) {
+		t.Errorf("Expected black from the string, got %v", fg)
+	}
+	_, _, style, _ = sim.GetContent(3, 0)
+	if style != tcell.StyleDefault {
+		t.Errorf("Expected the default style to be kept, got %v", style)
+	}
+}
@@ -0,0 +1,73 @@
+package palette
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Screen 重新着色的屏幕
+// 包装任意 tcell.Screen，让不了解调色板的特效也能换用调色板：写入的每个单元格按颜色的亮度从渐变中取色，
+// 再量化为终端能显示的颜色。终端默认颜色保持不变
+type Screen struct {
+	tcell.Screen
+
+	palette *Palette // nil 时只量化颜色
+	depth   Depth
+}
+
+// NewScreen 包装屏幕，palette 为 nil 时只按 depth 量化颜色
+func NewScreen(screen tcell.Screen, palette *Palette, depth Depth) *Screen {
+	return &Screen{Screen: screen, palette: palette, depth: depth}
+}
+
+// Style 返回重新着色后的样式
+func (s *Screen) Style(style tcell.Style) tcell.Style {
+	fg, bg, attr := style.Decompose()
+	return tcell.StyleDefault.Foreground(s.color(fg)).Background(s.color(bg)).Attributes(attr)
+}
+
+// color 按亮度在渐变上取色后量化
+func (s *Screen) color(c tcell.Color) tcell.Color {
+	if c == tcell.ColorDefault || !c.Valid() {
+		return c
+	}
+	if s.palette != nil {
+		c = s.palette.At(Luminance(c))
+	}
+	return s.depth.Quantize(c)
+}
+
+// Luminance 返回颜色 0~1 的相对亮度
+func Luminance(c tcell.Color) float64 {
+	r, g, b := c.RGB()
+	return (0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)) / 255
+}
+
+// SetContent 以重新着色后的样式设置单元格
+func (s *Screen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	s.Screen.SetContent(x, y, primary, combining, s.Style(style))
+}
+
+// Put 以重新着色后的样式写入 str 的第一个字素
+func (s *Screen) Put(x, y int, str string, style tcell.Style) (string, int) {
+	return s.Screen.Put(x, y, str, s.Style(style))
+}
+
+// PutStr 写入字符串
+func (s *Screen) PutStr(x, y int, str string) {
+	s.Screen.PutStrStyled(x, y, str, s.Style(tcell.StyleDefault))
+}
+
+// PutStrStyled 以重新着色后的样式写入字符串
+func (s *Screen) PutStrStyled(x, y int, str string, style tcell.Style) {
+	s.Screen.PutStrStyled(x, y, str, s.Style(style))
+}
+
+// SetCell 以重新着色后的样式设置单元格
+func (s *Screen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	s.Screen.SetCell(x, y, s.Style(style), ch...)
+}
+
+// Fill 以重新着色后的样式填满屏幕
+func (s *Screen) Fill(r rune, style tcell.Style) {
+	s.Screen.Fill(r, s.Style(style))
+}
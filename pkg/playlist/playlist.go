@@ -0,0 +1,185 @@
+package playlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/transition"
+)
+
+// 默认值
+const (
+	DefaultDuration       = 30 * time.Second     // 每个特效的播放时长
+	DefaultTransition     = transition.Crossfade // 特效之间的过渡效果
+	DefaultTransitionTime = time.Second          // 过渡时长
+)
+
+// Playlist 播放列表：按顺序或随机轮流播放多个特效，循环往复
+type Playlist struct {
+	Effects        []string `json:"effects,omitempty"`         // 特效 ID 或标签（标签展开为所有带有该标签的特效），为空表示全部特效
+	Duration       Duration `json:"duration,omitempty"`        // 每个特效的播放时长（0 表示 DefaultDuration）
+	Shuffle        bool     `json:"shuffle,omitempty"`         // 每一轮都打乱播放顺序
+	Transition     string   `json:"transition,omitempty"`      // 过渡效果名称（空表示 DefaultTransition）
+	TransitionTime Duration `json:"transition_time,omitempty"` // 过渡时长（0 表示 DefaultTransitionTime）
+}
+
+// Validate 校验播放时长与过渡效果
+func (p *Playlist) Validate() error {
+	if p.Duration < 0 {
+		return fmt.Errorf("duration 不能为负数: %v", time.Duration(p.Duration))
+	}
+	if p.TransitionTime < 0 {
+		return fmt.Errorf("transition_time 不能为负数: %v", time.Duration(p.TransitionTime))
+	}
+	if p.Transition != "" {
+		if _, err := transition.Get(p.Transition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachDuration 返回每个特效的播放时长
+func (p *Playlist) EachDuration() time.Duration {
+	if p.Duration > 0 {
+		return time.Duration(p.Duration)
+	}
+	return DefaultDuration
+}
+
+// TransitionName 返回过渡效果名称
+func (p *Playlist) TransitionName() string {
+	if p.Transition != "" {
+		return p.Transition
+	}
+	return DefaultTransition
+}
+
+// TransitionDuration 返回过渡时长
+func (p *Playlist) TransitionDuration() time.Duration {
+	if p.TransitionTime > 0 {
+		return time.Duration(p.TransitionTime)
+	}
+	return DefaultTransitionTime
+}
+
+// Clone 返回播放列表的副本
+func (p *Playlist) Clone() *Playlist {
+	copied := *p
+	copied.Effects = slices.Clone(p.Effects)
+	return &copied
+}
+
+// Resolve 把播放列表的条目展开为特效 ID（去重，保持首次出现的顺序）
+// 条目优先匹配特效 ID，其次匹配标签（忽略大小写）；条目为空时返回 list 中的全部特效
+func (p *Playlist) Resolve(list []effects.Metadata) ([]string, error) {
+	if len(p.Effects) == 0 {
+		ids := make([]string, len(list))
+		for i, metadata := range list {
+			ids[i] = metadata.ID
+		}
+		return ids, nil
+	}
+
+	var ids []string
+	add := func(id string) {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	for _, entry := range p.Effects {
+		entry = strings.TrimSpace(entry)
+		if i := slices.IndexFunc(list, func(m effects.Metadata) bool { return m.ID == entry }); i >= 0 {
+			add(list[i].ID)
+			continue
+		}
+
+		found := false
+		for _, metadata := range list {
+			if slices.ContainsFunc(metadata.Tags, func(tag string) bool { return strings.EqualFold(tag, entry) }) {
+				add(metadata.ID)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("播放列表中的 %q 既不是特效 ID 也不是标签", entry)
+		}
+	}
+	return ids, nil
+}
+
+// Order 播放顺序，一轮播放完后自动开始下一轮
+type Order struct {
+	ids     []string
+	shuffle bool
+	rand    *rand.Rand
+	queue   []string // 本轮尚未播放的特效
+	last    string   // 最近返回的特效
+}
+
+// NewOrder 创建播放顺序，shuffle 为 true 时每一轮使用 rng 打乱顺序
+func NewOrder(ids []string, shuffle bool, rng *rand.Rand) *Order {
+	return &Order{
+		ids:     slices.Clone(ids),
+		shuffle: shuffle,
+		rand:    rng,
+	}
+}
+
+// Next 返回下一个要播放的特效 ID
+// 随机顺序下新一轮的第一个特效不会与上一轮的最后一个相同
+func (o *Order) Next() string {
+	if len(o.ids) == 0 {
+		return ""
+	}
+
+	if len(o.queue) == 0 {
+		o.queue = slices.Clone(o.ids)
+		if o.shuffle {
+			o.rand.Shuffle(len(o.queue), func(i, j int) {
+				o.queue[i], o.queue[j] = o.queue[j], o.queue[i]
+			})
+			if last := len(o.queue) - 1; o.queue[0] == o.last {
+				o.queue[0], o.queue[last] = o.queue[last], o.queue[0]
+			}
+		}
+	}
+
+	o.last = o.queue[0]
+	o.queue = o.queue[1:]
+	return o.last
+}
+
+// Duration 时长，在 JSON 中写作 "30s"、"1m30s" 形式的字符串或秒数
+type Duration time.Duration
+
+// MarshalJSON 实现 json.Marshaler 接口
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("无法识别的时长 %q（示例: 30s、1m30s）", s)
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("时长应为 \"30s\" 形式的字符串或秒数: %s", data)
+	}
+	*d = Duration(seconds * float64(time.Second))
+	return nil
+}
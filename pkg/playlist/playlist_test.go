@@ -0,0 +1,88 @@
+package playlist
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/symbolmove/symbol_move/pkg/effects"
+)
+
+var testList = []effects.Metadata{
+	{ID: "snowfall", Tags: []string{"自然", "冬日"}},
+	{ID: "fireworks", Tags: []string{"粒子", "Party"}},
+	{ID: "ocean-wave", Tags: []string{"自然"}},
+}
+
+func TestResolve(t *testing.T) {
+	p := &Playlist{Effects: []string{"fireworks", "自然", "party", "snowfall"}}
+	ids, err := p.Resolve(testList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"fireworks", "snowfall", "ocean-wave"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+
+	if ids, _ := (&Playlist{}).Resolve(testList); len(ids) != len(testList) {
+		t.Errorf("Empty playlist should contain all effects, got %v", ids)
+	}
+	if _, err := (&Playlist{Effects: []string{"nope"}}).Resolve(testList); err == nil {
+		t.Error("Expected error for unknown entry")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	ids := []string{"a", "b", "c", "d"}
+
+	order := NewOrder(ids, false, nil)
+	for i := 0; i < 8; i++ {
+		if got := order.Next(); got != ids[i%len(ids)] {
+			t.Fatalf("Step %d: expected %s, got %s", i, ids[i%len(ids)], got)
+		}
+	}
+
+	// 随机顺序：每一轮包含全部特效，且不会连续播放同一个
+	order = NewOrder(ids, true, effects.NewRand(7))
+	prev := ""
+	for round := 0; round < 20; round++ {
+		var played []string
+		for range ids {
+			id := order.Next()
+			if id == prev {
+				t.Fatalf("Round %d repeats %s", round, id)
+			}
+			played = append(played, id)
+			prev = id
+		}
+		slices.Sort(played)
+		if !reflect.DeepEqual(played, ids) {
+			t.Fatalf("Round %d played %v", round, played)
+		}
+	}
+}
+
+func TestDurationJSON(t *testing.T) {
+	var p Playlist
+	if err := json.Unmarshal([]byte(`{"duration": "1m30s", "transition_time": 0.5}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.EachDuration() != 90*time.Second || p.TransitionDuration() != 500*time.Millisecond {
+		t.Errorf("Unexpected durations: %v, %v", p.EachDuration(), p.TransitionDuration())
+	}
+
+	data, _ := json.Marshal(&p)
+	if string(data) != `{"duration":"1m30s","transition_time":"500ms"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"duration": "soon"}`), &p); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+	if err := (&Playlist{Transition: "nope"}).Validate(); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+}
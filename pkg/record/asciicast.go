@@ -0,0 +1,208 @@
+package record
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+)
+
+// Header asciicast v2 文件头
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// 事件类型
+const (
+	EventOutput = "o" // 终端输出
+	EventResize = "r" // 终端尺寸变化，数据格式为 "列x行"
+)
+
+// Recorder asciicast v2 录制器
+// 写入文件头后，按时间顺序逐行写入事件。
+// 录制可以暂停，暂停期间经过的时间不计入事件时间戳。
+type Recorder struct {
+	mu       sync.Mutex
+	w        io.Writer
+	closer   io.Closer
+	width    int // 当前录制的终端尺寸
+	height   int
+	now      func() time.Time
+	start    time.Time
+	pausedAt time.Time // 暂停开始时间（零值表示未暂停）
+	paused   time.Duration
+	last     float64 // 上一个事件的时间戳，保证时间戳单调递增
+	err      error
+}
+
+// NewRecorder 创建录制器并写入文件头
+func NewRecorder(w io.Writer, width, height int, title string) (*Recorder, error) {
+	return newRecorder(w, width, height, title, time.Now)
+}
+
+// Create 创建录制文件
+// 返回的录制器在 Close 时会关闭文件
+func Create(path string, width, height int, title string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("创建录制文件失败: %w", err)
+	}
+
+	r, err := NewRecorder(file, width, height, title)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	r.closer = file
+
+	return r, nil
+}
+
+// newRecorder 创建使用指定时间源的录制器
+func newRecorder(w io.Writer, width, height int, title string, now func() time.Time) (*Recorder, error) {
+	start := now()
+
+	header := Header{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	}
+
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("编码录制文件头失败: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+		return nil, fmt.Errorf("写入录制文件头失败: %w", err)
+	}
+
+	return &Recorder{
+		w:      w,
+		width:  width,
+		height: height,
+		now:    now,
+		start:  start,
+	}, nil
+}
+
+// Output 录制一段终端输出
+func (r *Recorder) Output(data string) error {
+	if data == "" {
+		return nil
+	}
+	return r.event(EventOutput, data)
+}
+
+// Resize 录制终端尺寸变化
+func (r *Recorder) Resize(width, height int) error {
+	r.mu.Lock()
+	r.width, r.height = width, height
+	r.mu.Unlock()
+
+	return r.event(EventResize, fmt.Sprintf("%dx%d", width, height))
+}
+
+// fit 尺寸与当前录制尺寸不同时录制 resize 事件，返回尺寸是否变化
+func (r *Recorder) fit(width, height int) bool {
+	r.mu.Lock()
+	changed := width != r.width || height != r.height
+	r.mu.Unlock()
+
+	if changed {
+		r.Resize(width, height)
+	}
+	return changed
+}
+
+// Pause 暂停录制计时
+func (r *Recorder) Pause() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pausedAt.IsZero() {
+		r.pausedAt = r.now()
+	}
+}
+
+// Resume 恢复录制计时
+func (r *Recorder) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.pausedAt.IsZero() {
+		r.paused += r.now().Sub(r.pausedAt)
+		r.pausedAt = time.Time{}
+	}
+}
+
+// Err 返回录制过程中遇到的第一个写入错误
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close 结束录制，返回录制过程中遇到的第一个错误
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closer != nil {
+		if err := r.closer.Close(); err != nil && r.err == nil {
+			r.err = fmt.Errorf("关闭录制文件失败: %w", err)
+		}
+		r.closer = nil
+	}
+
+	return r.err
+}
+
+// event 写入一个事件
+// 出错后不再写入，错误通过 Err/Close 返回
+func (r *Recorder) event(kind, data string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return r.err
+	}
+
+	line, err := json.Marshal([]interface{}{r.elapsed(), kind, data})
+	if err != nil {
+		r.err = fmt.Errorf("编码录制事件失败: %w", err)
+		return r.err
+	}
+	if _, err := fmt.Fprintf(r.w, "%s\n", line); err != nil {
+		r.err = fmt.Errorf("写入录制事件失败: %w", err)
+		return r.err
+	}
+
+	return nil
+}
+
+// elapsed 返回扣除暂停时间后的秒数（保留微秒精度）
+func (r *Recorder) elapsed() float64 {
+	now := r.now()
+	if !r.pausedAt.IsZero() {
+		now = r.pausedAt
+	}
+
+	t := math.Round(now.Sub(r.start).Seconds()*1e6-r.paused.Seconds()*1e6) / 1e6
+	if t < r.last {
+		t = r.last
+	}
+	r.last = t
+
+	return t
+}
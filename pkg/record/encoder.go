@@ -0,0 +1,140 @@
+package record
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// ANSI 控制序列
+const (
+	ansiHideCursor = "\x1b[?25l"
+	ansiReset      = "\x1b[0m"
+	ansiClear      = "\x1b[2J"
+)
+
+// encoder 把帧差异编码为 ANSI 序列
+// 记录播放端终端的光标位置和当前样式，尽量省略冗余的控制序列
+type encoder struct {
+	x, y    int // 光标位置（x < 0 表示未知）
+	style   tcell.Style
+	started bool
+}
+
+// newEncoder 创建编码器
+func newEncoder() *encoder {
+	return &encoder{x: -1}
+}
+
+// encode 编码从 prev 到 cur 的变化，prev 为 nil 时完整重绘
+func (e *encoder) encode(prev, cur *frame.Frame) string {
+	var b strings.Builder
+
+	if !e.started {
+		b.WriteString(ansiHideCursor)
+		e.started = true
+	}
+
+	if prev == nil {
+		b.WriteString(ansiReset)
+		b.WriteString(ansiClear)
+		e.style = tcell.StyleDefault
+		e.x = -1
+	}
+
+	for y := 0; y < cur.Height; y++ {
+		// 宽字符的后一格由终端随宽字符一起绘制，采集到的空格不能输出，否则会覆盖宽字符的右半边
+		for x, width := 0, 1; x < cur.Width; x += width {
+			cell := cur.At(x, y)
+			width = max(uniseg.StringWidth(string(cell.Rune)), 1)
+			if prev != nil && prev.At(x, y) == cell {
+				continue
+			}
+			// 清屏后空白单元格无需重绘
+			if prev == nil && cell.Rune == ' ' && cell.Style == tcell.StyleDefault {
+				continue
+			}
+
+			if e.x != x || e.y != y {
+				fmt.Fprintf(&b, "\x1b[%d;%dH", y+1, x+1)
+			}
+			if cell.Style != e.style {
+				b.WriteString(sgr(cell.Style))
+				e.style = cell.Style
+			}
+			b.WriteRune(cell.Rune)
+
+			// 非 ASCII 字符的显示宽度不确定，下次输出时重新定位光标
+			if cell.Rune < 0x80 {
+				e.x, e.y = x+1, y
+			} else {
+				e.x = -1
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// sgr 生成样式对应的 SGR 序列（先重置再设置，避免遗留属性）
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+
+	codes := []string{"0"}
+	if attrs&tcell.AttrBold != 0 {
+		codes = append(codes, "1")
+	}
+	if attrs&tcell.AttrDim != 0 {
+		codes = append(codes, "2")
+	}
+	if attrs&tcell.AttrItalic != 0 {
+		codes = append(codes, "3")
+	}
+	if style.GetUnderlineStyle() != tcell.UnderlineStyleNone {
+		codes = append(codes, "4")
+	}
+	if attrs&tcell.AttrBlink != 0 {
+		codes = append(codes, "5")
+	}
+	if attrs&tcell.AttrReverse != 0 {
+		codes = append(codes, "7")
+	}
+	if attrs&tcell.AttrStrikeThrough != 0 {
+		codes = append(codes, "9")
+	}
+	if code := colorCode(fg, 30); code != "" {
+		codes = append(codes, code)
+	}
+	if code := colorCode(bg, 40); code != "" {
+		codes = append(codes, code)
+	}
+
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// colorCode 生成颜色对应的 SGR 参数，base 为 30（前景）或 40（背景）
+// 默认颜色返回空字符串
+func colorCode(color tcell.Color, base int) string {
+	if !color.Valid() || color&tcell.ColorSpecial != 0 {
+		return ""
+	}
+
+	if color.IsRGB() {
+		r, g, b := color.RGB()
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+	}
+
+	index := int(color - tcell.ColorValid)
+	switch {
+	case index < 8:
+		return strconv.Itoa(base + index)
+	case index < 16:
+		return strconv.Itoa(base + 60 + index - 8)
+	default:
+		return fmt.Sprintf("%d;5;%d", base+8, index)
+	}
+}
@@ -0,0 +1,167 @@
+package record
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/headless"
+)
+
+// fakeNow 可手动推进的时间源
+type fakeNow struct {
+	t time.Time
+}
+
+func (f *fakeNow) now() time.Time { return f.t }
+
+func (f *fakeNow) advance(d time.Duration) { f.t = f.t.Add(d) }
+
+// parseCast 解析录制输出，返回文件头和事件列表
+func parseCast(t *testing.T, data string) (Header, [][]interface{}) {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+
+	var header Header
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("文件头解析失败: %v", err)
+	}
+
+	var events [][]interface{}
+	for _, line := range lines[1:] {
+		var event []interface{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("事件解析失败: %v (%q)", err, line)
+		}
+		if len(event) != 3 {
+			t.Fatalf("事件应包含 3 个元素: %q", line)
+		}
+		events = append(events, event)
+	}
+
+	return header, events
+}
+
+func TestRecordScreen(t *testing.T) {
+	clock := &fakeNow{t: time.Unix(1700000000, 0)}
+
+	var buf bytes.Buffer
+	rec, err := newRecorder(&buf, 10, 3, "demo", clock.now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sim, err := headless.NewScreen(10, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Fini()
+
+	screen := rec.Wrap(sim)
+
+	red := tcell.StyleDefault.Foreground(tcell.ColorRed)
+	screen.SetContent(1, 0, 'A', nil, red)
+	screen.Show()
+
+	clock.advance(500 * time.Millisecond)
+	screen.SetContent(1, 0, 'B', nil, red)
+	screen.Show()
+
+	// 会话之间的时间不计入录制
+	screen.Close()
+	clock.advance(10 * time.Second)
+	screen = rec.Wrap(sim)
+
+	clock.advance(250 * time.Millisecond)
+	sim.SetSize(12, 4)
+	screen.Show()
+
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	header, events := parseCast(t, buf.String())
+	if header.Version != 2 || header.Width != 10 || header.Height != 3 || header.Title != "demo" {
+		t.Errorf("文件头不正确: %+v", header)
+	}
+
+	if len(events) != 4 {
+		t.Fatalf("应录制 4 个事件，实际 %d: %v", len(events), events)
+	}
+
+	first := events[0][2].(string)
+	if !strings.Contains(first, ansiClear) || !strings.Contains(first, "\x1b[0;91m") || !strings.HasSuffix(first, "A") {
+		t.Errorf("首帧应清屏并以红色绘制 A: %q", first)
+	}
+
+	second := events[1][2].(string)
+	if second != "\x1b[1;2HB" {
+		t.Errorf("第二帧应只包含变化的单元格: %q", second)
+	}
+	if events[1][0].(float64) != 0.5 {
+		t.Errorf("第二帧时间戳应为 0.5，实际 %v", events[1][0])
+	}
+
+	if events[2][1] != EventResize || events[2][2] != "12x4" {
+		t.Errorf("尺寸变化应录制 resize 事件: %v", events[2])
+	}
+	if events[3][0].(float64) != 0.75 {
+		t.Errorf("暂停时间不应计入录制，时间戳应为 0.75，实际 %v", events[3][0])
+	}
+}
+
+func TestEncodeWideRunes(t *testing.T) {
+	sim, err := headless.NewScreen(5, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Fini()
+
+	sim.PutStr(0, 0, "abcde")
+	prev := frame.Capture(sim)
+
+	// 宽字符之后的格子被采集为空格，不应输出
+	sim.Clear()
+	sim.PutStr(0, 0, "界a世")
+	cur := frame.Capture(sim)
+
+	enc := newEncoder()
+	got := enc.encode(prev, cur)
+	want := ansiHideCursor + "\x1b[1;1H界\x1b[1;3Ha世"
+	if got != want {
+		t.Errorf("宽字符的后一格不应输出:\n got %q\nwant %q", got, want)
+	}
+
+	// 完整重绘时同样跳过
+	got = enc.encode(nil, cur)
+	if strings.Contains(got, "界 ") || strings.Contains(got, "世 ") {
+		t.Errorf("完整重绘不应在宽字符之后输出空格: %q", got)
+	}
+}
+
+func TestColorCode(t *testing.T) {
+	tests := []struct {
+		color tcell.Color
+		want  string
+	}{
+		{tcell.ColorDefault, ""},
+		{tcell.ColorReset, ""},
+		{tcell.ColorGreen, "32"},
+		{tcell.ColorMaroon, "31"},
+		{tcell.ColorYellow, "93"},
+		{tcell.PaletteColor(9), "91"},
+		{tcell.PaletteColor(200), "38;5;200"},
+		{tcell.NewRGBColor(1, 2, 3), "38;2;1;2;3"},
+	}
+
+	for _, tt := range tests {
+		if got := colorCode(tt.color, 30); got != tt.want {
+			t.Errorf("colorCode(%v) = %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
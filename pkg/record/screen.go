@@ -0,0 +1,70 @@
+package record
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// Screen 录制屏幕
+// 包装任意 tcell.Screen，每次 Show/Sync 时把与上一帧的差异编码为 ANSI 序列并录制。
+// 特效只需把它当作普通屏幕使用，因此注册表中的任何特效都可以录制。
+type Screen struct {
+	tcell.Screen
+
+	rec  *Recorder
+	mu   sync.Mutex
+	prev *frame.Frame // 上一次录制的帧（nil 表示下次需要完整重绘）
+	enc  *encoder
+}
+
+// Wrap 包装屏幕，开始一段录制会话
+// 会话开始时恢复录制计时，并在第一次 Show 时完整重绘屏幕
+func (r *Recorder) Wrap(screen tcell.Screen) *Screen {
+	r.Resume()
+	return &Screen{
+		Screen: screen,
+		rec:    r,
+		enc:    newEncoder(),
+	}
+}
+
+// Show 刷新屏幕并录制本帧的变化
+func (s *Screen) Show() {
+	s.Screen.Show()
+	s.capture(false)
+}
+
+// Sync 完整刷新屏幕并录制完整重绘
+func (s *Screen) Sync() {
+	s.Screen.Sync()
+	s.capture(true)
+}
+
+// Close 结束录制会话并暂停录制计时，不会关闭底层屏幕
+// 在同一个录制器上再次调用 Wrap 可以继续录制，会话之间的时间不计入录制
+func (s *Screen) Close() {
+	s.rec.Pause()
+}
+
+// capture 采集当前屏幕并录制差异
+func (s *Screen) capture(full bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := frame.Capture(s.Screen)
+
+	prev := s.prev
+	if full {
+		prev = nil
+	}
+
+	// 尺寸变化时录制 resize 事件并完整重绘
+	if s.rec.fit(cur.Width, cur.Height) {
+		prev = nil
+	}
+
+	s.rec.Output(s.enc.encode(prev, cur))
+	s.prev = cur
+}
@@ -0,0 +1,89 @@
+package theme
+
+import "github.com/gdamore/tcell/v2"
+
+// 内置主题
+var (
+	// Dark 深色（默认）：终端背景上的绿色界面，特效使用各自的配色
+	Dark = &Theme{
+		Name: "dark",
+		UI: UI{
+			Background:  tcell.ColorDefault,
+			Title:       tcell.ColorLightGreen,
+			Accent:      tcell.ColorGreen,
+			Text:        tcell.ColorWhite,
+			Dim:         tcell.ColorGray,
+			Highlight:   tcell.ColorBlack,
+			HighlightBg: tcell.ColorGreen,
+			Error:       tcell.ColorRed,
+		},
+	}
+
+	// Light 浅色：浅灰背景上的深色文字
+	Light = &Theme{
+		Name: "light",
+		UI: UI{
+			Background:  tcell.NewRGBColor(0xf5, 0xf5, 0xf5),
+			Title:       tcell.NewRGBColor(0x00, 0x5f, 0x00),
+			Accent:      tcell.NewRGBColor(0x00, 0x87, 0x00),
+			Text:        tcell.NewRGBColor(0x1c, 0x1c, 0x1c),
+			Dim:         tcell.NewRGBColor(0x80, 0x80, 0x80),
+			Highlight:   tcell.NewRGBColor(0xff, 0xff, 0xff),
+			HighlightBg: tcell.NewRGBColor(0x00, 0x87, 0x00),
+			Error:       tcell.NewRGBColor(0xd7, 0x00, 0x00),
+		},
+	}
+
+	// Solarized Solarized 深色配色，特效使用由该配色组成的渐变
+	Solarized = &Theme{
+		Name: "solarized",
+		UI: UI{
+			Background:  tcell.NewRGBColor(0x00, 0x2b, 0x36),
+			Title:       tcell.NewRGBColor(0xb5, 0x89, 0x00),
+			Accent:      tcell.NewRGBColor(0x2a, 0xa1, 0x98),
+			Text:        tcell.NewRGBColor(0x93, 0xa1, 0xa1),
+			Dim:         tcell.NewRGBColor(0x58, 0x6e, 0x75),
+			Highlight:   tcell.NewRGBColor(0x00, 0x2b, 0x36),
+			HighlightBg: tcell.NewRGBColor(0x26, 0x8b, 0xd2),
+			Error:       tcell.NewRGBColor(0xdc, 0x32, 0x2f),
+		},
+		Palette: "#073642,#268bd2,#2aa198,#859900,#b58900,#fdf6e3",
+	}
+
+	// HighContrast 高对比度：黑底上的纯白与亮黄
+	HighContrast = &Theme{
+		Name: "high-contrast",
+		UI: UI{
+			Background:  tcell.ColorBlack,
+			Title:       tcell.ColorYellow,
+			Accent:      tcell.ColorAqua,
+			Text:        tcell.ColorWhite,
+			Dim:         tcell.ColorSilver,
+			Highlight:   tcell.ColorBlack,
+			HighlightBg: tcell.ColorYellow,
+			Error:       tcell.ColorRed,
+		},
+	}
+
+	// Monochrome 单色：界面只用黑白灰，特效使用灰度渐变
+	Monochrome = &Theme{
+		Name: "monochrome",
+		UI: UI{
+			Background:  tcell.ColorDefault,
+			Title:       tcell.ColorWhite,
+			Accent:      tcell.ColorWhite,
+			Text:        tcell.ColorSilver,
+			Dim:         tcell.ColorGray,
+			Highlight:   tcell.ColorBlack,
+			HighlightBg: tcell.ColorWhite,
+			Error:       tcell.ColorWhite,
+		},
+		Palette: "grayscale",
+	}
+)
+
+func init() {
+	for _, t := range []*Theme{Dark, Light, Solarized, HighContrast, Monochrome} {
+		Register(t)
+	}
+}
@@ -0,0 +1,154 @@
+package theme
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+)
+
+// file 主题文件的内容（JSON 与 TOML 使用相同的字段）
+type file struct {
+	Name     string            `json:"name"`     // 主题名称（省略时使用文件名）
+	Base     string            `json:"base"`     // 未设置的界面颜色沿用的主题（默认 dark）
+	UI       map[string]string `json:"ui"`       // 界面颜色，键见 uiFields
+	Palette  string            `json:"palette"`  // 特效默认使用的调色板
+	Palettes map[string]string `json:"palettes"` // 特效 ID -> 调色板
+}
+
+// uiFields 主题文件中界面颜色的键
+var uiFields = map[string]func(ui *UI) *tcell.Color{
+	"background":   func(ui *UI) *tcell.Color { return &ui.Background },
+	"title":        func(ui *UI) *tcell.Color { return &ui.Title },
+	"accent":       func(ui *UI) *tcell.Color { return &ui.Accent },
+	"text":         func(ui *UI) *tcell.Color { return &ui.Text },
+	"dim":          func(ui *UI) *tcell.Color { return &ui.Dim },
+	"highlight":    func(ui *UI) *tcell.Color { return &ui.Highlight },
+	"highlight_bg": func(ui *UI) *tcell.Color { return &ui.HighlightBg },
+	"error":        func(ui *UI) *tcell.Color { return &ui.Error },
+}
+
+// LoadFile 读取 .json 或 .toml 主题文件
+func LoadFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	t, err := Parse(data, ext)
+	if err != nil {
+		return nil, fmt.Errorf("主题文件 %s: %w", path, err)
+	}
+	if t.Name == "" {
+		t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return t, nil
+}
+
+// LoadDir 读取并注册目录中的所有主题文件，目录不存在时什么也不做
+// 出错的文件被跳过，其余主题照常注册，返回的错误汇总所有出错的文件
+func LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取主题目录失败: %w", err)
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+
+		t, err := LoadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		Register(t)
+	}
+	return errors.Join(errs...)
+}
+
+// Parse 解析主题文件的内容，ext 为 ".json" 或 ".toml"
+func Parse(data []byte, ext string) (*Theme, error) {
+	if ext == ".toml" {
+		values, err := decodeTOML(data)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(values); err != nil {
+			return nil, err
+		}
+	}
+
+	var f file
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&f); err != nil {
+		return nil, fmt.Errorf("格式错误: %w", err)
+	}
+	return f.theme()
+}
+
+// theme 由文件内容创建主题，未设置的界面颜色沿用 base 主题
+func (f *file) theme() (*Theme, error) {
+	base, err := Get(cmp.Or(f.Base, Dark.Name))
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+
+	t := &Theme{Name: strings.TrimSpace(f.Name), UI: base.UI, Palette: f.Palette, Palettes: f.Palettes}
+	if f.Palette == "" && f.Palettes == nil {
+		t.Palette, t.Palettes = base.Palette, base.Palettes
+	}
+
+	keys := make([]string, 0, len(f.UI))
+	for key := range f.UI {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field, ok := uiFields[key]
+		if !ok {
+			return nil, fmt.Errorf("ui.%s: 未知的界面颜色", key)
+		}
+		color, err := parseColor(f.UI[key])
+		if err != nil {
+			return nil, fmt.Errorf("ui.%s: %w", key, err)
+		}
+		*field(&t.UI) = color
+	}
+
+	if t.Palette != "" {
+		if _, err := palette.Parse(t.Palette); err != nil {
+			return nil, fmt.Errorf("palette: %w", err)
+		}
+	}
+	for id, spec := range t.Palettes {
+		if _, err := palette.Parse(spec); err != nil {
+			return nil, fmt.Errorf("palettes.%s: %w", id, err)
+		}
+	}
+	return t, nil
+}
+
+// parseColor 解析界面颜色，"default" 表示终端的默认颜色
+func parseColor(s string) (tcell.Color, error) {
+	if strings.EqualFold(strings.TrimSpace(s), "default") {
+		return tcell.ColorDefault, nil
+	}
+	return palette.ParseColor(s)
+}
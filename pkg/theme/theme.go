@@ -0,0 +1,84 @@
+package theme
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// UI 选择器等界面的配色
+type UI struct {
+	Background  tcell.Color // 界面背景（ColorDefault 表示终端背景）
+	Title       tcell.Color // 标题
+	Accent      tcell.Color // 副标题、选中项、边框与滑块等强调色
+	Text        tcell.Color // 正文
+	Dim         tcell.Color // 提示、分隔线等次要内容
+	Highlight   tcell.Color // 反色高亮（标签栏当前项）的文字
+	HighlightBg tcell.Color // 反色高亮的背景
+	Error       tcell.Color // 错误信息
+}
+
+// Theme 主题：界面配色与特效默认使用的调色板
+type Theme struct {
+	Name     string
+	UI       UI
+	Palette  string            // 所有特效默认使用的调色板（空表示各特效自己的配色），取值同 palette.Parse
+	Palettes map[string]string // 特效 ID -> 调色板，优先于 Palette
+}
+
+// Style 返回以 fg 为前景、主题背景为背景的样式
+func (t *Theme) Style(fg tcell.Color) tcell.Style {
+	return tcell.StyleDefault.Foreground(fg).Background(t.UI.Background)
+}
+
+// HighlightStyle 返回反色高亮的样式
+func (t *Theme) HighlightStyle() tcell.Style {
+	return tcell.StyleDefault.Foreground(t.UI.Highlight).Background(t.UI.HighlightBg)
+}
+
+// EffectPalette 返回特效在该主题下默认使用的调色板（没有设置时返回空字符串）
+func (t *Theme) EffectPalette(effectID string) string {
+	if spec := t.Palettes[effectID]; spec != "" {
+		return spec
+	}
+	return t.Palette
+}
+
+var (
+	mu     sync.RWMutex
+	themes = make(map[string]*Theme)
+	order  []string // 注册顺序
+)
+
+// Register 注册主题，同名的主题被替换（用户主题可以覆盖内置主题）
+func Register(t *Theme) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	name := strings.ToLower(t.Name)
+	if _, ok := themes[name]; !ok {
+		order = append(order, name)
+	}
+	themes[name] = t
+}
+
+// Get 按名称获取主题（忽略大小写）
+func Get(name string) (*Theme, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	t, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("未知的主题 %q（可选 %s）", name, strings.Join(order, ", "))
+	}
+	return t, nil
+}
+
+// Names 返回所有主题的名称（按注册顺序）
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), order...)
+}
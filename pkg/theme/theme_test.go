@@ -0,0 +1,107 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseTOML(t *testing.T) {
+	data := `
+# 海蓝主题
+name = "deep-sea"
+palette = "ocean"
+
+[ui]
+background = "#001020"  # 深蓝背景
+title = 'white'
+"highlight_bg" = "#0af"
+
+[palettes]
+fire-effect = "fire"
+`
+	th, err := Parse([]byte(data), ".toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.Name != "deep-sea" || th.Palette != "ocean" || th.EffectPalette("fire-effect") != "fire" || th.EffectPalette("snowfall") != "ocean" {
+		t.Errorf("Unexpected theme %+v", th)
+	}
+	if th.UI.Background != tcell.NewRGBColor(0x00, 0x10, 0x20) || th.UI.Title != tcell.ColorWhite || th.UI.HighlightBg != tcell.NewRGBColor(0x00, 0xaa, 0xff) {
+		t.Errorf("Unexpected UI colours %+v", th.UI)
+	}
+	// 未设置的颜色沿用 dark 主题
+	if th.UI.Text != Dark.UI.Text || th.UI.Error != Dark.UI.Error {
+		t.Errorf("Expected unset colours to come from dark, got %+v", th.UI)
+	}
+}
+
+func TestParseJSONWithBase(t *testing.T) {
+	th, err := Parse([]byte(`{"base": "monochrome", "ui": {"title": "default"}}`), ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.UI.Title != tcell.ColorDefault || th.UI.Text != Monochrome.UI.Text {
+		t.Errorf("Unexpected UI colours %+v", th.UI)
+	}
+	if th.Palette != "grayscale" {
+		t.Errorf("Expected the base palette, got %q", th.Palette)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		content, ext, want string
+	}{
+		{`{"ui": {"titel": "red"}}`, ".json", "ui.titel"},
+		{`{"ui": {"title": "#12345"}}`, ".json", "无法识别的颜色"},
+		{`{"colour": "red"}`, ".json", "格式错误"},
+		{`{"base": "neon"}`, ".json", "未知的主题"},
+		{`{"palettes": {"fire-effect": "lava"}}`, ".json", "palettes.fire-effect"},
+		{"[ui]\ntitle = 3", ".toml", "第 2 行"},
+		{"[ui\n", ".toml", "第 1 行"},
+		{"name = \"a\"\nname = \"b\"", ".toml", "重复的键"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.content), tt.ext)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q): expected error containing %q, got %v", tt.content, tt.want, err)
+		}
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	if err := LoadDir(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("Expected a missing directory to be ignored, got %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "sunset.toml"), "[ui]\ntitle = \"#ff8800\"\n")
+	writeFile(t, filepath.Join(dir, "broken.json"), `{"ui": `)
+	writeFile(t, filepath.Join(dir, "notes.txt"), "not a theme")
+
+	err := LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "broken.json") {
+		t.Errorf("Expected an error about broken.json, got %v", err)
+	}
+
+	// 出错的文件不影响其他主题，名称取自文件名
+	th, err := Get("Sunset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if th.UI.Title != tcell.NewRGBColor(0xff, 0x88, 0x00) {
+		t.Errorf("Unexpected title colour %v", th.UI.Title)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
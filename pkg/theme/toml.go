@@ -0,0 +1,114 @@
+package theme
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeTOML 解析主题文件用到的 TOML 子集：
+// 顶层与 [table] 中的 key = "string" 键值对（基本字符串或 '字面量字符串'）以及 # 注释，
+// 不支持数字、数组、内联表与多级表
+func decodeTOML(data []byte) (map[string]any, error) {
+	root := make(map[string]any)
+	table := root
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(stripComment(scanner.Text()))
+		if text == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			if !strings.HasSuffix(text, "]") || strings.HasPrefix(text, "[[") {
+				return nil, fmt.Errorf("第 %d 行: 无法识别的表头 %s", line, text)
+			}
+			name, err := parseKey(text[1 : len(text)-1])
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行: %w", line, err)
+			}
+			if _, ok := root[name]; ok {
+				return nil, fmt.Errorf("第 %d 行: 重复的表 [%s]", line, name)
+			}
+			table = make(map[string]any)
+			root[name] = table
+			continue
+		}
+
+		rawKey, rawValue, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("第 %d 行: 应为 key = \"value\"", line)
+		}
+		key, err := parseKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", line, err)
+		}
+		value, err := parseString(strings.TrimSpace(rawValue))
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %s: %w", line, key, err)
+		}
+		if _, ok := table[key]; ok {
+			return nil, fmt.Errorf("第 %d 行: 重复的键 %s", line, key)
+		}
+		table[key] = value
+	}
+	return root, scanner.Err()
+}
+
+// stripComment 去掉字符串之外的 # 注释
+func stripComment(line string) string {
+	var quote rune
+	escaped := false
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseKey 解析裸键（字母、数字、- 与 _）或带引号的键
+func parseKey(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "\"") || strings.HasPrefix(s, "'") {
+		return parseString(s)
+	}
+	if s == "" {
+		return "", fmt.Errorf("键为空")
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return "", fmt.Errorf("不支持的键 %s", s)
+		}
+	}
+	return s, nil
+}
+
+// parseString 解析基本字符串 "..."（支持转义）或字面量字符串 '...'
+func parseString(s string) (string, error) {
+	switch {
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return s[1 : len(s)-1], nil
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		value, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("无效的字符串 %s", s)
+		}
+		return value, nil
+	}
+	return "", fmt.Errorf("只支持字符串值: %s", s)
+}
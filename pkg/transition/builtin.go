@@ -0,0 +1,122 @@
+package transition
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+func init() {
+	Register(Clear, Func(cut))
+	Register(Crossfade, Func(crossfade))
+	Register(Dissolve, Func(dissolve))
+	Register(Wipe, Func(wipe))
+	Register(MatrixDrip, Func(matrixDrip))
+	Register(FadeToBlack, Func(fadeToBlack))
+}
+
+// dripRunes 字符雨使用的字符
+var dripRunes = []rune("ｱｲｳｴｵｶｷｸｹｺｻｼｽｾｿﾀﾁﾂﾃﾄ0123456789")
+
+// black 纯黑的空白单元格
+var black = frame.Cell{Rune: ' ', Style: tcell.StyleDefault.Background(tcell.ColorBlack)}
+
+// cut 直接显示新画面
+func cut(from, to, out *frame.Frame, progress float64) {
+	copy(out.Cells, to.Cells)
+}
+
+// crossfade 交叉淡入淡出：颜色逐渐过渡，字符在进度过半时切换
+// 只有一方有字符的单元格，字符从背景色中浮现或隐没
+func crossfade(from, to, out *frame.Frame, progress float64) {
+	for y := 0; y < out.Height; y++ {
+		for x := 0; x < out.Width; x++ {
+			out.Set(x, y, MixCells(from.At(x, y), to.At(x, y), progress))
+		}
+	}
+}
+
+// dissolve 逐格随机溶解：每个单元格在各自的随机时刻切换为新画面
+func dissolve(from, to, out *frame.Frame, progress float64) {
+	for y := 0; y < out.Height; y++ {
+		for x := 0; x < out.Width; x++ {
+			if noise(x, y) < progress {
+				out.Set(x, y, to.At(x, y))
+			} else {
+				out.Set(x, y, from.At(x, y))
+			}
+		}
+	}
+}
+
+// wipe 从左到右擦除，边缘有一段渐变过渡带
+func wipe(from, to, out *frame.Frame, progress float64) {
+	band := float64(max(out.Width/8, 1))
+	edge := progress*(float64(out.Width)+band) - band
+
+	for y := 0; y < out.Height; y++ {
+		for x := 0; x < out.Width; x++ {
+			t := 1 - (float64(x)-edge)/band
+			out.Set(x, y, MixCells(from.At(x, y), to.At(x, y), math.Max(0, math.Min(1, t))))
+		}
+	}
+}
+
+// matrixDrip 字符雨逐列滴落：每列在随机时刻落下一道绿色字符雨，雨滴经过之处露出新画面
+func matrixDrip(from, to, out *frame.Frame, progress float64) {
+	trail := max(out.Height/3, 4)
+
+	for x := 0; x < out.Width; x++ {
+		// 各列的起始时刻错开，进度为 1 时所有列都已落完
+		start := noise(x, -1) * 0.4
+		p := math.Max(0, math.Min(1, (progress-start)/0.6))
+		head := int(p * float64(out.Height+trail))
+
+		for y := 0; y < out.Height; y++ {
+			switch d := head - y; {
+			case d > trail:
+				out.Set(x, y, to.At(x, y))
+			case d > 0:
+				out.Set(x, y, dripCell(x, y, head, d, trail))
+			default:
+				out.Set(x, y, from.At(x, y))
+			}
+		}
+	}
+}
+
+// dripCell 返回字符雨中距离雨滴头部 d 格（1 为头部）的单元格，越靠近尾部越暗
+func dripCell(x, y, head, d, trail int) frame.Cell {
+	r := dripRunes[int(noise(x, y+head)*float64(len(dripRunes)))]
+
+	style := tcell.StyleDefault.Background(tcell.ColorBlack)
+	if d == 1 {
+		style = style.Foreground(tcell.ColorWhite).Bold(true)
+	} else {
+		brightness := 1 - float64(d-1)/float64(trail)
+		style = style.Foreground(MixColor(tcell.ColorBlack, tcell.ColorLime, tcell.ColorLime, 0.3+0.7*brightness))
+	}
+	return frame.Cell{Rune: r, Style: style}
+}
+
+// fadeToBlack 前半段旧画面淡出到黑色，后半段新画面从黑色淡入
+func fadeToBlack(from, to, out *frame.Frame, progress float64) {
+	for y := 0; y < out.Height; y++ {
+		for x := 0; x < out.Width; x++ {
+			if progress < 0.5 {
+				out.Set(x, y, MixCells(from.At(x, y), black, progress*2))
+			} else {
+				out.Set(x, y, MixCells(black, to.At(x, y), progress*2-1))
+			}
+		}
+	}
+}
+
+// noise 返回由坐标决定的伪随机数（0 到 1），同一位置每帧相同，画面不会闪烁
+func noise(x, y int) float64 {
+	h := uint32(x)*374761393 + uint32(y)*668265263
+	h = (h ^ (h >> 13)) * 1274126177
+	h ^= h >> 16
+	return float64(h) / (1 << 32)
+}
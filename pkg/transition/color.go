@@ -0,0 +1,75 @@
+package transition
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// 终端默认颜色没有 RGB 值，混合时按常见的深色终端处理
+var (
+	defaultForeground = tcell.ColorSilver
+	defaultBackground = tcell.ColorBlack
+)
+
+// MixColor 在颜色 a 与 b 之间按比例 t（0 到 1）线性插值
+// fallback 代替无法取得 RGB 值的终端默认颜色
+func MixColor(a, b, fallback tcell.Color, t float64) tcell.Color {
+	switch {
+	case t <= 0 || a == b:
+		return a
+	case t >= 1:
+		return b
+	}
+
+	ar, ag, ab := resolve(a, fallback).RGB()
+	br, bg, bb := resolve(b, fallback).RGB()
+	return tcell.NewRGBColor(lerp(ar, br, t), lerp(ag, bg, t), lerp(ab, bb, t))
+}
+
+// MixCells 按进度 t 混合两个单元格：颜色线性插值，字符在进度过半时切换
+// 只有一方有字符时始终显示该字符，颜色从背景色过渡，看起来像从背景中浮现或隐没
+func MixCells(a, b frame.Cell, t float64) frame.Cell {
+	switch {
+	case t <= 0:
+		return a
+	case t >= 1:
+		return b
+	}
+
+	afg, abg, aattr := a.Style.Decompose()
+	bfg, bbg, battr := b.Style.Decompose()
+
+	// 空白单元格的前景色不可见，按背景色处理
+	if a.Rune == ' ' {
+		afg = resolve(abg, defaultBackground)
+	}
+	if b.Rune == ' ' {
+		bfg = resolve(bbg, defaultBackground)
+	}
+
+	r, attr := b.Rune, battr
+	if b.Rune == ' ' || (t < 0.5 && a.Rune != ' ') {
+		r, attr = a.Rune, aattr
+	}
+
+	style := tcell.StyleDefault.
+		Foreground(MixColor(afg, bfg, defaultForeground, t)).
+		Background(MixColor(abg, bbg, defaultBackground, t)).
+		Attributes(attr)
+	return frame.Cell{Rune: r, Style: style}
+}
+
+// resolve 把终端默认颜色替换为 fallback
+func resolve(c, fallback tcell.Color) tcell.Color {
+	if c == tcell.ColorDefault || !c.Valid() {
+		return fallback
+	}
+	return c
+}
+
+// lerp 在 a 与 b 之间线性插值
+func lerp(a, b int32, t float64) int32 {
+	return a + int32(math.Round(float64(b-a)*t))
+}
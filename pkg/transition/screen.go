@@ -0,0 +1,120 @@
+package transition
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// Screen 过渡屏幕
+// 包装任意 tcell.Screen。过渡期间每次 Show 时把新特效绘制的画面与旧画面合成后显示，
+// 显示之后恢复新特效自己的画面，因此特效无需感知过渡的存在。
+type Screen struct {
+	tcell.Screen
+
+	mu         sync.Mutex
+	transition Transition
+	from       *frame.Frame   // 旧特效的最后一帧（nil 表示没有进行中的过渡）
+	clock      *effects.Clock // 驱动新特效的时钟，过渡进度按它推进的模拟时间计算
+	start      float64        // 过渡开始时时钟已推进的时间（秒）
+	duration   float64        // 过渡时长（秒）
+}
+
+// NewScreen 包装屏幕
+func NewScreen(screen tcell.Screen) *Screen {
+	return &Screen{Screen: screen}
+}
+
+// Start 开始从 from 到新特效画面的过渡
+// 过渡跟随 clock 的暂停与速度倍率；duration 不大于 0 时不进行过渡
+func (s *Screen) Start(t Transition, from *frame.Frame, duration time.Duration, clock *effects.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t == nil || from == nil || clock == nil || duration <= 0 {
+		s.from = nil
+		return
+	}
+
+	s.transition = t
+	s.from = from
+	s.clock = clock
+	s.start = clock.Elapsed()
+	s.duration = duration.Seconds()
+}
+
+// animateFPS Animate 刷新画面的帧率
+const animateFPS = 30
+
+// Animate 为自身不会持续刷新的界面（如选择器）播放过渡
+// 以固定帧率反复调用 render 绘制新画面，过渡结束后返回
+func (s *Screen) Animate(t Transition, from *frame.Frame, duration time.Duration, render func()) {
+	clock := effects.NewClock()
+	s.Start(t, from, duration, clock)
+
+	quit := make(chan struct{})
+	finished := false
+	clock.Run(animateFPS, quit, func(float64) {
+		render()
+		if !finished && !s.Active() {
+			finished = true
+			close(quit)
+		}
+	})
+}
+
+// Active 判断过渡是否仍在进行
+func (s *Screen) Active() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.from != nil
+}
+
+// Show 刷新屏幕，过渡期间显示合成后的画面
+func (s *Screen) Show() {
+	s.present(s.Screen.Show)
+}
+
+// Sync 完整刷新屏幕，过渡期间显示合成后的画面
+func (s *Screen) Sync() {
+	s.present(s.Screen.Sync)
+}
+
+// present 按过渡进度合成画面后调用 show，然后恢复新特效的画面
+func (s *Screen) present(show func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.from == nil {
+		show()
+		return
+	}
+
+	progress := (s.clock.Elapsed() - s.start) / s.duration
+	if progress >= 1 {
+		s.from = nil
+		show()
+		return
+	}
+
+	to := frame.Capture(s.Screen)
+	out := frame.New(to.Width, to.Height)
+	s.transition.Blend(s.from, to, out, max(progress, 0))
+
+	s.draw(out)
+	show()
+	s.draw(to)
+}
+
+// draw 把帧写入底层屏幕的缓冲区
+func (s *Screen) draw(f *frame.Frame) {
+	for y := 0; y < f.Height; y++ {
+		for x := 0; x < f.Width; x++ {
+			cell := f.Cells[y*f.Width+x]
+			s.Screen.SetContent(x, y, cell.Rune, nil, cell.Style)
+		}
+	}
+}
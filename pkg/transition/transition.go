@@ -0,0 +1,77 @@
+package transition
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/symbolmove/symbol_move/pkg/frame"
+)
+
+// Transition 两个特效之间的过渡效果
+// 把旧特效的最后一帧与新特效的当前帧按进度合成为一帧
+type Transition interface {
+	// Blend 把 from 与 to 按进度 progress（0 到 1）合成到 out
+	// out 与 to 的尺寸相同；from 的尺寸可能不同（终端大小发生了变化）
+	Blend(from, to, out *frame.Frame, progress float64)
+}
+
+// Func 把普通函数适配为 Transition
+type Func func(from, to, out *frame.Frame, progress float64)
+
+// Blend 实现 Transition 接口
+func (f Func) Blend(from, to, out *frame.Frame, progress float64) {
+	f(from, to, out, progress)
+}
+
+// 内置过渡效果
+const (
+	Clear       = "clear"         // 直接切换
+	Crossfade   = "crossfade"     // 交叉淡入淡出
+	Dissolve    = "dissolve"      // 逐格随机溶解
+	Wipe        = "wipe"          // 从左到右擦除
+	MatrixDrip  = "matrix-drip"   // 字符雨逐列滴落
+	FadeToBlack = "fade-to-black" // 先淡出到黑色再淡入
+)
+
+var (
+	mu          sync.RWMutex
+	transitions = make(map[string]Transition)
+)
+
+// Register 注册过渡效果，同名的过渡效果会被替换
+func Register(name string, t Transition) {
+	mu.Lock()
+	defer mu.Unlock()
+	transitions[name] = t
+}
+
+// Get 按名称获取过渡效果（忽略大小写）
+func Get(name string) (Transition, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	t, ok := transitions[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("未知的过渡效果 %q（可选 %s）", name, strings.Join(namesLocked(), ", "))
+	}
+	return t, nil
+}
+
+// Names 返回所有过渡效果的名称（按字母顺序）
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return namesLocked()
+}
+
+// namesLocked 返回排序后的名称，调用方须持有读锁
+func namesLocked() []string {
+	names := make([]string, 0, len(transitions))
+	for name := range transitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,145 @@
+package transition
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/headless"
+)
+
+func TestGet(t *testing.T) {
+	for _, name := range []string{Clear, Crossfade, " CrossFade "} {
+		if _, err := Get(name); err != nil {
+			t.Errorf("Get(%q): %v", name, err)
+		}
+	}
+	if _, err := Get("nope"); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+}
+
+func TestBuiltinEndpoints(t *testing.T) {
+	from, to := frame.New(12, 6), frame.New(12, 6)
+	for i := range from.Cells {
+		from.Cells[i].Rune = 'o'
+		to.Cells[i].Rune = 'n'
+	}
+
+	for _, name := range []string{Clear, Crossfade, Dissolve, Wipe, MatrixDrip, FadeToBlack} {
+		tr, err := Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out := frame.New(to.Width, to.Height)
+		tr.Blend(from, to, out, 1)
+		if !out.Equal(to) {
+			t.Errorf("%s: progress 1 should show the new frame:\n%s", name, out.Text())
+		}
+		if name == Clear {
+			continue
+		}
+
+		out = frame.New(to.Width, to.Height)
+		tr.Blend(from, to, out, 0)
+		if !out.Equal(from) {
+			t.Errorf("%s: progress 0 should show the old frame:\n%s", name, out.Text())
+		}
+
+		// 按位置切换的过渡中途两个画面都可见
+		if name == Crossfade || name == FadeToBlack {
+			continue
+		}
+		out = frame.New(to.Width, to.Height)
+		tr.Blend(from, to, out, 0.5)
+		if !strings.ContainsRune(out.Text(), 'o') || !strings.ContainsRune(out.Text(), 'n') {
+			t.Errorf("%s: expected both frames halfway:\n%s", name, out.Text())
+		}
+	}
+}
+
+func TestMixCells(t *testing.T) {
+	a := frame.Cell{Rune: 'A', Style: tcell.StyleDefault.Foreground(tcell.ColorRed)}
+	b := frame.Cell{Rune: 'B', Style: tcell.StyleDefault.Foreground(tcell.ColorBlue)}
+
+	if got := MixCells(a, b, 0); got != a {
+		t.Errorf("progress 0 should keep a, got %+v", got)
+	}
+	if got := MixCells(a, b, 1); got != b {
+		t.Errorf("progress 1 should be b, got %+v", got)
+	}
+	if got := MixCells(a, b, 0.25); got.Rune != 'A' {
+		t.Errorf("Expected A before halfway, got %q", got.Rune)
+	}
+	if got := MixCells(a, b, 0.75); got.Rune != 'B' {
+		t.Errorf("Expected B after halfway, got %q", got.Rune)
+	}
+
+	// 只有一方有字符时显示该字符
+	blank := frame.Cell{Rune: ' ', Style: tcell.StyleDefault}
+	if got := MixCells(blank, b, 0.1); got.Rune != 'B' {
+		t.Errorf("Expected B to fade in, got %q", got.Rune)
+	}
+	if got := MixCells(a, blank, 0.9); got.Rune != 'A' {
+		t.Errorf("Expected A to fade out, got %q", got.Rune)
+	}
+
+	fg, _, _ := MixCells(a, b, 0.5).Style.Decompose()
+	r, g, bl := fg.RGB()
+	if r <= 0 || bl <= 0 || g != 0 {
+		t.Errorf("Expected a red/blue mix, got %d,%d,%d", r, g, bl)
+	}
+}
+
+func TestScreenRestoresEffectFrame(t *testing.T) {
+	sim, err := headless.NewScreen(3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Fini()
+
+	fill := func(r rune) {
+		for x := 0; x < 3; x++ {
+			sim.SetContent(x, 0, r, nil, tcell.StyleDefault)
+		}
+	}
+
+	fill('x')
+	from := frame.Capture(sim)
+
+	clock := effects.NewClockWithMode(effects.ClockSimulated)
+	screen := NewScreen(sim)
+	screen.Start(Func(func(from, to, out *frame.Frame, progress float64) {
+		copy(out.Cells, from.Cells)
+	}), from, time.Second, clock)
+
+	// 过渡期间显示合成的画面，但特效自己的画面保持不变
+	fill('y')
+	screen.Show()
+	if cells, _, _ := sim.GetContents(); cells[0].Runes[0] != 'x' {
+		t.Errorf("Expected blended frame on screen, got %q", cells[0].Runes[0])
+	}
+	if r, _, _, _ := sim.GetContent(0, 0); r != 'y' {
+		t.Errorf("Effect frame should be restored, got %q", r)
+	}
+
+	// 时钟推进超过过渡时长后直接显示特效画面
+	quit := make(chan struct{})
+	frames := 0
+	clock.Run(10, quit, func(float64) {
+		if frames++; frames == 11 {
+			close(quit)
+		}
+	})
+	screen.Show()
+	if cells, _, _ := sim.GetContents(); cells[0].Runes[0] != 'y' {
+		t.Errorf("Expected effect frame after transition, got %q", cells[0].Runes[0])
+	}
+	if screen.Active() {
+		t.Error("Transition should have finished")
+	}
+}
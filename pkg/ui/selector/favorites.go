@@ -0,0 +1,65 @@
+package selector
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+)
+
+// sortOrderKeys 各排序方式在界面上显示的名称
+var sortOrderKeys = map[effects.SortOrder]string{
+	effects.SortRegistration: i18n.KeySortRegistration,
+	effects.SortName:         i18n.KeySortName,
+	effects.SortAuthor:       i18n.KeySortAuthor,
+	effects.SortPlays:        i18n.KeySortPlays,
+}
+
+// loadHistory 从配置读取收藏、最近运行的特效、运行次数与排序方式
+func (s *Selector) loadHistory() {
+	if s.store == nil {
+		return
+	}
+
+	cfg := s.store.Config()
+	s.favorites = cfg.Favorites
+	s.recent = cfg.Recent
+	s.plays = cfg.PlayCounts
+	s.order = cmp.Or(cfg.SortOrder, effects.SortRegistration)
+}
+
+// isFavorite 判断特效是否已收藏
+func (s *Selector) isFavorite(effectID string) bool {
+	return slices.Contains(s.favorites, effectID)
+}
+
+// toggleFavorite 收藏或取消收藏选中的特效并保存到配置
+func (s *Selector) toggleFavorite() {
+	metadata, ok := s.GetSelected()
+	if !ok {
+		return
+	}
+
+	if i := slices.Index(s.favorites, metadata.ID); i >= 0 {
+		s.favorites = slices.Delete(slices.Clone(s.favorites), i, i+1)
+	} else {
+		s.favorites = append(slices.Clone(s.favorites), metadata.ID)
+	}
+	if s.store != nil {
+		s.store.Update(func(cfg *config.Config) { cfg.ToggleFavorite(metadata.ID) }) // 保存失败时仅本次生效
+	}
+	s.applyFilter()
+}
+
+// cycleSortOrder 切换到下一种排序方式并保存到配置
+func (s *Selector) cycleSortOrder() {
+	orders := effects.SortOrders()
+	s.order = orders[(slices.Index(orders, s.order)+1)%len(orders)]
+	if s.store != nil {
+		order := s.order
+		s.store.Update(func(cfg *config.Config) { cfg.SortOrder = order }) // 保存失败时仅本次生效
+	}
+	s.Refresh()
+}
@@ -0,0 +1,72 @@
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+)
+
+func TestFavoritesAndRecent(t *testing.T) {
+	sel := newTestSelector(t)
+	store := newTestStore(t)
+	store.Update(func(cfg *config.Config) { cfg.Recent = []string{"c", "missing", "a"} })
+	sel.SetConfig(store)
+	setTestEffects(sel,
+		effects.Metadata{ID: "a"},
+		effects.Metadata{ID: "b"},
+		effects.Metadata{ID: "c"},
+	)
+
+	// 收藏的特效排在列表前面并保存到配置
+	press(sel, tcell.KeyDown, tcell.KeyDown, "f")
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"c", "a", "b"}) {
+		t.Errorf("Expected favourites first, got %v", got)
+	}
+	if metadata, _ := sel.GetSelected(); metadata.ID != "c" {
+		t.Errorf("Expected selection to follow the starred effect, got %q", metadata.ID)
+	}
+	if !store.Config().IsFavorite("c") {
+		t.Error("Expected favourite to be saved")
+	}
+
+	press(sel, tcell.KeyTab)
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"c"}) {
+		t.Errorf("Expected only favourites, got %v", got)
+	}
+
+	// 最近运行的特效按运行时间排列，已不存在的特效被忽略
+	press(sel, tcell.KeyTab)
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"c", "a"}) {
+		t.Errorf("Expected recent effects, got %v", got)
+	}
+
+	press(sel, "f")
+	if store.Config().IsFavorite("c") {
+		t.Error("Expected second toggle to remove the favourite")
+	}
+}
+
+func TestSortOrderIsSaved(t *testing.T) {
+	sel := newTestSelector(t)
+	store := newTestStore(t)
+	sel.SetConfig(store)
+
+	press(sel, "o", "o")
+	if sel.order != effects.SortAuthor || store.Config().SortOrder != effects.SortAuthor {
+		t.Errorf("Expected author order to be saved, got %q / %q", sel.order, store.Config().SortOrder)
+	}
+
+	reopened := newTestSelector(t)
+	reopened.SetConfig(store)
+	if reopened.order != effects.SortAuthor {
+		t.Errorf("Expected saved order to be restored, got %q", reopened.order)
+	}
+
+	press(sel, "o", "o")
+	if sel.order != effects.SortRegistration {
+		t.Errorf("Expected order to wrap around, got %q", sel.order)
+	}
+}
@@ -0,0 +1,281 @@
+package selector
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+)
+
+// fuzzyScore 计算 pattern 与 text 的模糊匹配得分（忽略大小写）
+// pattern 的字符须按顺序出现在 text 中；连续匹配、单词开头和完整子串得分更高
+func fuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return 0, true
+	}
+
+	score := 0
+	last := -2
+	i := 0
+	for j := 0; j < len(t) && i < len(p); j++ {
+		if t[j] != p[i] {
+			continue
+		}
+
+		score++
+		if j == last+1 {
+			score += 4 // 连续匹配
+		}
+		if j == 0 || !unicode.IsLetter(t[j-1]) && !unicode.IsDigit(t[j-1]) {
+			score += 2 // 单词开头
+		}
+		last = j
+		i++
+	}
+	if i < len(p) {
+		return 0, false
+	}
+
+	if strings.Contains(string(t), string(p)) {
+		score += 3 * len(p)
+	}
+	return score, true
+}
+
+// matchScore 返回搜索词与特效的最佳匹配得分
+// 依次匹配 ID、中英文名称与描述，描述的得分减半
+func matchScore(metadata effects.Metadata, query string) (int, bool) {
+	best, found := 0, false
+	try := func(text string, weight int) {
+		if score, ok := fuzzyScore(query, text); ok && text != "" {
+			found = true
+			best = max(best, score*weight)
+		}
+	}
+
+	try(metadata.ID, 2)
+	try(metadata.Name, 2)
+	try(metadata.NameEN, 2)
+	try(metadata.Description, 1)
+	try(metadata.DescriptionEN, 1)
+	return best, found
+}
+
+// collectTags 返回所有特效的标签，按使用次数从多到少排列（次数相同时按首次出现的顺序）
+func collectTags(list []effects.Metadata) []string {
+	var tags []string
+	counts := make(map[string]int)
+	for _, metadata := range list {
+		for _, tag := range metadata.Tags {
+			if counts[tag] == 0 {
+				tags = append(tags, tag)
+			}
+			counts[tag]++
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return counts[tags[i]] > counts[tags[j]]
+	})
+	return tags
+}
+
+// 标签栏中标签之前的固定筛选项，tagIdx 从 filterTags 开始依次对应各个标签
+const (
+	filterAll       = iota // 全部特效
+	filterFavorites        // 收藏的特效
+	filterRecent           // 最近运行的特效（最近的在前）
+	filterTags
+)
+
+// activeTag 返回当前筛选的标签（空字符串表示没有按标签筛选）
+func (s *Selector) activeTag() string {
+	if s.tagIdx < filterTags || s.tagIdx >= filterTags+len(s.tags) {
+		return ""
+	}
+	return s.tags[s.tagIdx-filterTags]
+}
+
+// candidates 返回当前标签栏筛选项下的特效（尚未按搜索词筛选）
+func (s *Selector) candidates() []effects.Metadata {
+	switch s.tagIdx {
+	case filterAll:
+		return s.all
+	case filterRecent:
+		var list []effects.Metadata
+		for _, id := range s.recent {
+			if i := slices.IndexFunc(s.all, func(m effects.Metadata) bool { return m.ID == id }); i >= 0 {
+				list = append(list, s.all[i])
+			}
+		}
+		return list
+	case filterFavorites:
+		return slices.DeleteFunc(slices.Clone(s.all), func(m effects.Metadata) bool {
+			return !s.isFavorite(m.ID)
+		})
+	}
+
+	tag := s.activeTag()
+	return slices.DeleteFunc(slices.Clone(s.all), func(m effects.Metadata) bool {
+		return !slices.Contains(m.Tags, tag)
+	})
+}
+
+// applyFilter 按搜索词与标签栏的筛选项筛选特效列表，并尽量保持原来的选中项
+// 有搜索词时按匹配得分排序（搜索词变化后由调用方选中第一项），否则收藏的特效排在前面
+func (s *Selector) applyFilter() {
+	selected, _ := s.GetSelected()
+	query := strings.TrimSpace(string(s.query))
+
+	type match struct {
+		metadata effects.Metadata
+		score    int
+	}
+	var matches []match
+	for _, metadata := range s.candidates() {
+		score, ok := matchScore(metadata, query)
+		if !ok {
+			continue
+		}
+		matches = append(matches, match{metadata, score})
+	}
+	switch {
+	case query != "":
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].score > matches[j].score
+		})
+	case s.tagIdx != filterRecent:
+		sort.SliceStable(matches, func(i, j int) bool {
+			return s.isFavorite(matches[i].metadata.ID) && !s.isFavorite(matches[j].metadata.ID)
+		})
+	}
+
+	s.effectList = make([]effects.Metadata, len(matches))
+	s.selectedIdx = 0
+	for i, m := range matches {
+		s.effectList[i] = m.metadata
+		if m.metadata.ID == selected.ID {
+			s.selectedIdx = i
+		}
+	}
+}
+
+// filtering 判断是否有生效的筛选条件
+func (s *Selector) filtering() bool {
+	return len(s.query) > 0 || s.tagIdx != filterAll
+}
+
+// cycleTag 切换到下一个（delta 为 1）或上一个（delta 为 -1）筛选项
+func (s *Selector) cycleTag(delta int) {
+	n := len(s.tags) + filterTags
+	s.tagIdx = ((s.tagIdx+delta)%n + n) % n
+	s.applyFilter()
+}
+
+// setQuery 修改搜索词并选中匹配得分最高的特效
+func (s *Selector) setQuery(query []rune) {
+	s.query = query
+	s.applyFilter()
+	s.selectedIdx = 0
+}
+
+// handleSearchKey 处理搜索模式下的按键
+// 输入的字符追加到搜索词，列表随之实时更新；Enter 保留搜索结果退出搜索模式，Esc 清空搜索词
+func (s *Selector) handleSearchKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		s.searching = false
+		s.setQuery(nil)
+	case tcell.KeyEnter:
+		s.searching = false
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(s.query) > 0 {
+			s.setQuery(s.query[:len(s.query)-1])
+		}
+	case tcell.KeyUp:
+		s.MoveUp()
+	case tcell.KeyDown:
+		s.MoveDown()
+	case tcell.KeyTab:
+		s.cycleTag(1)
+	case tcell.KeyBacktab:
+		s.cycleTag(-1)
+	case tcell.KeyRune:
+		s.setQuery(append(s.query, event.Rune()))
+	}
+}
+
+// renderFilterBar 渲染搜索框、标签栏与排序方式
+func (s *Selector) renderFilterBar() {
+	mgr := i18n.GetManager()
+	y := listStartY - 2
+	dim := s.theme.Style(s.theme.UI.Dim)
+	text := s.theme.Style(s.theme.UI.Text)
+	highlight := s.theme.HighlightStyle()
+
+	// 搜索框
+	x := 2
+	label := mgr.T(i18n.KeySearchLabel) + " /"
+	s.drawText(x, y, label, dim)
+	x += uniseg.StringWidth(label)
+	switch {
+	case s.searching:
+		s.drawText(x, y, string(s.query)+"▏", s.theme.Style(s.theme.UI.Accent).Bold(true))
+	case len(s.query) > 0:
+		s.drawText(x, y, string(s.query), text)
+	}
+	x = max(x+uniseg.StringWidth(string(s.query))+4, 24)
+
+	// 排序方式显示在最右侧
+	sortText := mgr.T(i18n.KeySortLabel) + " " + mgr.T(sortOrderKeys[s.order])
+	right := s.width - 2 - uniseg.StringWidth(sortText)
+	s.drawText(right, y, sortText, dim)
+	s.filterHits = append(s.filterHits[:0], hitArea{x0: right, x1: s.width - 2, index: sortHit})
+	right -= 2
+
+	// 标签栏：宽度不够时从当前标签附近开始显示
+	label = mgr.T(i18n.KeyTagsLabel)
+	s.drawText(x, y, label, dim)
+	x += uniseg.StringWidth(label) + 1
+
+	names := append([]string{mgr.T(i18n.KeyTagAll), mgr.T(i18n.KeyFavorites), mgr.T(i18n.KeyRecent)}, s.tags...)
+	start := 0
+	for start < s.tagIdx && tagsWidth(names[start:s.tagIdx+1]) > right-x-2 {
+		start++
+	}
+	if start > 0 {
+		s.drawText(x, y, "…", dim)
+		x += 2
+	}
+	for i := start; i < len(names); i++ {
+		w := uniseg.StringWidth(names[i]) + 2
+		if x+w > right {
+			s.drawText(x, y, "…", dim)
+			break
+		}
+
+		style := text
+		if i == s.tagIdx {
+			style = highlight
+		}
+		s.drawText(x, y, " "+names[i]+" ", style)
+		s.filterHits = append(s.filterHits, hitArea{x0: x, x1: x + w, index: i})
+		x += w + 1
+	}
+}
+
+// tagsWidth 返回标签在标签栏中占用的宽度
+func tagsWidth(names []string) int {
+	width := 0
+	for _, name := range names {
+		width += uniseg.StringWidth(name) + 3
+	}
+	return width
+}
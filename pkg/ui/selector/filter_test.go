@@ -0,0 +1,111 @@
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("mrn", "matrix-rain"); !ok {
+		t.Error("Expected subsequence to match")
+	}
+	if _, ok := fuzzyScore("rm", "matrix-rain"); ok {
+		t.Error("Expected out-of-order pattern not to match")
+	}
+
+	substring, _ := fuzzyScore("rain", "matrix-rain")
+	spread, _ := fuzzyScore("rain", "r-a-i-n")
+	if spread >= substring {
+		t.Errorf("Expected substrings to rank above scattered matches, got %d and %d", substring, spread)
+	}
+}
+
+// setTestEffects 用给定的特效列表替换选择器中的全部特效
+func setTestEffects(sel *Selector, list ...effects.Metadata) {
+	sel.all = list
+	sel.tags = collectTags(list)
+	sel.tagIdx = 0
+	sel.selectedIdx = 0
+	sel.applyFilter()
+}
+
+// listedIDs 返回当前列表中的特效 ID
+func listedIDs(sel *Selector) []string {
+	var ids []string
+	for _, metadata := range sel.effectList {
+		ids = append(ids, metadata.ID)
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	sel := newTestSelector(t)
+	setTestEffects(sel,
+		effects.Metadata{ID: "fire-effect", Name: "火焰", NameEN: "Fire"},
+		effects.Metadata{ID: "fireworks", Name: "烟花", NameEN: "Fireworks"},
+		effects.Metadata{ID: "snowfall", Name: "雪花", NameEN: "Snowfall", Description: "冬夜飘落的雪花"},
+	)
+
+	press(sel, "/", "work")
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"fireworks"}) {
+		t.Fatalf("Expected list to narrow while typing, got %v", got)
+	}
+
+	press(sel, tcell.KeyBackspace2, tcell.KeyBackspace2, tcell.KeyBackspace2, tcell.KeyBackspace2, "雪")
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"snowfall"}) {
+		t.Fatalf("Expected Chinese names to be searchable, got %v", got)
+	}
+
+	// Enter 退出搜索模式但保留结果，之后的按键恢复为普通快捷键
+	press(sel, tcell.KeyEnter, "j")
+	if !sel.filtering() || sel.searching {
+		t.Fatal("Expected Enter to keep the filter and leave search mode")
+	}
+
+	press(sel, tcell.KeyEscape)
+	if got := listedIDs(sel); len(got) != 3 || sel.filtering() {
+		t.Errorf("Expected Esc to clear the filter, got %v", got)
+	}
+	if metadata, _ := sel.GetSelected(); metadata.ID != "snowfall" {
+		t.Errorf("Expected selection to stay on the last match, got %q", metadata.ID)
+	}
+}
+
+func TestTagFilter(t *testing.T) {
+	sel := newTestSelector(t)
+	setTestEffects(sel,
+		effects.Metadata{ID: "a", Tags: []string{"nature"}},
+		effects.Metadata{ID: "b", Tags: []string{"retro", "nature"}},
+		effects.Metadata{ID: "c", Tags: []string{"retro"}},
+		effects.Metadata{ID: "d", Tags: []string{"nature"}},
+	)
+
+	if !reflect.DeepEqual(sel.tags, []string{"nature", "retro"}) {
+		t.Fatalf("Expected tags ordered by use, got %v", sel.tags)
+	}
+
+	// 标签排在全部、收藏和最近之后
+	press(sel, tcell.KeyTab, tcell.KeyTab, tcell.KeyTab)
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"a", "b", "d"}) {
+		t.Errorf("Expected nature effects, got %v", got)
+	}
+
+	press(sel, tcell.KeyTab, "/", "b")
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Expected search to combine with the tag, got %v", got)
+	}
+
+	press(sel, tcell.KeyEscape, tcell.KeyBacktab)
+	if got := listedIDs(sel); !reflect.DeepEqual(got, []string{"a", "b", "d"}) {
+		t.Errorf("Expected Shift+Tab to go back to nature, got %v", got)
+	}
+
+	press(sel, "/", "zzz")
+	sel.Render()
+	if len(sel.effectList) != 0 {
+		t.Errorf("Expected no matches, got %v", listedIDs(sel))
+	}
+}
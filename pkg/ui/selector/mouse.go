@@ -0,0 +1,67 @@
+package selector
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// sortHit 点击区域对应排序方式（而不是标签栏中的筛选项）
+const sortHit = -1
+
+// hitArea 搜索栏所在行中可点击的区域（从 x0 列到 x1 列之前）
+type hitArea struct {
+	x0, x1 int
+	index  int // 标签栏中的筛选项，或 sortHit
+}
+
+// HandleMouse 处理鼠标事件，返回值与 HandleKey 相同
+// 点击特效选中它，点击已选中的特效或预览区域运行它；滚轮上下移动选择；
+// 点击标签栏切换筛选项，点击排序方式切换排序
+func (s *Selector) HandleMouse(event *tcell.EventMouse) int {
+	pressed := s.buttons.Pressed(event)
+	if s.settings != nil {
+		return -1
+	}
+
+	switch {
+	case event.Buttons()&tcell.WheelUp != 0:
+		s.MoveUp()
+		return -1
+	case event.Buttons()&tcell.WheelDown != 0:
+		s.MoveDown()
+		return -1
+	case pressed&tcell.Button1 == 0:
+		return -1
+	}
+
+	x, y := event.Position()
+	if y == listStartY-2 {
+		for _, hit := range s.filterHits {
+			if x < hit.x0 || x >= hit.x1 {
+				continue
+			}
+			if hit.index == sortHit {
+				s.cycleSortOrder()
+			} else {
+				s.tagIdx = hit.index
+				s.applyFilter()
+			}
+			return -1
+		}
+		return -1
+	}
+
+	if idx := s.listIndexAt(x, y); idx >= 0 {
+		if idx == s.selectedIdx {
+			return idx
+		}
+		s.selectedIdx = idx
+		return -1
+	}
+
+	if rect, _ := s.previewRect(); !rect.Empty() && rect.Contains(x-rect.X, y-rect.Y) {
+		if _, ok := s.GetSelected(); ok {
+			return s.selectedIdx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,57 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+)
+
+// click 在 (x, y) 处按下并松开鼠标左键，返回按下时 HandleMouse 的结果
+func click(sel *Selector, x, y int) int {
+	result := sel.HandleMouse(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	sel.HandleMouse(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+	return result
+}
+
+func TestMouse(t *testing.T) {
+	sel := newTestSelector(t)
+	setTestEffects(sel,
+		effects.Metadata{ID: "a", Tags: []string{"nature"}},
+		effects.Metadata{ID: "b"},
+		effects.Metadata{ID: "c", Tags: []string{"nature"}},
+	)
+	sel.Render()
+
+	x := sel.listStartX() + 4
+	if result := click(sel, x, listStartY+2); result != -1 || sel.selectedIdx != 2 {
+		t.Fatalf("Expected click to select the third effect, got result %d, selection %d", result, sel.selectedIdx)
+	}
+	if result := click(sel, x, listStartY+2); result != 2 {
+		t.Errorf("Expected clicking the selection to run it, got %d", result)
+	}
+	if result := click(sel, x, listStartY+5); result != -1 || sel.selectedIdx != 2 {
+		t.Errorf("Expected click below the list to do nothing, got result %d, selection %d", result, sel.selectedIdx)
+	}
+
+	sel.HandleMouse(tcell.NewEventMouse(x, listStartY, tcell.WheelUp, tcell.ModNone))
+	if sel.selectedIdx != 1 {
+		t.Errorf("Expected wheel to move the selection up, got %d", sel.selectedIdx)
+	}
+
+	// 点击标签栏中的标签
+	for _, hit := range sel.filterHits {
+		if hit.index == filterTags {
+			click(sel, hit.x0, listStartY-2)
+		}
+	}
+	if got := listedIDs(sel); len(got) != 2 || sel.activeTag() != "nature" {
+		t.Errorf("Expected clicking the tag to filter, got %v", got)
+	}
+
+	// 拖动不会重复触发点击
+	sel.HandleMouse(tcell.NewEventMouse(x, listStartY, tcell.Button1, tcell.ModNone))
+	if result := sel.HandleMouse(tcell.NewEventMouse(x, listStartY, tcell.Button1, tcell.ModNone)); result != -1 {
+		t.Errorf("Expected held button not to count as a second click, got %d", result)
+	}
+}
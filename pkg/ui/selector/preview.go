@@ -0,0 +1,210 @@
+package selector
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/viewport"
+)
+
+// 预览区域（不含边框）的最小尺寸，终端放不下时暂停预览
+const (
+	previewMinWidth  = 24
+	previewMinHeight = 6
+)
+
+// preview 在选择器中运行的特效预览
+// 特效绘制到离屏缓冲区，每次 Show 时由选择器把缓冲区复制到预览区域，
+// 因此预览与选择器的绘制互不干扰。
+type preview struct {
+	effectID string
+	rect     viewport.Rect // 预览区域在屏幕上的位置
+	buffer   tcell.SimulationScreen
+	effect   effects.Effect
+	palette  *palette.Palette // 特效使用的调色板（nil 表示特效自己的配色）
+	err      error            // 创建或初始化失败的原因
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// newPreview 创建特效预览，特效使用配置中的默认帧率、已保存的参数与 palettes 返回的调色板（palettes 可为 nil）
+func newPreview(effectID string, rect viewport.Rect, store *config.Store, palettes func(string) (*palette.Palette, error)) *preview {
+	p := &preview{
+		effectID: effectID,
+		rect:     rect,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	factory, err := effects.Get(effectID)
+	if err != nil {
+		p.err = err
+		return p
+	}
+
+	p.effect = factory()
+	if store != nil {
+		effects.ApplySavedParams(effects.ParamsOf(p.effect), store.Config().RunParams(effectID), "")
+	}
+	effects.ApplyClock(p.effect, effects.NewClock())
+	if palettes != nil {
+		p.palette, p.err = palettes(effectID)
+	}
+	return p
+}
+
+// start 初始化特效并在后台运行，onShow 在特效每次刷新画面时调用（在特效的帧循环中）
+func (p *preview) start(onShow func()) {
+	if p.err != nil {
+		close(p.done)
+		return
+	}
+
+	p.buffer = tcell.NewSimulationScreen("UTF-8")
+	if p.err = p.buffer.Init(); p.err != nil {
+		p.buffer = nil
+		close(p.done)
+		return
+	}
+	p.buffer.SetSize(p.rect.Width, p.rect.Height)
+
+	screen := effects.PaletteScreen(p.effect, &previewScreen{SimulationScreen: p.buffer, onShow: onShow}, p.palette, palette.DepthTrueColor)
+	if p.err = p.effect.Init(screen); p.err != nil {
+		p.effect.Cleanup()
+		close(p.done)
+		return
+	}
+
+	go func() {
+		defer close(p.done)
+		defer p.effect.Cleanup()
+		p.effect.Run(p.quit)
+	}()
+}
+
+// stop 停止预览并等待特效退出
+// 调用方不能持有 drawMu，否则特效的帧循环无法结束
+func (p *preview) stop() {
+	close(p.quit)
+	<-p.done
+	if p.buffer != nil {
+		p.buffer.Fini()
+	}
+}
+
+// draw 把预览缓冲区的内容复制到屏幕的预览区域
+func (p *preview) draw(screen tcell.Screen) {
+	for y := 0; y < p.rect.Height; y++ {
+		for x := 0; x < p.rect.Width; x++ {
+			r, comb, style, _ := p.buffer.GetContent(x, y)
+			screen.SetContent(p.rect.X+x, p.rect.Y+y, r, comb, style)
+		}
+	}
+}
+
+// previewScreen 预览特效使用的离屏屏幕，Show 与 Sync 通知选择器复制画面
+type previewScreen struct {
+	tcell.SimulationScreen
+	onShow func()
+}
+
+// Show 通知选择器刷新预览区域
+func (s *previewScreen) Show() {
+	s.onShow()
+}
+
+// Sync 通知选择器刷新预览区域
+func (s *previewScreen) Sync() {
+	s.onShow()
+}
+
+// previewRect 返回预览区域（不含边框）以及它是否位于列表右侧
+// 列表靠左后右侧放得下时显示在右侧，否则显示在列表下方；都放不下时返回空区域
+func (s *Selector) previewRect() (viewport.Rect, bool) {
+	columns, rows := s.listSize()
+	bottom := s.height - 8 // 边框下沿，描述区域的分隔线上方
+
+	right := viewport.Rect{X: 2 + columns*columnWidth + 1, Y: listStartY + 1}
+	right.Width = s.width - 2 - right.X
+	right.Height = bottom - right.Y
+	if right.Width >= previewMinWidth && right.Height >= previewMinHeight {
+		return right, true
+	}
+
+	below := viewport.Rect{X: 3, Y: listStartY + rows + 2}
+	below.Width = s.width - 3 - below.X
+	below.Height = bottom - below.Y
+	if below.Width >= previewMinWidth && below.Height >= previewMinHeight {
+		return below, false
+	}
+	return viewport.Rect{}, false
+}
+
+// syncPreview 让预览跟随当前选中的特效
+// 选中项或预览区域变化时重新启动预览；设置面板打开或终端太小时暂停预览
+func (s *Selector) syncPreview() {
+	rect, _ := s.previewRect()
+	metadata, ok := s.GetSelected()
+	if s.settings != nil || rect.Empty() || !ok {
+		s.StopPreview()
+		return
+	}
+
+	if current := s.preview; current != nil && current.effectID == metadata.ID && current.rect == rect {
+		return
+	}
+
+	s.StopPreview()
+	p := newPreview(metadata.ID, rect, s.store, s.palettes)
+	s.drawMu.Lock()
+	s.preview = p
+	s.drawMu.Unlock()
+	p.start(func() { s.showPreview(p) })
+}
+
+// StopPreview 停止预览（启动特效或退出前调用），下次 Render 时重新启动
+func (s *Selector) StopPreview() {
+	s.drawMu.Lock()
+	p := s.preview
+	s.preview = nil
+	s.drawMu.Unlock()
+
+	if p != nil {
+		p.stop()
+	}
+}
+
+// showPreview 把预览的新画面复制到屏幕并刷新（由预览特效的帧循环调用）
+func (s *Selector) showPreview(p *preview) {
+	s.drawMu.Lock()
+	defer s.drawMu.Unlock()
+
+	// 已被替换的预览不再绘制
+	if s.preview != p {
+		return
+	}
+	p.draw(s.screen)
+	s.screen.Show()
+}
+
+// renderPreview 绘制预览区域的边框与当前画面（调用方须持有 drawMu）
+func (s *Selector) renderPreview() {
+	p := s.preview
+	if p == nil {
+		return
+	}
+
+	mgr := i18n.GetManager()
+	border := s.theme.Style(s.theme.UI.Dim)
+	s.drawBox(p.rect.X-1, p.rect.Y-1, p.rect.Width+2, p.rect.Height+2, border)
+	s.drawText(p.rect.X+1, p.rect.Y-1, " "+mgr.T(i18n.KeyPreview)+" ", s.theme.Style(s.theme.UI.Accent))
+
+	if p.err != nil {
+		s.drawText(p.rect.X+1, p.rect.Y+1, p.err.Error(), s.theme.Style(s.theme.UI.Dim))
+		return
+	}
+	p.draw(s.screen)
+}
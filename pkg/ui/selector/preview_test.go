@@ -0,0 +1,64 @@
+package selector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPreview(t *testing.T) {
+	sel := newTestSelector(t)
+	sel.Render()
+
+	p := sel.preview
+	if p == nil {
+		t.Fatal("Expected preview to start for the highlighted effect")
+	}
+	if p.effectID != "matrix-rain" {
+		t.Errorf("Expected preview of matrix-rain, got %s", p.effectID)
+	}
+
+	// 等待预览的帧循环把画面复制到屏幕上
+	screen := sel.screen.(tcell.SimulationScreen)
+	deadline := time.Now().Add(2 * time.Second)
+	for !regionDrawn(screen, p) {
+		if time.Now().After(deadline) {
+			t.Fatal("Preview never drew into its region")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 重新渲染时选中项未变，预览继续运行
+	sel.Render()
+	if sel.preview != p {
+		t.Error("Preview should keep running while the highlight stays")
+	}
+
+	// 打开设置面板时暂停预览
+	press(sel, "s")
+	sel.Render()
+	if sel.preview != nil {
+		t.Error("Expected preview to pause while the settings panel is open")
+	}
+	press(sel, tcell.KeyEscape)
+
+	// 终端太小时暂停预览
+	screen.SetSize(40, 20)
+	sel.Render()
+	if sel.preview != nil {
+		t.Error("Expected preview to pause on a small terminal")
+	}
+}
+
+// regionDrawn 判断预览区域中是否出现了非空白的字符
+func regionDrawn(screen tcell.Screen, p *preview) bool {
+	for y := p.rect.Y; y < p.rect.Y+p.rect.Height; y++ {
+		for x := p.rect.X; x < p.rect.X+p.rect.Width; x++ {
+			if r, _, _, _ := screen.GetContent(x, y); r != ' ' && r != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -2,28 +2,102 @@ package selector
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/config"
 	"github.com/symbolmove/symbol_move/pkg/effects"
 	"github.com/symbolmove/symbol_move/pkg/i18n"
+	"github.com/symbolmove/symbol_move/pkg/palette"
+	"github.com/symbolmove/symbol_move/pkg/theme"
+)
+
+// 特效列表布局
+const (
+	listStartY       = 8  // 列表起始行（上方为搜索框与标签栏）
+	maxRowsPerColumn = 10 // 每列最多显示的特效数
+	columnWidth      = 30 // 每列宽度（包括序号、名称、间距）
 )
 
 // Selector 特效选择器
 type Selector struct {
 	screen      tcell.Screen
-	effectList  []effects.Metadata
+	all         []effects.Metadata // 全部特效
+	effectList  []effects.Metadata // 按搜索词与标签筛选后显示的特效
 	selectedIdx int
 	width       int
 	height      int
+	store       *config.Store  // 应用配置（可为 nil，此时设置不会保存）
+	settings    *settingsPanel // 打开的参数设置面板（nil 表示未打开）
+	theme       *theme.Theme   // 界面配色
+
+	// palettes 返回预览特效使用的调色板（nil 表示预览使用特效自己的配色）
+	palettes func(effectID string) (*palette.Palette, error)
+
+	searching bool     // 是否处于搜索模式（按键输入到搜索词）
+	query     []rune   // 搜索词
+	tags      []string // 标签栏中的标签
+	tagIdx    int      // 标签栏中当前的筛选项（filterAll 等，或 filterTags+i 表示 tags[i]）
+
+	favorites []string          // 收藏的特效 ID
+	recent    []string          // 最近运行的特效 ID（最近的在前）
+	plays     map[string]int    // 特效 ID -> 运行次数
+	order     effects.SortOrder // 特效列表的排序方式
+
+	buttons    effects.MouseButtons // 鼠标按键状态
+	filterHits []hitArea            // 搜索栏所在行中可点击的区域（Render 时更新）
+
+	drawMu  sync.Mutex // 屏幕绘制互斥锁，预览的帧循环与 Render 互斥
+	preview *preview   // 正在运行的预览（nil 表示没有预览），修改时须持有 drawMu
 }
 
 // New 创建新的选择器
 func New(screen tcell.Screen) *Selector {
-	return &Selector{
+	s := &Selector{
 		screen:      screen,
-		effectList:  effects.List(),
 		selectedIdx: 0,
+		order:       effects.SortRegistration,
+		theme:       theme.Dark,
+	}
+	s.Refresh()
+	return s
+}
+
+// SetConfig 设置应用配置，设置面板保存的参数、界面语言、收藏与排序方式写入其中
+// 并选中最近运行的特效
+func (s *Selector) SetConfig(store *config.Store) {
+	s.store = store
+	if store == nil {
+		return
+	}
+	s.Refresh()
+
+	lastRun := store.Config().LastRun
+	for i, metadata := range s.effectList {
+		if metadata.ID == lastRun {
+			s.selectedIdx = i
+		}
+	}
+}
+
+// SetTheme 设置界面配色，下次 Render 时生效
+func (s *Selector) SetTheme(t *theme.Theme) {
+	s.theme = t
+}
+
+// SetPalettes 设置预览特效使用的调色板，应与运行特效时使用的调色板一致
+func (s *Selector) SetPalettes(palettes func(effectID string) (*palette.Palette, error)) {
+	s.palettes = palettes
+}
+
+// toggleLanguage 切换界面语言并保存到配置
+func (s *Selector) toggleLanguage() {
+	lang := i18n.GetManager().Toggle()
+	if s.store != nil {
+		s.store.Update(func(cfg *config.Config) { cfg.Language = lang }) // 保存失败时仅本次生效
 	}
 }
 
@@ -35,11 +109,19 @@ func (s *Selector) updateSize() {
 // Render 渲染选择器界面
 func (s *Selector) Render() {
 	s.updateSize()
-	s.screen.Clear()
+	s.syncPreview()
+
+	s.drawMu.Lock()
+	defer s.drawMu.Unlock()
+
+	s.screen.Fill(' ', s.theme.Style(s.theme.UI.Text))
 
 	// 标题区域
 	s.renderTitle()
 
+	// 搜索框与标签栏
+	s.renderFilterBar()
+
 	// 特效列表
 	s.renderEffectList()
 
@@ -49,6 +131,14 @@ func (s *Selector) Render() {
 	// 提示区域
 	s.renderHints()
 
+	// 预览区域
+	s.renderPreview()
+
+	// 参数设置面板
+	if s.settings != nil {
+		s.renderSettings(s.settings)
+	}
+
 	s.screen.Show()
 }
 
@@ -60,41 +150,63 @@ func (s *Selector) renderTitle() {
 
 	// 居中显示标题
 	titleY := 2
-	s.drawCenteredText(titleY, title, tcell.StyleDefault.
-		Foreground(tcell.ColorLightGreen).
-		Bold(true))
+	s.drawCenteredText(titleY, title, s.theme.Style(s.theme.UI.Title).Bold(true))
 
-	s.drawCenteredText(titleY+1, subtitle, tcell.StyleDefault.
-		Foreground(tcell.ColorGreen))
+	s.drawCenteredText(titleY+1, subtitle, s.theme.Style(s.theme.UI.Accent))
 
 	// 分隔线
 	s.drawHorizontalLine(titleY + 3)
 }
 
+// listSize 返回特效列表的列数和行数
+// 按全部特效计算，筛选时列表和预览的位置保持不变
+func (s *Selector) listSize() (columns, rows int) {
+	total := len(s.all)
+	columns = (total + maxRowsPerColumn - 1) / maxRowsPerColumn
+	rows = min(total, maxRowsPerColumn)
+	return columns, rows
+}
+
+// listStartX 返回特效列表的起始列
+// 列表按总宽度居中，预览显示在右侧时列表靠左
+func (s *Selector) listStartX() int {
+	columns, _ := s.listSize()
+	startX := (s.width - columns*columnWidth) / 2
+	if _, beside := s.previewRect(); startX < 0 || beside {
+		startX = 2
+	}
+	return startX
+}
+
+// listIndexAt 返回屏幕坐标 (x, y) 处的特效在列表中的序号，不在任何特效上时返回 -1
+func (s *Selector) listIndexAt(x, y int) int {
+	startX := s.listStartX()
+	row := y - listStartY
+	if x < startX || row < 0 || row >= maxRowsPerColumn || y >= s.height-5 {
+		return -1
+	}
+
+	idx := (x-startX)/columnWidth*maxRowsPerColumn + row
+	if idx >= len(s.effectList) {
+		return -1
+	}
+	return idx
+}
+
 // renderEffectList 渲染特效列表（支持多列）
 func (s *Selector) renderEffectList() {
-	startY := 6
+	startY := listStartY
 
 	if len(s.effectList) == 0 {
-		s.drawCenteredText(startY+2, "暂无可用特效", tcell.StyleDefault.
-			Foreground(tcell.ColorGray))
+		message := "暂无可用特效"
+		if s.filtering() {
+			message = i18n.GetManager().T(i18n.KeyNoMatch)
+		}
+		s.drawCenteredText(startY+2, message, s.theme.Style(s.theme.UI.Dim))
 		return
 	}
 
-	// 计算列数和每列行数
-	maxRowsPerColumn := 10
-	totalEffects := len(s.effectList)
-	columns := (totalEffects + maxRowsPerColumn - 1) / maxRowsPerColumn
-
-	// 每列宽度（包括序号、名称、间距）
-	columnWidth := 30
-
-	// 计算总宽度并居中
-	totalWidth := columns * columnWidth
-	startX := (s.width - totalWidth) / 2
-	if startX < 0 {
-		startX = 2
-	}
+	startX := s.listStartX()
 
 	// 绘制特效列表
 	mgr := i18n.GetManager()
@@ -117,23 +229,31 @@ func (s *Selector) renderEffectList() {
 		// 特效名称 - 根据当前语言选择
 		nameText := mgr.GetEffectName(metadata.Name, metadata.NameEN)
 
+		// 收藏的特效带有星标
+		if s.isFavorite(metadata.ID) {
+			nameText += " ★"
+		}
+
 		// 完整文本
 		text := fmt.Sprintf("  %s %s", indexText, nameText)
 
 		// 选中状态
-		style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+		style := s.theme.Style(s.theme.UI.Text)
 		if i == s.selectedIdx {
 			// 高亮选中项
 			text = fmt.Sprintf("> %s %s", indexText, nameText)
-			style = tcell.StyleDefault.
-				Foreground(tcell.ColorGreen).
-				Bold(true)
+			style = s.theme.Style(s.theme.UI.Accent).Bold(true)
 		}
 
 		s.drawText(x, y, text, style)
 	}
 }
 
+// customized 判断特效是否有已保存的参数
+func (s *Selector) customized(effectID string) bool {
+	return s.store != nil && len(s.store.EffectParams(effectID)) > 0
+}
+
 // renderDescription 渲染描述区域
 func (s *Selector) renderDescription() {
 	if len(s.effectList) == 0 || s.selectedIdx >= len(s.effectList) {
@@ -155,8 +275,10 @@ func (s *Selector) renderDescription() {
 		desc = desc[:maxLen-3] + "..."
 	}
 	fullDesc := descLabel + desc
-	s.drawText(4, descY, fullDesc, tcell.StyleDefault.
-		Foreground(tcell.ColorWhite))
+	if s.customized(metadata.ID) {
+		fullDesc += " " + mgr.T(i18n.KeySettingsModified)
+	}
+	s.drawText(4, descY, fullDesc, s.theme.Style(s.theme.UI.Text))
 }
 
 // renderHints 渲染操作提示
@@ -171,8 +293,7 @@ func (s *Selector) renderHints() {
 	langIndicator := mgr.T(i18n.KeyLanguageIndicator)
 	hintsWithLang := hints + " | " + langIndicator
 
-	s.drawCenteredText(hintY, hintsWithLang, tcell.StyleDefault.
-		Foreground(tcell.ColorGray))
+	s.drawCenteredText(hintY, hintsWithLang, s.theme.Style(s.theme.UI.Dim))
 }
 
 // drawText 在指定位置绘制文本
@@ -183,37 +304,21 @@ func (s *Selector) drawText(x, y int, text string, style tcell.Style) {
 
 	pos := x
 	for _, ch := range text {
+		// 中文等宽字符占2个位置
+		w := uniseg.StringWidth(string(ch))
 		if pos >= 0 && pos < s.width {
 			var comb []rune
-			w := 1
-			// 中文等宽字符占2个位置
-			if ch > 127 {
-				w = 2
-			}
 			s.screen.SetContent(pos, y, ch, comb, style)
-			pos += w
-		} else {
-			// 跳过不在范围内的字符，但仍需计算位置
-			w := 1
-			if ch > 127 {
-				w = 2
-			}
-			pos += w
 		}
+		// 跳过不在范围内的字符，但仍需计算位置
+		pos += w
 	}
 }
 
 // drawCenteredText 居中绘制文本
 func (s *Selector) drawCenteredText(y int, text string, style tcell.Style) {
 	// 计算文本的显示宽度（中文字符占2个宽度）
-	textWidth := 0
-	for _, ch := range text {
-		if ch > 127 {
-			textWidth += 2
-		} else {
-			textWidth += 1
-		}
-	}
+	textWidth := uniseg.StringWidth(text)
 	x := (s.width - textWidth) / 2
 	if x < 0 {
 		x = 0
@@ -228,29 +333,86 @@ func (s *Selector) drawHorizontalLine(y int) {
 	}
 
 	line := strings.Repeat("─", s.width)
-	s.drawText(0, y, line, tcell.StyleDefault.
-		Foreground(tcell.ColorGray))
+	s.drawText(0, y, line, s.theme.Style(s.theme.UI.Dim))
+}
+
+// drawBox 绘制带边框的矩形并清空其内部
+func (s *Selector) drawBox(x0, y0, width, height int, style tcell.Style) {
+	for y := y0; y < y0+height; y++ {
+		for x := x0; x < x0+width; x++ {
+			top, bottom := y == y0, y == y0+height-1
+			left, right := x == x0, x == x0+width-1
+
+			ch := ' '
+			switch {
+			case top && left:
+				ch = '┌'
+			case top && right:
+				ch = '┐'
+			case bottom && left:
+				ch = '└'
+			case bottom && right:
+				ch = '┘'
+			case top || bottom:
+				ch = '─'
+			case left || right:
+				ch = '│'
+			}
+			s.screen.SetContent(x, y, ch, nil, style)
+		}
+	}
 }
 
 // HandleKey 处理键盘事件
 func (s *Selector) HandleKey(event *tcell.EventKey) int {
-	if len(s.effectList) == 0 {
+	// 设置面板打开时按键全部交给面板处理
+	if s.settings != nil {
+		if s.settings.handleKey(event, s.store) {
+			s.settings = nil
+		}
+		return -1
+	}
+
+	// 搜索模式下按键输入到搜索词
+	if s.searching {
+		s.handleSearchKey(event)
+		return -1
+	}
+
+	switch {
+	case event.Key() == tcell.KeyRune && event.Rune() == '/':
+		s.searching = true
+		return -1
+	case event.Key() == tcell.KeyTab:
+		s.cycleTag(1)
+		return -1
+	case event.Key() == tcell.KeyBacktab:
+		s.cycleTag(-1)
+		return -1
+	case event.Key() == tcell.KeyEscape && s.filtering():
+		// 清除搜索词与标签筛选
+		s.query = nil
+		s.tagIdx = filterAll
+		s.applyFilter()
 		return -1
 	}
 
-	maxRowsPerColumn := 10
+	if len(s.effectList) == 0 {
+		if event.Key() == tcell.KeyRune && (event.Rune() == 'q' || event.Rune() == 'Q') {
+			return -2
+		}
+		return -1
+	}
 
 	// 处理 Ctrl+Space 切换语言 (尝试多种方式捕获)
 	// 方式1: 检查 Ctrl + 空格字符 (某些终端)
 	if event.Key() == tcell.KeyRune && event.Rune() == ' ' && event.Modifiers()&tcell.ModCtrl != 0 {
-		mgr := i18n.GetManager()
-		mgr.ToggleAndSave()
+		s.toggleLanguage()
 		return -3 // 返回 -3 表示需要重新渲染
 	}
 	// 方式2: 检查 NUL 字符 (Ctrl+Space 在某些终端发送 0x00)
 	if event.Key() == tcell.KeyNUL || event.Key() == tcell.KeyRune && event.Rune() == 0 {
-		mgr := i18n.GetManager()
-		mgr.ToggleAndSave()
+		s.toggleLanguage()
 		return -3
 	}
 
@@ -291,12 +453,24 @@ func (s *Selector) HandleKey(event *tcell.EventKey) int {
 			if newIdx < len(s.effectList) {
 				s.selectedIdx = newIdx
 			}
+		case 's', 'S':
+			// 打开当前特效的参数设置面板
+			if metadata, ok := s.GetSelected(); ok {
+				var saved map[string]any
+				if s.store != nil {
+					saved = s.store.EffectParams(metadata.ID)
+				}
+				s.settings = newSettingsPanel(metadata, saved)
+			}
+		case 'f', 'F':
+			s.toggleFavorite()
+		case 'o', 'O':
+			s.cycleSortOrder()
 		case 'q', 'Q':
 			return -2 // 退出信号
 		case 't', 'T':
 			// 切换语言 (备用快捷键)
-			mgr := i18n.GetManager()
-			mgr.ToggleAndSave()
+			s.toggleLanguage()
 			return -3
 		default:
 			// 数字快捷键
@@ -352,10 +526,19 @@ func (s *Selector) GetSelected() (effects.Metadata, bool) {
 	return s.effectList[s.selectedIdx], true
 }
 
-// Refresh 刷新特效列表
+// Refresh 重新读取配置并刷新特效列表，保留搜索词、标签筛选与选中项
 func (s *Selector) Refresh() {
-	s.effectList = effects.List()
-	if s.selectedIdx >= len(s.effectList) {
-		s.selectedIdx = 0
+	tag := s.activeTag()
+
+	s.loadHistory()
+	s.all = effects.ListSorted(s.order, s.plays)
+	s.tags = collectTags(s.all)
+	if tag != "" {
+		s.tagIdx = filterAll
+		if i := slices.Index(s.tags, tag); i >= 0 {
+			s.tagIdx = filterTags + i
+		}
 	}
+
+	s.applyFilter()
 }
@@ -0,0 +1,430 @@
+package selector
+
+import (
+	"math"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/uniseg"
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	"github.com/symbolmove/symbol_move/pkg/i18n"
+)
+
+// sliderWidth 数值滑块的宽度
+const sliderWidth = 20
+
+// colorChoices 用 ←→ 调整颜色参数时循环的颜色（其他颜色可按 Enter 直接输入）
+var colorChoices = []string{
+	"white", "silver", "gray", "red", "maroon", "orange", "yellow", "olive",
+	"lime", "green", "aqua", "teal", "lightblue", "blue", "navy", "fuchsia", "purple",
+}
+
+// settingsPanel 特效参数设置面板
+type settingsPanel struct {
+	effectID string
+	title    string
+	params   *effects.ParamSet // 绑定到临时特效实例的参数，保存前不影响任何运行中的特效
+	defaults map[string]any    // 特效的默认参数值
+	cursor   int
+	offset   int    // 参数较多时的滚动位置
+	editing  bool   // 是否正在输入文本
+	input    []rune // 正在输入的文本
+	message  string // 状态信息（输入错误、保存失败等）
+}
+
+// newSettingsPanel 为特效创建设置面板，并载入已保存的参数
+func newSettingsPanel(metadata effects.Metadata, saved map[string]any) *settingsPanel {
+	mgr := i18n.GetManager()
+	panel := &settingsPanel{
+		effectID: metadata.ID,
+		title:    mgr.GetEffectName(metadata.Name, metadata.NameEN),
+	}
+
+	factory, err := effects.Get(metadata.ID)
+	if err != nil {
+		panel.message = err.Error()
+		return panel
+	}
+
+	if ps := effects.ParamsOf(factory()); ps != nil {
+		panel.defaults = ps.Values()
+	}
+	panel.params = effects.ParamsOf(factory())
+	if panel.params == nil {
+		return panel
+	}
+
+	effects.ApplySavedParams(panel.params, saved, "")
+
+	return panel
+}
+
+// list 返回面板中的参数
+func (p *settingsPanel) list() []effects.Param {
+	if p.params == nil {
+		return nil
+	}
+	return p.params.Params()
+}
+
+// current 返回光标所在的参数
+func (p *settingsPanel) current() (effects.Param, bool) {
+	list := p.list()
+	if p.cursor < 0 || p.cursor >= len(list) {
+		return effects.Param{}, false
+	}
+	return list[p.cursor], true
+}
+
+// overrides 返回与默认值不同的参数
+func (p *settingsPanel) overrides() map[string]any {
+	values := make(map[string]any)
+	if p.params == nil {
+		return values
+	}
+
+	for name, value := range p.params.Values() {
+		if value != p.defaults[name] {
+			values[name] = value
+		}
+	}
+	return values
+}
+
+// handleKey 处理面板中的按键，返回面板是否应关闭
+func (p *settingsPanel) handleKey(event *tcell.EventKey, store *config.Store) bool {
+	if p.editing {
+		p.handleEditKey(event)
+		return false
+	}
+
+	if event.Key() == tcell.KeyEscape {
+		return true
+	}
+
+	p.message = ""
+	list := p.list()
+
+	switch event.Key() {
+	case tcell.KeyUp:
+		p.move(-1)
+	case tcell.KeyDown:
+		p.move(1)
+	case tcell.KeyLeft:
+		p.adjust(-1)
+	case tcell.KeyRight:
+		p.adjust(1)
+	case tcell.KeyPgUp:
+		p.adjust(10)
+	case tcell.KeyPgDn:
+		p.adjust(-10)
+	case tcell.KeyEnter:
+		p.activate()
+	case tcell.KeyRune:
+		switch event.Rune() {
+		case 'k', 'K':
+			p.move(-1)
+		case 'j', 'J':
+			p.move(1)
+		case 'h', 'H':
+			p.adjust(-1)
+		case 'l', 'L':
+			p.adjust(1)
+		case 'r':
+			if param, ok := p.current(); ok {
+				p.params.Set(param.Name, p.defaults[param.Name])
+			}
+		case 'R':
+			for _, param := range list {
+				p.params.Set(param.Name, p.defaults[param.Name])
+			}
+		case 's', 'S':
+			if store == nil {
+				return true
+			}
+			if err := store.SetEffectParams(p.effectID, p.overrides()); err != nil {
+				p.message = i18n.T(i18n.KeySettingsSaveError) + err.Error()
+				return false
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleEditKey 处理文本输入状态下的按键
+func (p *settingsPanel) handleEditKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		p.editing = false
+		p.message = ""
+	case tcell.KeyEnter:
+		param, ok := p.current()
+		if !ok {
+			p.editing = false
+			return
+		}
+		if err := p.params.Set(param.Name, string(p.input)); err != nil {
+			p.message = err.Error()
+			return
+		}
+		p.editing = false
+		p.message = ""
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+	case tcell.KeyCtrlU:
+		p.input = p.input[:0]
+	case tcell.KeyRune:
+		p.input = append(p.input, event.Rune())
+	}
+}
+
+// move 循环移动光标
+func (p *settingsPanel) move(delta int) {
+	n := len(p.list())
+	if n == 0 {
+		return
+	}
+	p.cursor = (p.cursor + delta + n) % n
+}
+
+// activate 按 Enter 时的操作：布尔值切换，枚举循环，其他类型进入文本输入
+func (p *settingsPanel) activate() {
+	param, ok := p.current()
+	if !ok {
+		return
+	}
+
+	switch param.Type {
+	case effects.ParamBool, effects.ParamEnum:
+		p.adjust(1)
+	default:
+		value, _ := p.params.Get(param.Name)
+		p.input = []rune(effects.FormatParamValue(value))
+		p.editing = true
+	}
+}
+
+// adjust 按步数调整当前参数：数值按步长增减，枚举和颜色循环切换，布尔值取反
+func (p *settingsPanel) adjust(steps int) {
+	param, ok := p.current()
+	if !ok {
+		return
+	}
+
+	value, _ := p.params.Get(param.Name)
+	var next any
+
+	switch param.Type {
+	case effects.ParamInt, effects.ParamFloat:
+		next = stepValue(param, value, steps)
+	case effects.ParamBool:
+		next = !value.(bool)
+	case effects.ParamEnum:
+		next = cycle(param.Options, value.(string), steps)
+	case effects.ParamColor:
+		next = cycle(colorChoices, value.(string), steps)
+	default:
+		return
+	}
+
+	p.params.Set(param.Name, next)
+}
+
+// stepValue 按步长调整数值并限制在取值范围内
+func stepValue(param effects.Param, value any, steps int) any {
+	var n float64
+	switch v := value.(type) {
+	case int:
+		n = float64(v)
+	case float64:
+		n = v
+	}
+
+	n += param.StepSize() * float64(steps)
+	if param.Ranged() {
+		n = math.Max(param.Min, math.Min(param.Max, n))
+	}
+
+	if param.Type == effects.ParamInt {
+		return int(math.Round(n))
+	}
+	// 避免浮点累加误差（如 0.30000000000000004）
+	return math.Round(n*1e6) / 1e6
+}
+
+// cycle 在选项中循环移动，当前值不在选项中时从第一项开始
+func cycle(options []string, current string, steps int) string {
+	if len(options) == 0 {
+		return current
+	}
+
+	index := -1
+	for i, option := range options {
+		if option == current {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return options[0]
+	}
+
+	n := len(options)
+	return options[((index+steps)%n+n)%n]
+}
+
+// renderSettings 在选择器上方绘制设置面板
+func (s *Selector) renderSettings(p *settingsPanel) {
+	mgr := i18n.GetManager()
+	list := p.list()
+
+	// 面板尺寸：参数行 + 上下边框、两个空行、状态和提示各占一行
+	width := min(s.width-4, 72)
+	rows := max(1, len(list))
+	height := min(s.height-2, rows+6)
+	visible := height - 6
+	if width < 20 || visible < 1 {
+		return
+	}
+
+	x0 := (s.width - width) / 2
+	y0 := (s.height - height) / 2
+
+	border := s.theme.Style(s.theme.UI.Accent)
+	text := s.theme.Style(s.theme.UI.Text)
+	dim := s.theme.Style(s.theme.UI.Dim)
+	highlight := s.theme.Style(s.theme.UI.Accent).Bold(true)
+
+	// 背景与边框
+	s.drawBox(x0, y0, width, height, border)
+
+	title := " " + mgr.T(i18n.KeySettingsTitle) + ": " + p.title + " "
+	s.drawText(x0+2, y0, title, highlight)
+
+	if len(list) == 0 {
+		message := p.message
+		if message == "" {
+			message = mgr.T(i18n.KeySettingsEmpty)
+		}
+		s.drawText(x0+3, y0+2, message, dim)
+		s.drawText(x0+3, y0+height-2, "Esc", dim)
+		return
+	}
+
+	// 保持光标可见
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+visible {
+		p.offset = p.cursor - visible + 1
+	}
+
+	labelWidth := 0
+	for _, param := range list {
+		labelWidth = max(labelWidth, uniseg.StringWidth(mgr.GetEffectName(param.Label, param.LabelEN)))
+	}
+
+	for i := p.offset; i < len(list) && i < p.offset+visible; i++ {
+		param := list[i]
+		y := y0 + 2 + i - p.offset
+
+		label := mgr.GetEffectName(param.Label, param.LabelEN)
+		style := text
+		prefix := "  "
+		if i == p.cursor {
+			style = highlight
+			prefix = "> "
+		}
+		s.drawText(x0+2, y, prefix+label, style)
+
+		x := x0 + 4 + labelWidth + 2
+		value, _ := p.params.Get(param.Name)
+		if p.editing && i == p.cursor {
+			s.drawText(x, y, string(p.input)+"_", highlight)
+			continue
+		}
+		s.drawParamValue(x, y, x0+width-2-x, param, value, i == p.cursor)
+
+		if value != p.defaults[param.Name] {
+			s.drawText(x0+width-3, y, "*", dim)
+		}
+	}
+
+	// 滚动提示
+	if p.offset > 0 {
+		s.drawText(x0+width-3, y0+1, "↑", dim)
+	}
+	if p.offset+visible < len(list) {
+		s.drawText(x0+width-3, y0+2+visible, "↓", dim)
+	}
+
+	if p.message != "" {
+		s.drawText(x0+3, y0+height-3, p.message, s.theme.Style(s.theme.UI.Error))
+	}
+
+	hints := mgr.T(i18n.KeySettingsHints)
+	if p.editing {
+		hints = mgr.T(i18n.KeySettingsEditHints)
+	}
+	s.drawText(x0+3, y0+height-2, hints, dim)
+}
+
+// drawParamValue 绘制参数的控件：数值为滑块，枚举为选项，布尔值为复选框，颜色带色块
+func (s *Selector) drawParamValue(x, y, width int, param effects.Param, value any, selected bool) {
+	text := s.theme.Style(s.theme.UI.Text)
+	if selected {
+		text = text.Bold(true)
+	}
+	dim := s.theme.Style(s.theme.UI.Dim)
+	formatted := effects.FormatParamValue(value)
+
+	switch param.Type {
+	case effects.ParamInt, effects.ParamFloat:
+		if !param.Ranged() || width < sliderWidth+8 {
+			s.drawText(x, y, "◀ "+formatted+" ▶", text)
+			return
+		}
+
+		var n float64
+		switch v := value.(type) {
+		case int:
+			n = float64(v)
+		case float64:
+			n = v
+		}
+		filled := int(math.Round((n - param.Min) / (param.Max - param.Min) * sliderWidth))
+		filled = max(0, min(sliderWidth, filled))
+
+		bar := s.theme.Style(s.theme.UI.Accent)
+		s.drawText(x, y, strings.Repeat("█", filled), bar)
+		s.drawText(x+filled, y, strings.Repeat("─", sliderWidth-filled), dim)
+		s.drawText(x+sliderWidth+2, y, formatted, text)
+
+	case effects.ParamEnum:
+		s.drawText(x, y, "◀ "+formatted+" ▶", text)
+
+	case effects.ParamBool:
+		box := "[ ]"
+		if value == true {
+			box = "[x]"
+		}
+		s.drawText(x, y, box, text)
+
+	case effects.ParamColor:
+		s.drawText(x, y, "██", s.theme.Style(tcell.GetColor(formatted)))
+		s.drawText(x+3, y, "◀ "+formatted+" ▶", text)
+
+	default:
+		// 过长的文本截断显示
+		runes := []rune(formatted)
+		for uniseg.StringWidth(string(runes)) > width && len(runes) > 0 {
+			runes = runes[:len(runes)-1]
+		}
+		s.drawText(x, y, string(runes), text)
+	}
+}
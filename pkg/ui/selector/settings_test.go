@@ -0,0 +1,155 @@
+package selector
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/config"
+	"github.com/symbolmove/symbol_move/pkg/effects"
+	_ "github.com/symbolmove/symbol_move/pkg/effects/matrix-rain"
+)
+
+func newTestStore(t *testing.T) *config.Store {
+	t.Helper()
+
+	store, err := config.Open(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func newTestSelector(t *testing.T) *Selector {
+	t.Helper()
+
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatal(err)
+	}
+	screen.SetSize(80, 30)
+	t.Cleanup(screen.Fini)
+
+	sel := New(screen)
+	t.Cleanup(sel.StopPreview)
+	for i, metadata := range sel.effectList {
+		if metadata.ID == "matrix-rain" {
+			sel.selectedIdx = i
+		}
+	}
+	return sel
+}
+
+// press 依次发送按键，字符串中的字符作为普通按键发送
+func press(sel *Selector, keys ...any) {
+	for _, key := range keys {
+		switch k := key.(type) {
+		case tcell.Key:
+			sel.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone))
+		case string:
+			for _, r := range k {
+				sel.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+			}
+		}
+	}
+}
+
+func TestSettingsPanel(t *testing.T) {
+	sel := newTestSelector(t)
+	store := newTestStore(t)
+	sel.SetConfig(store)
+
+	// 打开面板，charset: mixed -> custom，trail: 15 -> 16，再输入 25
+	press(sel, "s", tcell.KeyRight, "jjjj", tcell.KeyRight)
+	press(sel, tcell.KeyEnter, tcell.KeyBackspace2, tcell.KeyBackspace2, "25", tcell.KeyEnter)
+
+	sel.Render()
+	if sel.settings == nil {
+		t.Fatal("Expected settings panel to be open")
+	}
+
+	press(sel, "s")
+	if sel.settings != nil {
+		t.Fatal("Expected settings panel to close after saving")
+	}
+
+	want := map[string]any{"charset": "custom", "trail": 25}
+	if got := store.EffectParams("matrix-rain"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected saved params %v, got %v", want, got)
+	}
+
+	// 重新打开时载入已保存的参数，R 恢复全部默认值后保存会清除设置
+	press(sel, "s")
+	if value, _ := sel.settings.params.Get("trail"); value != 25 {
+		t.Errorf("Expected saved trail 25, got %v", value)
+	}
+	press(sel, "R", "s")
+	if store.EffectParams("matrix-rain") != nil {
+		t.Error("Expected defaults to clear saved params")
+	}
+}
+
+func TestSettingsPanelRejectsInvalidInput(t *testing.T) {
+	sel := newTestSelector(t)
+	store := newTestStore(t)
+	sel.SetConfig(store)
+
+	press(sel, "s", "jjjj", tcell.KeyEnter, "0", tcell.KeyEnter)
+	if !sel.settings.editing || sel.settings.message == "" {
+		t.Fatal("Expected out-of-range input to keep editing with an error message")
+	}
+
+	press(sel, tcell.KeyEscape, tcell.KeyEscape)
+	if sel.settings != nil {
+		t.Fatal("Expected Esc to close the panel")
+	}
+	if params := store.EffectParams("matrix-rain"); params != nil {
+		t.Errorf("Expected nothing saved after cancelling, got %v", params)
+	}
+}
+
+func TestStepValue(t *testing.T) {
+	p := effects.Param{Name: "x", Type: effects.ParamFloat, Min: 0, Max: 1, Step: 0.1}
+
+	value := any(0.0)
+	for i := 0; i < 3; i++ {
+		value = stepValue(p, value, 1)
+	}
+	if value != 0.3 {
+		t.Errorf("Expected 0.3, got %v", value)
+	}
+	if value = stepValue(p, value, 100); value != 1.0 {
+		t.Errorf("Expected value clamped to 1, got %v", value)
+	}
+
+	if got := cycle([]string{"a", "b", "c"}, "a", -1); got != "c" {
+		t.Errorf("Expected cycle to wrap to c, got %s", got)
+	}
+}
+
+func TestRenderSettings(t *testing.T) {
+	sel := newTestSelector(t)
+	press(sel, "s")
+	sel.Render()
+
+	screen := sel.screen.(tcell.SimulationScreen)
+	cells, width, _ := screen.GetContents()
+
+	var b strings.Builder
+	for i, cell := range cells {
+		if len(cell.Runes) > 0 {
+			b.WriteRune(cell.Runes[0])
+		}
+		if (i+1)%width == 0 {
+			b.WriteByte('\n')
+		}
+	}
+
+	for _, want := range []string{"字符集", "mixed", "尾迹长度", "┌"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("Expected %q in rendered panel:\n%s", want, b.String())
+		}
+	}
+}
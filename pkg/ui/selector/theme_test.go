@@ -0,0 +1,43 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/theme"
+)
+
+func TestTheme(t *testing.T) {
+	sel := newTestSelector(t)
+	sel.SetTheme(theme.Solarized)
+	sel.Render()
+
+	screen := sel.screen.(tcell.SimulationScreen)
+	ui := theme.Solarized.UI
+
+	// 空白处也填充主题背景
+	_, _, style, _ := screen.GetContent(0, 0)
+	if _, bg, _ := style.Decompose(); bg != ui.Background {
+		t.Errorf("Expected background %v, got %v", ui.Background, bg)
+	}
+
+	// 标题使用主题的标题颜色
+	width, _ := screen.Size()
+	found := false
+	for x := range width {
+		r, _, style, _ := screen.GetContent(x, 2)
+		if strings.TrimSpace(string(r)) == "" {
+			continue
+		}
+		fg, bg, attr := style.Decompose()
+		if fg != ui.Title || bg != ui.Background || attr&tcell.AttrBold == 0 {
+			t.Fatalf("Expected bold title colour at %d, got %v on %v", x, fg, bg)
+		}
+		found = true
+		break
+	}
+	if !found {
+		t.Error("Expected the title to be drawn")
+	}
+}
@@ -0,0 +1,235 @@
+package viewport
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Rect 屏幕上的矩形区域（单位为单元格）
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Empty 判断区域是否为空
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Contains 判断区域内的相对坐标 (x, y) 是否在区域范围内
+func (r Rect) Contains(x, y int) bool {
+	return x >= 0 && y >= 0 && x < r.Width && y < r.Height
+}
+
+// Intersect 返回两个区域的交集
+func (r Rect) Intersect(other Rect) Rect {
+	x0, y0 := max(r.X, other.X), max(r.Y, other.Y)
+	x1, y1 := min(r.X+r.Width, other.X+other.Width), min(r.Y+r.Height, other.Y+other.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{X: x0, Y: y0}
+	}
+	return Rect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
+
+// Layout 按终端尺寸比例描述的区域（0 到 1），终端尺寸确定后换算为 Rect
+type Layout struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+// Full 占满整个屏幕的布局
+var Full = Layout{Width: 1, Height: 1}
+
+// Rect 按终端尺寸换算为单元格区域
+// 边界按四舍五入取整，相邻布局换算后的区域既不重叠也没有缝隙
+func (l Layout) Rect(width, height int) Rect {
+	x0, x1 := scale(l.X, width), scale(l.X+l.Width, width)
+	y0, y1 := scale(l.Y, height), scale(l.Y+l.Height, height)
+	return Rect{X: x0, Y: y0, Width: max(x1-x0, 0), Height: max(y1-y0, 0)}
+}
+
+// scale 把比例换算为单元格坐标
+func scale(f float64, size int) int {
+	return min(max(int(f*float64(size)+0.5), 0), size)
+}
+
+// Columns 把屏幕从左到右等分为 n 列
+func Columns(n int) []Layout {
+	layouts := make([]Layout, n)
+	for i := range layouts {
+		layouts[i] = Layout{X: float64(i) / float64(n), Width: 1 / float64(n), Height: 1}
+	}
+	return layouts
+}
+
+// Rows 把屏幕从上到下等分为 n 行
+func Rows(n int) []Layout {
+	layouts := make([]Layout, n)
+	for i := range layouts {
+		layouts[i] = Layout{Y: float64(i) / float64(n), Width: 1, Height: 1 / float64(n)}
+	}
+	return layouts
+}
+
+// Screen 区域屏幕
+// 包装任意 tcell.Screen，只暴露其中的一个矩形区域：Size 返回区域的尺寸，
+// 绘制坐标相对于区域左上角，超出区域的内容被裁掉，因此特效无需修改即可在窗格中运行。
+// 事件、Show 与 Sync 等其余方法直接转发给底层屏幕。
+type Screen struct {
+	tcell.Screen
+
+	mu   sync.Mutex
+	rect Rect
+}
+
+// New 创建显示在 screen 的 rect 区域中的屏幕，rect 超出屏幕的部分被裁掉
+func New(screen tcell.Screen, rect Rect) *Screen {
+	s := &Screen{Screen: screen}
+	s.SetRect(rect)
+	return s
+}
+
+// Rect 返回区域在底层屏幕上的位置
+func (s *Screen) Rect() Rect {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rect
+}
+
+// SetRect 移动区域或修改区域的大小
+func (s *Screen) SetRect(rect Rect) {
+	width, height := s.Screen.Size()
+	rect = rect.Intersect(Rect{Width: width, Height: height})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rect = rect
+}
+
+// Size 返回区域的尺寸
+func (s *Screen) Size() (int, int) {
+	rect := s.Rect()
+	return rect.Width, rect.Height
+}
+
+// translate 把区域内的相对坐标换算为底层屏幕坐标，越界时返回 false
+func (s *Screen) translate(x, y int) (int, int, bool) {
+	rect := s.Rect()
+	if !rect.Contains(x, y) {
+		return 0, 0, false
+	}
+	return rect.X + x, rect.Y + y, true
+}
+
+// SetContent 在区域内设置单元格
+func (s *Screen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	s.Put(x, y, string(append([]rune{primary}, combining...)), style)
+}
+
+// Put 在区域内写入 str 的第一个字素，返回剩余的字符串和显示宽度
+// 宽字符在区域右边缘放不下时不写入
+func (s *Screen) Put(x, y int, str string, style tcell.Style) (string, int) {
+	rect := s.Rect()
+	if !rect.Contains(x, y) {
+		return str, 0
+	}
+
+	remain, width := s.Screen.Put(rect.X+x, rect.Y+y, str, style)
+	if x+width > rect.Width {
+		// 宽字符跨出了区域，改为空白以免覆盖相邻区域
+		s.Screen.Put(rect.X+x, rect.Y+y, " ", style)
+	}
+	return remain, width
+}
+
+// PutStr 从 (x, y) 开始写入字符串，超出区域的部分被裁掉
+func (s *Screen) PutStr(x, y int, str string) {
+	s.PutStrStyled(x, y, str, tcell.StyleDefault)
+}
+
+// PutStrStyled 以指定样式从 (x, y) 开始写入字符串，超出区域的部分被裁掉
+func (s *Screen) PutStrStyled(x, y int, str string, style tcell.Style) {
+	width, _ := s.Size()
+	for str != "" && x < width {
+		var w int
+		str, w = s.Put(x, y, str, style)
+		if w == 0 {
+			break
+		}
+		x += w
+	}
+}
+
+// SetCell 在区域内设置单元格
+func (s *Screen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	if len(ch) > 0 {
+		s.Put(x, y, string(ch), style)
+	} else {
+		s.Put(x, y, " ", style)
+	}
+}
+
+// Get 读取区域内的单元格，越界时返回空白
+func (s *Screen) Get(x, y int) (string, tcell.Style, int) {
+	sx, sy, ok := s.translate(x, y)
+	if !ok {
+		return " ", tcell.StyleDefault, 1
+	}
+	return s.Screen.Get(sx, sy)
+}
+
+// GetContent 读取区域内的单元格，越界时返回空白
+func (s *Screen) GetContent(x, y int) (rune, []rune, tcell.Style, int) {
+	sx, sy, ok := s.translate(x, y)
+	if !ok {
+		return ' ', nil, tcell.StyleDefault, 1
+	}
+	return s.Screen.GetContent(sx, sy)
+}
+
+// Clear 清空区域
+func (s *Screen) Clear() {
+	s.Fill(' ', tcell.StyleDefault)
+}
+
+// Fill 以指定字符和样式填满区域
+func (s *Screen) Fill(r rune, style tcell.Style) {
+	rect := s.Rect()
+	for y := 0; y < rect.Height; y++ {
+		for x := 0; x < rect.Width; x++ {
+			s.Screen.SetContent(rect.X+x, rect.Y+y, r, nil, style)
+		}
+	}
+}
+
+// ShowCursor 在区域内显示光标，越界时隐藏光标
+func (s *Screen) ShowCursor(x, y int) {
+	sx, sy, ok := s.translate(x, y)
+	if !ok {
+		s.Screen.HideCursor()
+		return
+	}
+	s.Screen.ShowCursor(sx, sy)
+}
+
+// HideCursor 隐藏光标
+func (s *Screen) HideCursor() {
+	s.Screen.HideCursor()
+}
+
+// LockRegion 锁定或解锁区域内的单元格
+func (s *Screen) LockRegion(x, y, width, height int, lock bool) {
+	rect := s.Rect()
+	clip := Rect{X: x, Y: y, Width: width, Height: height}.Intersect(Rect{Width: rect.Width, Height: rect.Height})
+	if clip.Empty() {
+		return
+	}
+	s.Screen.LockRegion(rect.X+clip.X, rect.Y+clip.Y, clip.Width, clip.Height, lock)
+}
+
+// Resize 区域屏幕的大小由 SetRect 决定，忽略 Resize
+func (s *Screen) Resize(int, int, int, int) {}
+
+// SetSize 区域屏幕的大小由 SetRect 决定，忽略 SetSize
+func (s *Screen) SetSize(int, int) {}
@@ -0,0 +1,78 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/symbolmove/symbol_move/pkg/frame"
+	"github.com/symbolmove/symbol_move/pkg/headless"
+)
+
+func TestScreenClipsAndOffsets(t *testing.T) {
+	screen, err := headless.NewScreen(6, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+
+	screen.Fill('.', tcell.StyleDefault)
+	pane := New(screen, Rect{X: 2, Y: 1, Width: 3, Height: 5})
+
+	if w, h := pane.Size(); w != 3 || h != 2 {
+		t.Fatalf("Expected size clipped to 3x2, got %dx%d", w, h)
+	}
+
+	pane.Fill('#', tcell.StyleDefault)
+	pane.SetContent(0, 0, 'a', nil, tcell.StyleDefault)
+	pane.SetContent(3, 0, 'x', nil, tcell.StyleDefault)
+	pane.SetContent(-1, 1, 'x', nil, tcell.StyleDefault)
+	pane.PutStr(1, 1, "bcdef")
+
+	want := "" +
+		"......\n" +
+		"..a##.\n" +
+		"..#bc.\n"
+	if got := frame.Capture(screen).Text(); got != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	if r, _, _, _ := pane.GetContent(0, 0); r != 'a' {
+		t.Errorf("GetContent should read relative to the region, got %q", r)
+	}
+}
+
+func TestWideRuneAtEdge(t *testing.T) {
+	screen, err := headless.NewScreen(4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer screen.Fini()
+
+	screen.Fill('.', tcell.StyleDefault)
+	pane := New(screen, Rect{Width: 2, Height: 1})
+	pane.SetContent(1, 0, '世', nil, tcell.StyleDefault)
+
+	if got := frame.Capture(screen).Text(); got != ". ..\n" {
+		t.Errorf("Wide rune should not spill into the neighbouring region, got %q", got)
+	}
+}
+
+func TestLayoutRect(t *testing.T) {
+	for _, width := range []int{7, 80, 81} {
+		x := 0
+		for _, layout := range Columns(3) {
+			rect := layout.Rect(width, 10)
+			if rect.X != x || rect.Height != 10 {
+				t.Errorf("width %d: expected column at %d, got %+v", width, x, rect)
+			}
+			x += rect.Width
+		}
+		if x != width {
+			t.Errorf("width %d: columns cover %d cells", width, x)
+		}
+	}
+
+	if rect := Full.Rect(30, 8); rect != (Rect{Width: 30, Height: 8}) {
+		t.Errorf("Full layout should cover the screen, got %+v", rect)
+	}
+}